@@ -0,0 +1,77 @@
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tsrand
+
+// Import standard library packages and tserr
+import (
+	"fmt"       // fmt
+	"math/bits" // math/bits
+
+	"github.com/thorstenrie/tserr" // tserr
+)
+
+// Uint64n returns a uniformly distributed random number in the half-open interval [0,n) using src. It is based on the
+// nearly divisionless multiply-shift method of Lemire with bias correction: a division is only performed with a probability
+// lower than n/2^64, which is significantly faster than the rejection method of rand.Intn. It panics, if n is 0.
+func Uint64n(src Source, n uint64) uint64 {
+	// Panic, if n is 0
+	if n == 0 {
+		panic(tserr.Higher(&tserr.HigherArgs{Var: "n", Actual: 0, LowerBound: 1}))
+	}
+	// Return random number
+	return uint64n(src, n)
+}
+
+// Uint32n returns a uniformly distributed random number in the half-open interval [0,n) using the upper 32 bits of a 64-bit
+// random value of src. It is based on the method of Lemire like Uint64n. It panics, if n is 0.
+func Uint32n(src Source, n uint32) uint32 {
+	// Panic, if n is 0
+	if n == 0 {
+		panic(tserr.Higher(&tserr.HigherArgs{Var: "n", Actual: 0, LowerBound: 1}))
+	}
+	// Multiply a random 32-bit value with n, the upper 32 bits are the result
+	m := uint64(uint32(src.Uint64()>>32)) * uint64(n)
+	// Reject values of the lower 32 bits, which would result in a bias. The division is only needed, if the lower 32 bits are lower than n.
+	if uint32(m) < n {
+		t := -n % n
+		for uint32(m) < t {
+			m = uint64(uint32(src.Uint64()>>32)) * uint64(n)
+		}
+	}
+	// Return the upper 32 bits
+	return uint32(m >> 32)
+}
+
+// IntRange returns a uniformly distributed random number in the closed interval [lo,hi] using src based on Uint64n. The interval may contain
+// negative numbers and may span the full range of int64. It panics, if hi is lower than lo.
+func IntRange(src Source, lo, hi int64) int64 {
+	// Panic, if hi is lower than lo
+	if hi < lo {
+		panic(tserr.Higher(&tserr.HigherArgs{Var: fmt.Sprintf("hi of range [%d,%d]", lo, hi), Actual: hi, LowerBound: lo}))
+	}
+	// Number of values in the range in two's complement arithmetic, which is 0 for the full range of int64
+	n := uint64(hi) - uint64(lo) + 1
+	// Return a random 64-bit value for the full range of int64
+	if n == 0 {
+		return int64(src.Uint64())
+	}
+	// Return random number in [lo,hi] in two's complement arithmetic
+	return int64(uint64(lo) + uint64n(src, n))
+}
+
+// uint64n returns a uniformly distributed random number in the half-open interval [0,n) using r based on the multiply-shift method
+// of Lemire. n must be higher than 0.
+func uint64n(r Random, n uint64) uint64 {
+	// Multiply a random 64-bit value with n, the upper 64 bits are the result
+	hi, lo := bits.Mul64(r.Uint64(), n)
+	// Reject values of the lower 64 bits, which would result in a bias. The division is only needed, if the lower 64 bits are lower than n.
+	if lo < n {
+		t := -n % n
+		for lo < t {
+			hi, lo = bits.Mul64(r.Uint64(), n)
+		}
+	}
+	// Return the upper 64 bits
+	return hi
+}
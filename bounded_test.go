@@ -0,0 +1,186 @@
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tsrand
+
+// Import standard library packages and tserr
+import (
+	"fmt"       // fmt
+	"math"      // math
+	"math/rand" // math/rand
+	"testing"   // testing
+
+	"github.com/thorstenrie/tserr" // tserr
+)
+
+// testSeqSource is a Source returning the values of v in order and repeating them afterwards.
+type testSeqSource struct {
+	v []uint64 // returned values
+	i int      // index of the next returned value
+}
+
+// Seed is empty for testSeqSource.
+func (s *testSeqSource) Seed(int64) {}
+
+// Uint64 returns the next value of v.
+func (s *testSeqSource) Uint64() uint64 {
+	v := s.v[s.i%len(s.v)]
+	s.i++
+	return v
+}
+
+// Int63 returns the first 63 bits of the next value of v.
+func (s *testSeqSource) Int63() int64 { return int64(s.Uint64() >> 1) }
+
+// Assert is empty for testSeqSource.
+func (s *testSeqSource) Assert() {}
+
+// Err returns nil for testSeqSource.
+func (s *testSeqSource) Err() error { return nil }
+
+// testPanic tests that f panics. The test fails, if f does not panic.
+func testPanic(t *testing.T, name string, f func()) {
+	// Panic if t is nil
+	if t == nil {
+		panic("nil pointer")
+	}
+	defer func() {
+		// The test fails, if f does not panic
+		if recover() == nil {
+			t.Error(tserr.NilFailed(name + " panic"))
+		}
+	}()
+	f()
+}
+
+// TestUint64n tests that Uint64n returns values in [0,n) for each built-in source and edge cases of n, each value of [0,testIntn) with
+// equal probability, rejects biased values and panics for n = 0.
+func TestUint64n(t *testing.T) {
+	for name, f := range testBuiltinSources() {
+		src := f()
+		for _, n := range []uint64{1, 2, 3, uint64(testIntn), 1 << 63, 1<<63 + 1, math.MaxUint64} {
+			for i := 0; i < testCalls; i++ {
+				// The test fails, if the value is not in [0,n)
+				if v := Uint64n(src, n); v >= n {
+					t.Fatal(tserr.Higher(&tserr.HigherArgs{Var: fmt.Sprintf("n of %s", name), Actual: int64(n), LowerBound: int64(v + 1)}))
+				}
+			}
+		}
+	}
+	// The test fails, if Uint64n with n = 2^63 does not return values from both halves of [0,2^63)
+	src := testSampleSource()
+	counts := make(map[int]int)
+	for i := 0; i < testCalls; i++ {
+		counts[int(Uint64n(src, 1<<63)>>62)]++
+	}
+	testChiSquare(t, "Uint64n with n = 2^63", counts, map[int]float64{0: float64(testCalls) / 2, 1: float64(testCalls) / 2})
+	// The test fails, if the values of [0,testIntn) are not equally likely
+	counts = make(map[int]int)
+	want := make(map[int]float64)
+	for i := 0; i < testIntn; i++ {
+		want[i] = float64(testItr) / float64(testIntn)
+	}
+	for i := 0; i < testItr; i++ {
+		counts[int(Uint64n(src, uint64(testIntn)))]++
+	}
+	testChiSquare(t, "Uint64n", counts, want)
+	// The test fails, if the biased random value 0 for n = 3 is not rejected
+	if v := Uint64n(&testSeqSource{v: []uint64{0, math.MaxUint64}}, 3); v != 2 {
+		t.Error(tserr.Equal(&tserr.EqualArgs{Var: "Uint64n of rejected value", Actual: int64(v), Want: 2}))
+	}
+	// The test fails, if Uint64n does not panic for n = 0
+	testPanic(t, "Uint64n with n = 0", func() { Uint64n(src, 0) })
+}
+
+// TestUint32n tests that Uint32n returns values in [0,n) for edge cases of n, each value of [0,testIntn) with equal probability,
+// rejects biased values and panics for n = 0.
+func TestUint32n(t *testing.T) {
+	src := testSampleSource()
+	for _, n := range []uint32{1, 2, 3, uint32(testIntn), 1 << 31, 1<<31 + 1, math.MaxUint32} {
+		for i := 0; i < testCalls; i++ {
+			// The test fails, if the value is not in [0,n)
+			if v := Uint32n(src, n); v >= n {
+				t.Fatal(tserr.Higher(&tserr.HigherArgs{Var: "n", Actual: int64(n), LowerBound: int64(v) + 1}))
+			}
+		}
+	}
+	// The test fails, if the values of [0,testIntn) are not equally likely
+	counts, want := make(map[int]int), make(map[int]float64)
+	for i := 0; i < testIntn; i++ {
+		want[i] = float64(testItr) / float64(testIntn)
+	}
+	for i := 0; i < testItr; i++ {
+		counts[int(Uint32n(src, uint32(testIntn)))]++
+	}
+	testChiSquare(t, "Uint32n", counts, want)
+	// The test fails, if the biased random value 0 for n = 3 is not rejected
+	if v := Uint32n(&testSeqSource{v: []uint64{0, math.MaxUint64}}, 3); v != 2 {
+		t.Error(tserr.Equal(&tserr.EqualArgs{Var: "Uint32n of rejected value", Actual: int64(v), Want: 2}))
+	}
+	// The test fails, if Uint32n does not panic for n = 0
+	testPanic(t, "Uint32n with n = 0", func() { Uint32n(src, 0) })
+}
+
+// TestIntRange tests that IntRange returns values in [lo,hi] for negative, single value and full ranges, each value of a negative
+// range with equal probability and panics, if hi is lower than lo.
+func TestIntRange(t *testing.T) {
+	src := testSampleSource()
+	for _, r := range [][2]int64{{-3, 2}, {-10, -5}, {5, 5}, {math.MinInt64, math.MinInt64 + 1}, {math.MaxInt64 - 1, math.MaxInt64}, {-1, math.MaxInt64}, {math.MinInt64, math.MaxInt64}} {
+		for i := 0; i < testCalls; i++ {
+			// The test fails, if the value is not in [lo,hi]
+			if v := IntRange(src, r[0], r[1]); v < r[0] || v > r[1] {
+				t.Fatal(tserr.Return(&tserr.ReturnArgs{Op: fmt.Sprintf("IntRange of [%d,%d]", r[0], r[1]), Actual: fmt.Sprint(v), Want: "value in range"}))
+			}
+		}
+	}
+	// The test fails, if the full range does not return negative and positive values
+	counts := make(map[int]int)
+	for i := 0; i < testCalls; i++ {
+		if IntRange(src, math.MinInt64, math.MaxInt64) < 0 {
+			counts[0]++
+		} else {
+			counts[1]++
+		}
+	}
+	testChiSquare(t, "IntRange of full range", counts, map[int]float64{0: float64(testCalls) / 2, 1: float64(testCalls) / 2})
+	// The test fails, if the values of [-3,2] are not equally likely
+	counts = make(map[int]int)
+	want := make(map[int]float64)
+	for i := -3; i <= 2; i++ {
+		want[i] = float64(testItr) / 6
+	}
+	for i := 0; i < testItr; i++ {
+		counts[int(IntRange(src, -3, 2))]++
+	}
+	testChiSquare(t, "IntRange", counts, want)
+	// The test fails, if IntRange does not panic, if hi is lower than lo
+	testPanic(t, "IntRange of [1,0]", func() { IntRange(src, 1, 0) })
+}
+
+// BenchmarkUint64n performs a benchmark on Uint64n compared to rand.Intn and Uint32n compared to rand.Int31n for each built-in source.
+func BenchmarkUint64n(b *testing.B) {
+	for name, f := range testBuiltinSources() {
+		src := f()
+		rnd := rand.New(src)
+		b.Run(name+"/Uint64n", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				Uint64n(src, 1e9)
+			}
+		})
+		b.Run(name+"/Intn", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				rnd.Intn(1e9)
+			}
+		})
+		b.Run(name+"/Uint32n", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				Uint32n(src, 1e9)
+			}
+		})
+		b.Run(name+"/Int31n", func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				rnd.Int31n(1e9)
+			}
+		})
+	}
+}
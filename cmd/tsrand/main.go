@@ -0,0 +1,245 @@
+// Command tsrand writes random 64-bit values of a tsrand source to stdout, e.g., to pipe them into test suites like PractRand or dieharder
+// or to produce test vectors.
+//
+// Usage:
+//
+//	tsrand [-source name] [-seed s] [-format raw|hex|dec] [-n count]
+//
+// The flags are:
+//
+//	-source name
+//		name of the source, default crypto. Run tsrand -h for the list of sources.
+//	-seed s
+//		seed of type int64 of the source. Whether the output is reproducible depends on the source:
+//		crypto, bufferedcrypto and pseudo are seeded from crypto/rand and cannot be seeded, their output is never reproducible.
+//		chacha8 and chacha20 are seeded with a key read from crypto/rand, if -seed is omitted. They require -seed for a reproducible output.
+//		All other sources use their fixed default seed, if -seed is omitted, and their output is reproducible with and without -seed.
+//	-format raw|hex|dec
+//		format of the output, default raw. raw writes 8 bytes per value in little-endian byte order,
+//		hex writes 16 hexadecimal digits and dec the decimal value per line.
+//	-n count
+//		number of values, default 0. If count is 0, tsrand writes values infinitely.
+//
+// Examples:
+//
+//	tsrand -source pcg64 -seed 1 | RNG_test stdin64
+//	tsrand -source mt64 | dieharder -a -g 200
+//	tsrand -source chacha20 -seed 42 -format hex -n 10
+//
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package main
+
+// Import standard library packages, tserr and tsrand
+import (
+	"bufio"           // bufio
+	"encoding/binary" // encoding/binary
+	"errors"          // errors
+	"flag"            // flag
+	"fmt"             // fmt
+	"io"              // io
+	"os"              // os
+	"slices"          // slices
+	"strconv"         // strconv
+	"strings"         // strings
+
+	"github.com/thorstenrie/tserr"  // tserr
+	"github.com/thorstenrie/tsrand" // tsrand
+)
+
+// cmdc holds the constants of the command
+var (
+	cmdc = struct {
+		check int // number of values between the checks of the error of the source
+	}{
+		check: 4096, // number of values between the checks of the error of the source
+	}
+)
+
+// constructor returns a new random number generator seeded with seed, if seeded is true.
+type constructor func(seed int64, seeded bool) (tsrand.Random, error)
+
+// sources maps the names of the sources to their constructors
+var (
+	sources = map[string]constructor{
+		"crypto":               unseedable("crypto", func() tsrand.Random { return tsrand.NewCryptoSource() }),
+		"bufferedcrypto":       unseedable("bufferedcrypto", func() tsrand.Random { return tsrand.NewBufferedCryptoSource(0) }),
+		"pseudo":               pseudo,
+		"deterministic":        deterministic,
+		"simple":               seedable(tsrand.NewSimpleSource),
+		"mt32":                 seedable(tsrand.NewMT32Source),
+		"mt64":                 seedable(tsrand.NewMT64Source),
+		"pcg32":                seedable(tsrand.NewPCG32Source),
+		"pcg64":                seedable(tsrand.NewPCG64Source),
+		"xoshiro256starstar":   seedable(tsrand.NewXoshiro256StarStarSource),
+		"xoshiro256plus":       seedable(tsrand.NewXoshiro256PlusSource),
+		"xoroshiro128plusplus": seedable(tsrand.NewXoroshiro128PlusPlusSource),
+		"splitmix64":           seedable(tsrand.NewSplitMix64Source),
+		"chacha8":              seedable(tsrand.NewChaCha8Source),
+		"chacha20":             seedable(tsrand.NewChaCha20Source),
+		"philox":               seedable(tsrand.NewPhiloxSource),
+		"threefry":             seedable(tsrand.NewThreefrySource),
+	}
+)
+
+// formats maps the names of the output formats to functions appending the value v to b
+var (
+	formats = map[string]func(b []byte, v uint64) []byte{
+		"raw": binary.LittleEndian.AppendUint64,
+		"hex": appendHex,
+		"dec": func(b []byte, v uint64) []byte { return append(strconv.AppendUint(b, v, 10), '\n') },
+	}
+)
+
+// appendHex appends v as 16 lower-case hexadecimal digits with leading zeros and a newline to b. Unlike fmt.Appendf, it does not allocate.
+func appendHex(b []byte, v uint64) []byte {
+	for i := 60; i >= 0; i -= 4 {
+		b = append(b, "0123456789abcdef"[v>>i&0xf])
+	}
+	return append(b, '\n')
+}
+
+// seedable returns a constructor of the source returned by f, which is seeded, if a seed is provided.
+func seedable[S tsrand.Source](f func() S) constructor {
+	return func(seed int64, seeded bool) (tsrand.Random, error) {
+		src := f()
+		if seeded {
+			src.Seed(seed)
+		}
+		return src, nil
+	}
+}
+
+// unseedable returns a constructor of the random number generator with the name returned by f. The constructor returns an error,
+// if a seed is provided.
+func unseedable(name string, f func() tsrand.Random) constructor {
+	return func(seed int64, seeded bool) (tsrand.Random, error) {
+		if seeded {
+			return nil, tserr.Forbidden("seed of source " + name)
+		}
+		return f(), nil
+	}
+}
+
+// pseudo returns the pseudo-random number generator based on math/rand. It returns an error, if a seed is provided.
+func pseudo(seed int64, seeded bool) (tsrand.Random, error) {
+	if seeded {
+		return nil, tserr.Forbidden("seed of source pseudo")
+	}
+	r, e := tsrand.NewPseudoRandomRand()
+	if e != nil {
+		return nil, e
+	}
+	return r, nil
+}
+
+// deterministic returns the deterministic random number generator based on math/rand seeded with seed, if seeded is true.
+func deterministic(seed int64, seeded bool) (tsrand.Random, error) {
+	r, e := tsrand.NewDeterministicRand()
+	if e != nil {
+		return nil, e
+	}
+	if seeded {
+		r.Seed(seed)
+	}
+	return r, nil
+}
+
+// main runs the command with the command-line arguments and exits with the returned exit code.
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run parses the arguments args, writes the random values to stdout and errors to stderr. It returns the exit code 0 on success or for the help message,
+// 1 if the source is not available or writing fails and 2 if the arguments are invalid.
+func run(args []string, stdout, stderr io.Writer) int {
+	// Names of the sources for the usage message
+	names := make([]string, 0, len(sources))
+	for name := range sources {
+		names = append(names, name)
+	}
+	slices.Sort(names)
+	// Define and parse flags
+	fs := flag.NewFlagSet("tsrand", flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	source := fs.String("source", "crypto", "name of the source: "+strings.Join(names, ", "))
+	seed := fs.Int64("seed", 0, "seed of the source, required by chacha8 and chacha20 for a reproducible output, not supported by crypto, bufferedcrypto and pseudo")
+	format := fs.String("format", "raw", "format of the output: raw (8 bytes little-endian), hex or dec")
+	n := fs.Uint64("n", 0, "number of values, 0 for infinite output")
+	if e := fs.Parse(args); e != nil {
+		// The help message is not an error
+		if errors.Is(e, flag.ErrHelp) {
+			return 0
+		}
+		return 2
+	}
+	if fs.NArg() > 0 {
+		fmt.Fprintln(stderr, tserr.Forbidden("argument "+strconv.Quote(fs.Arg(0))))
+		return 2
+	}
+	// Check if seed is provided
+	seeded := false
+	fs.Visit(func(f *flag.Flag) { seeded = seeded || f.Name == "seed" })
+	// Retrieve constructor and format
+	c, ok := sources[*source]
+	if !ok {
+		fmt.Fprintln(stderr, tserr.NotExistent("source "+strconv.Quote(*source)))
+		return 2
+	}
+	f, ok := formats[*format]
+	if !ok {
+		fmt.Fprintln(stderr, tserr.NotExistent("format "+strconv.Quote(*format)))
+		return 2
+	}
+	r, e := c(*seed, seeded)
+	if e != nil {
+		fmt.Fprintln(stderr, e)
+		return 2
+	}
+	// Write the values
+	if e := write(stdout, r, f, *n); e != nil {
+		fmt.Fprintln(stderr, e)
+		return 1
+	}
+	return 0
+}
+
+// write writes n values of r in the format f to w. If n is 0, it writes values until writing fails. It returns an error, if the source
+// is not available or writing fails.
+func write(w io.Writer, r tsrand.Random, f func([]byte, uint64) []byte, n uint64) error {
+	// Check the availability of the source, if r is a Source
+	src, isSource := r.(tsrand.Source)
+	check := func() error {
+		if isSource && src.Err() != nil {
+			return tserr.NotAvailable(&tserr.NotAvailableArgs{S: "Source", Err: src.Err()})
+		}
+		return nil
+	}
+	if isSource {
+		src.Assert()
+	}
+	if e := check(); e != nil {
+		return e
+	}
+	// Write the values with a buffered writer
+	bw := bufio.NewWriter(w)
+	var b []byte
+	for i := uint64(1); n == 0 || i <= n; i++ {
+		// Reuse the buffer b for each value
+		b = f(b[:0], r.Uint64())
+		if _, e := bw.Write(b); e != nil {
+			return tserr.Op(&tserr.OpArgs{Op: "write", Fn: "stdout", Err: e})
+		}
+		// Check the error of the source periodically, since a failing source returns 0
+		if i%uint64(cmdc.check) == 0 {
+			if e := check(); e != nil {
+				return e
+			}
+		}
+	}
+	if e := bw.Flush(); e != nil {
+		return tserr.Op(&tserr.OpArgs{Op: "write", Fn: "stdout", Err: e})
+	}
+	return check()
+}
@@ -0,0 +1,202 @@
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package main
+
+// Import standard library packages, tserr and tsrand
+import (
+	"bytes"           // bytes
+	"encoding/binary" // encoding/binary
+	"errors"          // errors
+	"fmt"             // fmt
+	"io"              // io
+	"math/rand"       // math/rand
+	"slices"          // slices
+	"strconv"         // strconv
+	"strings"         // strings
+	"testing"         // testing
+
+	"github.com/thorstenrie/tserr"  // tserr
+	"github.com/thorstenrie/tsrand" // tsrand
+)
+
+// testFailWriter is an io.Writer failing after n bytes.
+type testFailWriter struct {
+	n int // number of remaining bytes before writing fails
+}
+
+// Write writes p, if there are enough remaining bytes, and fails otherwise.
+func (w *testFailWriter) Write(p []byte) (int, error) {
+	if len(p) > w.n {
+		return 0, io.ErrClosedPipe
+	}
+	w.n -= len(p)
+	return len(p), nil
+}
+
+// testRun runs the command with args and returns the exit code and the output to stdout and stderr.
+func testRun(args ...string) (int, string, string) {
+	var stdout, stderr bytes.Buffer
+	c := run(args, &stdout, &stderr)
+	return c, stdout.String(), stderr.String()
+}
+
+// testValues returns the decimal values of the output of the command with args. The test fails, if the command fails.
+func testValues(t *testing.T, args ...string) []uint64 {
+	// Panic if t is nil
+	if t == nil {
+		panic("nil pointer")
+	}
+	// The test fails, if the command fails
+	c, out, e := testRun(append(args, "-format", "dec")...)
+	if c != 0 {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "run", Fn: strings.Join(args, " "), Err: errors.New(e)}))
+	}
+	var v []uint64
+	for _, l := range strings.Fields(out) {
+		// The test fails, if a line is not a decimal value
+		u, e := strconv.ParseUint(l, 10, 64)
+		if e != nil {
+			t.Fatal(tserr.Op(&tserr.OpArgs{Op: "ParseUint", Fn: l, Err: e}))
+		}
+		v = append(v, u)
+	}
+	return v
+}
+
+// TestSources tests that each source writes the requested number of values and seeded sources match the values of the package.
+func TestSources(t *testing.T) {
+	for name := range sources {
+		// The test fails, if the number of values does not match
+		if v := testValues(t, "-source", name, "-n", "5"); len(v) != 5 {
+			t.Error(tserr.Equal(&tserr.EqualArgs{Var: "values of " + name, Actual: int64(len(v)), Want: 5}))
+		}
+	}
+	// The first value of MT64Source for the default seed 5489 of the reference implementation
+	if v := testValues(t, "-source", "mt64", "-n", "1"); v[0] != 14514284786278117030 {
+		t.Error(tserr.Equal(&tserr.EqualArgs{Var: "first value of mt64", Actual: int64(v[0]), Want: -3932459286831434586}))
+	}
+	// Seeded sources match the sources of the package
+	pcg, mt := tsrand.NewPCG64Source(), tsrand.NewMT32Source()
+	pcg.Seed(-42)
+	mt.Seed(7)
+	for name, c := range map[string]struct {
+		args []string
+		src  tsrand.Random
+	}{
+		"pcg64":         {[]string{"-source", "pcg64", "-seed", "-42"}, pcg},
+		"mt32":          {[]string{"-source", "mt32", "-seed", "7"}, mt},
+		"deterministic": {[]string{"-source", "deterministic", "-seed", "3"}, rand.New(rand.NewSource(3))},
+	} {
+		for i, v := range testValues(t, append(c.args, "-n", "100")...) {
+			// The test fails, if the value does not match
+			if w := c.src.Uint64(); v != w {
+				t.Fatal(tserr.Equal(&tserr.EqualArgs{Var: fmt.Sprintf("value %d of %s", i, name), Actual: int64(v), Want: int64(w)}))
+			}
+		}
+	}
+}
+
+// TestReproducible tests that the output of the sources seeded from crypto/rand differs between two runs without -seed, the output of chacha8
+// and chacha20 is reproducible with -seed and the output of all other sources is reproducible without -seed.
+func TestReproducible(t *testing.T) {
+	for name := range sources {
+		args := []string{"-source", name, "-n", "4"}
+		seeded := name == "chacha8" || name == "chacha20"
+		if seeded || name == "crypto" || name == "bufferedcrypto" || name == "pseudo" {
+			// The test fails, if two runs without -seed return the same values
+			if slices.Equal(testValues(t, args...), testValues(t, args...)) {
+				t.Error(tserr.Forbidden("same values of " + name + " without seed"))
+			}
+			// Only chacha8 and chacha20 are reproducible with -seed
+			if !seeded {
+				continue
+			}
+			args = append(args, "-seed", "1")
+		}
+		// The test fails, if two runs return different values
+		if v, w := testValues(t, args...), testValues(t, args...); !slices.Equal(v, w) {
+			t.Error(tserr.NotEqualStr(&tserr.NotEqualStrArgs{X: fmt.Sprint(v), Y: fmt.Sprint(w)}))
+		}
+	}
+}
+
+// TestFormats tests that the raw, hex and decimal output contain the same values.
+func TestFormats(t *testing.T) {
+	args := []string{"-source", "chacha20", "-seed", "1", "-n", "10"}
+	dec := testValues(t, args...)
+	// The test fails, if the raw or hex output fails
+	cr, raw, _ := testRun(append(args, "-format", "raw")...)
+	ch, hex, _ := testRun(append(args, "-format", "hex")...)
+	if cr != 0 || ch != 0 {
+		t.Fatal(tserr.Equal(&tserr.EqualArgs{Var: "exit code", Actual: int64(max(cr, ch)), Want: 0}))
+	}
+	// The test fails, if the sizes of the outputs do not match
+	lines := strings.Fields(hex)
+	if len(raw) != 8*len(dec) || len(lines) != len(dec) {
+		t.Fatal(tserr.Equal(&tserr.EqualArgs{Var: "length of raw output", Actual: int64(len(raw)), Want: int64(8 * len(dec))}))
+	}
+	for i, v := range dec {
+		// The test fails, if a raw value in little-endian byte order does not match
+		if r := binary.LittleEndian.Uint64([]byte(raw[8*i:])); r != v {
+			t.Error(tserr.Equal(&tserr.EqualArgs{Var: fmt.Sprintf("raw value %d", i), Actual: int64(r), Want: int64(v)}))
+		}
+		// The test fails, if a hex value does not match
+		if h := fmt.Sprintf("%016x", v); lines[i] != h {
+			t.Error(tserr.NotEqualStr(&tserr.NotEqualStrArgs{X: lines[i], Y: h}))
+		}
+	}
+}
+
+// TestInvalid tests that invalid arguments result in exit code 2 with an error message and the help message in exit code 0.
+func TestInvalid(t *testing.T) {
+	for name, c := range map[string]struct {
+		args []string
+		code int
+	}{
+		"unknown source": {[]string{"-source", "foo"}, 2},
+		"unknown format": {[]string{"-format", "foo"}, 2},
+		"crypto seed":    {[]string{"-source", "crypto", "-seed", "1"}, 2},
+		"pseudo seed":    {[]string{"-source", "pseudo", "-seed", "1"}, 2},
+		"negative n":     {[]string{"-n", "-1"}, 2},
+		"argument":       {[]string{"-n", "1", "foo"}, 2},
+		"help":           {[]string{"-h"}, 0},
+	} {
+		c1, out, e := testRun(c.args...)
+		// The test fails, if the exit code does not match, values are written or the error message is missing
+		if c1 != c.code {
+			t.Error(tserr.Equal(&tserr.EqualArgs{Var: "exit code of " + name, Actual: int64(c1), Want: int64(c.code)}))
+		}
+		if out != "" || e == "" {
+			t.Error(tserr.Check(&tserr.CheckArgs{F: name, Err: fmt.Errorf("stdout %q, stderr %q", out, e)}))
+		}
+	}
+}
+
+// TestWrite tests that infinite output stops with an error, if writing fails, and that an unavailable source results in an error.
+func TestWrite(t *testing.T) {
+	f := formats["raw"]
+	// The test fails, if infinite output does not stop with an error
+	if e := write(&testFailWriter{n: 1 << 20}, tsrand.NewPCG64Source(), f, 0); !errors.Is(e, io.ErrClosedPipe) {
+		t.Error(tserr.NilFailed("write to failing writer"))
+	}
+	// The test fails, if the unavailable source does not result in an error
+	if e := write(io.Discard, tsrand.NewBufferedCryptoSource(1), f, 1); e == nil {
+		t.Error(tserr.NilFailed("write of unavailable source"))
+	}
+	// The test fails, if writing allocates for each value instead of reusing the buffer
+	for name, f := range formats {
+		if a := testing.AllocsPerRun(10, func() { write(io.Discard, tsrand.NewPCG64Source(), f, 10000) }); a > 16 {
+			t.Error(tserr.Check(&tserr.CheckArgs{F: "write in format " + name, Err: fmt.Errorf("%v allocations, but expected at most 16", a)}))
+		}
+	}
+}
+
+// BenchmarkWrite performs a benchmark on writing values of PCG64Source in each format.
+func BenchmarkWrite(b *testing.B) {
+	for name, f := range formats {
+		b.Run(name, func(b *testing.B) {
+			write(io.Discard, tsrand.NewPCG64Source(), f, uint64(b.N))
+		})
+	}
+}
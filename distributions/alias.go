@@ -0,0 +1,115 @@
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package distributions
+
+// Import standard library package fmt, math, tserr and tsrand
+import (
+	"fmt"  // fmt
+	"math" // math
+
+	"github.com/thorstenrie/tserr"  // tserr
+	"github.com/thorstenrie/tsrand" // tsrand
+)
+
+// AliasTable provides random indices of weighted categories in constant time. Index i is returned with probability
+// weights[i]/sum(weights). AliasTable holds the source src and the alias table constructed with the method of Vose, which consists
+// of the probability of each index to be kept and its alias otherwise. The construction needs linear time. Each random index
+// uses two random values of src. AliasTable is safe for concurrent use by multiple goroutines only if src is.
+type AliasTable struct {
+	src   tsrand.Source // source of random numbers
+	prob  []float64     // probability of an index to be kept
+	alias []int         // alias of an index
+}
+
+// NewAliasTable returns a new instance of AliasTable for weights using src. The weights are not modified and not referenced afterwards.
+// It returns an error, if weights is empty, contains a negative, NaN or infinite weight, the sum of weights is 0 or infinite, or if src is nil
+// or not available on the platform.
+func NewAliasTable(src tsrand.Source, weights []float64) (*AliasTable, error) {
+	// Return an error, if the weights are invalid
+	sum, e := checkWeights(weights)
+	if e != nil {
+		return nil, e
+	}
+	// Return an error, if src is not available
+	if e := checkSource(src); e != nil {
+		return nil, e
+	}
+	n := len(weights)
+	a := &AliasTable{src: src, prob: make([]float64, n), alias: make([]int, n)}
+	// Scale the weights to a mean of 1 and split the indices into small and large ones
+	small, large := make([]int, 0, n), make([]int, 0, n)
+	for i, w := range weights {
+		a.prob[i] = w / sum * float64(n)
+		if a.prob[i] < 1 {
+			small = append(small, i)
+		} else {
+			large = append(large, i)
+		}
+	}
+	// Fill each small index up to 1 with a large index as alias
+	for len(small) > 0 && len(large) > 0 {
+		s, l := small[len(small)-1], large[len(large)-1]
+		small = small[:len(small)-1]
+		a.alias[s] = l
+		a.prob[l] = (a.prob[l] + a.prob[s]) - 1
+		if a.prob[l] < 1 {
+			large = large[:len(large)-1]
+			small = append(small, l)
+		}
+	}
+	// The remaining indices are kept with probability 1 apart from rounding errors
+	for _, i := range large {
+		a.prob[i], a.alias[i] = 1, i
+	}
+	for _, i := range small {
+		a.prob[i], a.alias[i] = 1, i
+	}
+	// Return AliasTable
+	return a, nil
+}
+
+// Len returns the number of categories of the AliasTable.
+func (a *AliasTable) Len() int {
+	return len(a.prob)
+}
+
+// Int returns a random index in the half-open interval [0,Len()) with the probability of its weight.
+func (a *AliasTable) Int() int {
+	// Select an index uniformly and keep it or return its alias
+	i := int(tsrand.Uint64n(a.src, uint64(len(a.prob))))
+	if uniform(a.src) < a.prob[i] {
+		return i
+	}
+	return a.alias[i]
+}
+
+// checkWeights returns the sum of weights. It returns an error, if weights is empty, contains a negative, NaN or
+// infinite weight, or if the sum of weights is 0 or infinite.
+func checkWeights(weights []float64) (float64, error) {
+	// Return an error, if weights is empty
+	if len(weights) == 0 {
+		return 0, tserr.Empty("weights")
+	}
+	sum := 0.0
+	for i, w := range weights {
+		// Return an error, if a weight is invalid
+		if e := checkWeight(i, w); e != nil {
+			return 0, e
+		}
+		sum += w
+	}
+	// Return an error, if the sum is 0 or infinite
+	if sum == 0 || math.IsInf(sum, 0) {
+		return 0, errParam("sum of weights", sum, "finite and higher than 0")
+	}
+	return sum, nil
+}
+
+// checkWeight returns an error, if weight w with index i is negative, NaN or infinite.
+func checkWeight(i int, w float64) error {
+	if math.IsNaN(w) || math.IsInf(w, 0) || w < 0 {
+		return errParam(fmt.Sprintf("weights[%d]", i), w, "finite and higher than or equal to 0")
+	}
+	return nil
+}
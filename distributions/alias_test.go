@@ -0,0 +1,187 @@
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package distributions
+
+// Import standard library packages, tserr and tsrand
+import (
+	"fmt"     // fmt
+	"math"    // math
+	"testing" // testing
+
+	"github.com/thorstenrie/tserr"  // tserr
+	"github.com/thorstenrie/tsrand" // tsrand
+)
+
+// testWeights contains weights for the tests including zero weights and weights of different magnitudes
+var (
+	testWeights = [][]float64{
+		{1},
+		{1, 1, 1, 1, 1, 1},
+		{0, 3, 0, 1, 6},
+		{1e-3, 1, 10, 100, 0.5, 2, 7, 0},
+	}
+)
+
+// testCategories compares the frequencies of the indices returned by fn with weights. The test fails, if an index
+// with weight 0 is returned or if tests on the frequencies fail.
+func testCategories(t *testing.T, name string, fn func() int, weights []float64) {
+	sum := 0.0
+	for _, w := range weights {
+		sum += w
+	}
+	testPMF(t, name, func() int64 {
+		i := fn()
+		// The test fails, if an index with weight 0 is returned
+		if i < 0 || i >= len(weights) || weights[i] == 0 {
+			t.Fatal(tserr.Forbidden(fmt.Sprintf("index %d of %s", i, name)))
+		}
+		return int64(i)
+	}, func(k int64) float64 { return weights[k] / sum }, 0, int64(len(weights)-1))
+}
+
+// TestAliasTable tests the frequencies of the indices returned by AliasTable.
+func TestAliasTable(t *testing.T) {
+	for _, w := range testWeights {
+		name := fmt.Sprintf("AliasTable(%v)", w)
+		// Retrieve the alias table
+		a, err := NewAliasTable(testSource(), w)
+		// The test fails if an error occurs
+		if err != nil {
+			t.Fatal(tserr.NotAvailable(&tserr.NotAvailableArgs{S: name, Err: err}))
+		}
+		// The test fails, if the number of categories does not match
+		if a.Len() != len(w) {
+			t.Error(tserr.Equal(&tserr.EqualArgs{Var: "Len of " + name, Actual: int64(a.Len()), Want: int64(len(w))}))
+		}
+		testCategories(t, name, a.Int, w)
+	}
+}
+
+// BenchmarkAliasTable performs a benchmark on AliasTable with 1000 categories
+func BenchmarkAliasTable(b *testing.B) {
+	// Weights 1, 2, ..., 1000
+	w := make([]float64, 1000)
+	for i := range w {
+		w[i] = float64(i + 1)
+	}
+	// Retrieve the alias table
+	a, err := NewAliasTable(tsrand.NewXoshiro256StarStarSource(), w)
+	// The benchmark fails if an error occurs
+	if err != nil {
+		b.Fatal(tserr.NotAvailable(&tserr.NotAvailableArgs{S: "AliasTable", Err: err}))
+	}
+	for i := 0; i < b.N; i++ {
+		a.Int()
+	}
+}
+
+// TestDynamicTable tests the frequencies of the indices returned by DynamicTable before and after updates of the weights.
+func TestDynamicTable(t *testing.T) {
+	for _, w := range testWeights {
+		name := fmt.Sprintf("DynamicTable(%v)", w)
+		// Retrieve the dynamic table
+		d, err := NewDynamicTable(testSource(), w)
+		// The test fails if an error occurs
+		if err != nil {
+			t.Fatal(tserr.NotAvailable(&tserr.NotAvailableArgs{S: name, Err: err}))
+		}
+		testCategories(t, name, d.Int, w)
+		// Reverse the weights with updates
+		u := make([]float64, len(w))
+		for i := range w {
+			u[i] = w[len(w)-1-i]
+			if err := d.Update(i, u[i]); err != nil {
+				t.Fatal(tserr.Op(&tserr.OpArgs{Op: "Update", Fn: name, Err: err}))
+			}
+		}
+		// The test fails, if the weights or the sum do not match the updated weights
+		sum := 0.0
+		for i := range u {
+			sum += u[i]
+			if d.Weight(i) != u[i] {
+				t.Error(tserr.Equalf(&tserr.EqualfArgs{Var: fmt.Sprintf("Weight(%d) of %s", i, name), Actual: d.Weight(i), Want: u[i]}))
+			}
+		}
+		if !nearEqualRel(d.Sum(), sum) {
+			t.Error(tserr.Equalf(&tserr.EqualfArgs{Var: "Sum of " + name, Actual: d.Sum(), Want: sum}))
+		}
+		testCategories(t, "updated "+name, d.Int, u)
+	}
+}
+
+// TestDynamicTableUpdate tests that invalid updates result in an error and do not change the weights.
+func TestDynamicTableUpdate(t *testing.T) {
+	d, err := NewDynamicTable(testSource(), []float64{0, 2, 0})
+	// The test fails if an error occurs
+	if err != nil {
+		t.Fatal(tserr.NotAvailable(&tserr.NotAvailableArgs{S: "DynamicTable", Err: err}))
+	}
+	// Invalid updates
+	for _, u := range []struct {
+		i int
+		w float64
+	}{{-1, 1}, {3, 1}, {0, -1}, {0, math.NaN()}, {0, math.Inf(1)}, {1, 0}} {
+		// The test fails, if the update does not return an error
+		if d.Update(u.i, u.w) == nil {
+			t.Error(tserr.NilFailed(fmt.Sprintf("Update(%d, %v)", u.i, u.w)))
+		}
+	}
+	// The test fails, if the weights changed
+	for i, w := range []float64{0, 2, 0} {
+		if d.Weight(i) != w {
+			t.Error(tserr.Equalf(&tserr.EqualfArgs{Var: fmt.Sprintf("Weight(%d)", i), Actual: d.Weight(i), Want: w}))
+		}
+	}
+	// The test fails, if an index with weight 0 is returned
+	for i := 0; i < testCalls; i++ {
+		if v := d.Int(); v != 1 {
+			t.Fatal(tserr.Equal(&tserr.EqualArgs{Var: "Int", Actual: int64(v), Want: 1}))
+		}
+	}
+}
+
+// BenchmarkDynamicTable performs a benchmark on DynamicTable with 1000 categories for updates and random indices
+func BenchmarkDynamicTable(b *testing.B) {
+	// Weights 1, 2, ..., 1000
+	w := make([]float64, 1000)
+	for i := range w {
+		w[i] = float64(i + 1)
+	}
+	// Retrieve the dynamic table
+	d, err := NewDynamicTable(tsrand.NewXoshiro256StarStarSource(), w)
+	// The benchmark fails if an error occurs
+	if err != nil {
+		b.Fatal(tserr.NotAvailable(&tserr.NotAvailableArgs{S: "DynamicTable", Err: err}))
+	}
+	b.Run("Int", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			d.Int()
+		}
+	})
+	b.Run("Update", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			d.Update(i%1000, float64(i%7+1))
+		}
+	})
+}
+
+// TestWeightsInvalid tests that invalid weights and sources result in an error.
+func TestWeightsInvalid(t *testing.T) {
+	for _, w := range [][]float64{nil, {}, {0, 0}, {1, -1}, {math.NaN()}, {1, math.Inf(1)}, {math.MaxFloat64, math.MaxFloat64}} {
+		// The test fails, if the constructors do not return an error
+		if _, err := NewAliasTable(testSource(), w); err == nil {
+			t.Error(tserr.NilFailed(fmt.Sprintf("NewAliasTable(%v)", w)))
+		}
+		if _, err := NewDynamicTable(testSource(), w); err == nil {
+			t.Error(tserr.NilFailed(fmt.Sprintf("NewDynamicTable(%v)", w)))
+		}
+	}
+	// The test fails, if a nil source does not result in an error
+	if _, err := NewAliasTable(nil, []float64{1}); err == nil {
+		t.Error(tserr.NilFailed("NewAliasTable(nil)"))
+	}
+	if _, err := NewDynamicTable(nil, []float64{1}); err == nil {
+		t.Error(tserr.NilFailed("NewDynamicTable(nil)"))
+	}
+}
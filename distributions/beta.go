@@ -0,0 +1,46 @@
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package distributions
+
+// Import standard library package math and tsrand
+import (
+	"math" // math
+
+	"github.com/thorstenrie/tsrand" // tsrand
+)
+
+// Beta provides beta distributed random numbers with shape parameters alpha and beta. It holds the source src and the parameters
+// of the distribution. The random numbers are generated as ratio X/(X+Y) of gamma distributed random numbers X with shape alpha and
+// Y with shape beta. The ratio is computed with the logarithms of X and Y to avoid an underflow for small shape parameters.
+// Beta is safe for concurrent use by multiple goroutines only if src is.
+type Beta struct {
+	src   tsrand.Source // source of random numbers
+	alpha float64       // shape parameter alpha
+	beta  float64       // shape parameter beta
+}
+
+// NewBeta returns a new instance of Beta with shape parameters alpha and beta using src. It returns an error, if alpha or beta
+// is not finite and higher than 0, or if src is nil or not available on the platform.
+func NewBeta(src tsrand.Source, alpha, beta float64) (*Beta, error) {
+	// Return an error, if a parameter is invalid
+	if e := checkPositive("alpha", alpha); e != nil {
+		return nil, e
+	}
+	if e := checkPositive("beta", beta); e != nil {
+		return nil, e
+	}
+	// Return an error, if src is not available
+	if e := checkSource(src); e != nil {
+		return nil, e
+	}
+	// Return Beta
+	return &Beta{src: src, alpha: alpha, beta: beta}, nil
+}
+
+// Float64 returns a beta distributed random number in the interval [0,1].
+func (b *Beta) Float64() float64 {
+	// X/(X+Y) = 1/(1+Y/X) = 1/(1+exp(log(Y)-log(X)))
+	lx, ly := logStdGamma(b.src, b.alpha), logStdGamma(b.src, b.beta)
+	return 1 / (1 + math.Exp(ly-lx))
+}
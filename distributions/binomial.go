@@ -0,0 +1,204 @@
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package distributions
+
+// Import standard library package math, tserr and tsrand
+import (
+	"math" // math
+
+	"github.com/thorstenrie/tserr"  // tserr
+	"github.com/thorstenrie/tsrand" // tsrand
+)
+
+// Parameters of the binomial distribution
+var (
+	binomialc = struct {
+		btpe float64 // minimum n*min(p, 1-p) for the BTPE method
+	}{
+		btpe: 30,
+	}
+)
+
+// Binomial provides binomially distributed random numbers, which are the number of successes in n independent trials
+// with success probability p. It holds the source src and the parameters of the distribution. For n*min(p, 1-p) < 30, the random
+// numbers are generated by inversion. Otherwise, they are generated with the BTPE method of Kachitvichyanukul and Schmeiser.
+// Binomial is safe for concurrent use by multiple goroutines only if src is.
+type Binomial struct {
+	src tsrand.Source // source of random numbers
+	n   int64         // number of trials
+	p   float64       // success probability
+}
+
+// NewBinomial returns a new instance of Binomial with n trials and success probability p using src. It returns an error,
+// if n is lower than 0, p is not in the interval [0,1], or if src is nil or not available on the platform.
+func NewBinomial(src tsrand.Source, n int64, p float64) (*Binomial, error) {
+	// Return an error, if a parameter is invalid
+	if n < 0 {
+		return nil, tserr.Higher(&tserr.HigherArgs{Var: "n", Actual: n, LowerBound: 0})
+	}
+	if e := checkProbability("p", p); e != nil {
+		return nil, e
+	}
+	// Return an error, if src is not available
+	if e := checkSource(src); e != nil {
+		return nil, e
+	}
+	// Return Binomial
+	return &Binomial{src: src, n: n, p: p}, nil
+}
+
+// Int64 returns a binomially distributed random number in the interval [0,n].
+func (b *Binomial) Int64() int64 {
+	return binomial(b.src, b.n, b.p)
+}
+
+// binomial returns a binomially distributed random number with n trials and success probability p using src.
+func binomial(src tsrand.Source, n int64, p float64) int64 {
+	// Trivial cases
+	if n == 0 || p == 0 {
+		return 0
+	}
+	if p == 1 {
+		return n
+	}
+	// Sample with r = min(p, 1-p) and mirror the result, if p > 0.5
+	r := math.Min(p, 1-p)
+	var y int64
+	if float64(n)*r < binomialc.btpe {
+		y = binomialInversion(src, n, r)
+	} else {
+		y = binomialBTPE(src, n, r)
+	}
+	if p > 0.5 {
+		return n - y
+	}
+	return y
+}
+
+// binomialInversion returns a binomially distributed random number with n trials and success probability p <= 0.5 using src
+// based on inversion of the cumulative distribution function.
+func binomialInversion(src tsrand.Source, n int64, p float64) int64 {
+	q := 1 - p
+	qn := math.Exp(float64(n) * math.Log1p(-p))
+	np := float64(n) * p
+	bound := math.Min(float64(n), np+10*math.Sqrt(np*q+1))
+	x := int64(0)
+	px := qn
+	u := uniform(src)
+	for u > px {
+		x++
+		// Restart, if the search exceeds the bound due to rounding errors
+		if float64(x) > bound {
+			x = 0
+			px = qn
+			u = uniform(src)
+			continue
+		}
+		u -= px
+		px = (float64(n-x+1) * p * px) / (float64(x) * q)
+	}
+	return x
+}
+
+// binomialBTPE returns a binomially distributed random number with n trials and success probability p <= 0.5 using src
+// based on the BTPE method of Kachitvichyanukul and Schmeiser, "Binomial random variate generation", 1988.
+func binomialBTPE(src tsrand.Source, n int64, p float64) int64 {
+	// Setup
+	nf := float64(n)
+	q := 1 - p
+	fm := nf*p + p
+	m := math.Floor(fm)
+	p1 := math.Floor(2.195*math.Sqrt(nf*p*q)-4.6*q) + 0.5
+	xm := m + 0.5
+	xl := xm - p1
+	xr := xm + p1
+	c := 0.134 + 20.5/(15.3+m)
+	a := (fm - xl) / (fm - xl*p)
+	laml := a * (1 + a/2)
+	a = (xr - fm) / (xr * q)
+	lamr := a * (1 + a/2)
+	p2 := p1 * (1 + 2*c)
+	p3 := p2 + c/laml
+	p4 := p3 + c/lamr
+	nrq := nf * p * q
+	for {
+		u := uniform(src) * p4
+		v := uniform(src)
+		var y float64
+		switch {
+		case u <= p1:
+			// Triangular region, accept immediately
+			return int64(math.Floor(xm - p1*v + u))
+		case u <= p2:
+			// Parallelogram region
+			x := xl + (u-p1)/c
+			v = v*c + 1 - math.Abs(m-x+0.5)/p1
+			if v > 1 {
+				continue
+			}
+			y = math.Floor(x)
+		case u <= p3:
+			// Left exponential tail
+			y = math.Floor(xl + math.Log(v)/laml)
+			if y < 0 || v == 0 {
+				continue
+			}
+			v = v * (u - p2) * laml
+		default:
+			// Right exponential tail
+			y = math.Floor(xr - math.Log(v)/lamr)
+			if y > nf || v == 0 {
+				continue
+			}
+			v = v * (u - p3) * lamr
+		}
+		k := math.Abs(y - m)
+		if k <= 20 || k >= nrq/2-1 {
+			// Explicit evaluation of f(y)/f(m)
+			s := p / q
+			a := s * (nf + 1)
+			f := 1.0
+			if m < y {
+				for i := m + 1; i <= y; i++ {
+					f *= a/i - s
+				}
+			} else if m > y {
+				for i := y + 1; i <= m; i++ {
+					f /= a/i - s
+				}
+			}
+			if v <= f {
+				return int64(y)
+			}
+			continue
+		}
+		// Squeezing using upper and lower bounds on log(f(y))
+		rho := (k / nrq) * ((k*(k/3+0.625)+0.16666666666666666)/nrq + 0.5)
+		t := -k * k / (2 * nrq)
+		la := math.Log(v)
+		if la < t-rho {
+			return int64(y)
+		}
+		if la > t+rho {
+			continue
+		}
+		// Final acceptance test with Stirling's formula
+		x1 := y + 1
+		f1 := m + 1
+		z := nf + 1 - m
+		w := nf - y + 1
+		if la <= xm*math.Log(f1/x1)+(nf-m+0.5)*math.Log(z/w)+(y-m)*math.Log(w*p/(x1*q))+
+			stirling(f1)+stirling(z)+stirling(x1)+stirling(w) {
+			return int64(y)
+		}
+	}
+}
+
+// stirling returns the correction term of Stirling's formula for x used by the BTPE method. It is the Stirling series
+// 1/(12x) - 1/(360x^3) + 1/(1260x^5) - 1/(1680x^7) + 1/(1188x^9) with the common denominator 166320, see step 5.3 of
+// Kachitvichyanukul and Schmeiser, "Binomial random variate generation", Communications of the ACM 31(2), 1988.
+func stirling(x float64) float64 {
+	x2 := x * x
+	return (13860 - (462-(132-(99-140/x2)/x2)/x2)/x2) / x / 166320
+}
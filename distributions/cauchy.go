@@ -0,0 +1,44 @@
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package distributions
+
+// Import standard library package math and tsrand
+import (
+	"math" // math
+
+	"github.com/thorstenrie/tsrand" // tsrand
+)
+
+// Cauchy provides Cauchy distributed random numbers with location x0 and scale gamma. It holds the source src and the parameters
+// of the distribution. The random numbers are generated by inversion as x0 + gamma*tan(pi*(U-0.5)) with a uniformly distributed random
+// number U in the open interval (0,1). The Cauchy distribution has no mean and variance. Cauchy is safe for concurrent use by multiple
+// goroutines only if src is.
+type Cauchy struct {
+	src   tsrand.Source // source of random numbers
+	x0    float64       // location
+	gamma float64       // scale
+}
+
+// NewCauchy returns a new instance of Cauchy with location x0 and scale gamma using src. It returns an error, if x0 is not finite,
+// gamma is not finite and higher than 0, or if src is nil or not available on the platform.
+func NewCauchy(src tsrand.Source, x0, gamma float64) (*Cauchy, error) {
+	// Return an error, if a parameter is invalid
+	if e := checkFinite("x0", x0); e != nil {
+		return nil, e
+	}
+	if e := checkPositive("gamma", gamma); e != nil {
+		return nil, e
+	}
+	// Return an error, if src is not available
+	if e := checkSource(src); e != nil {
+		return nil, e
+	}
+	// Return Cauchy
+	return &Cauchy{src: src, x0: x0, gamma: gamma}, nil
+}
+
+// Float64 returns a Cauchy distributed random number.
+func (c *Cauchy) Float64() float64 {
+	return c.x0 + c.gamma*math.Tan(math.Pi*(uniformOpen(c.src)-0.5))
+}
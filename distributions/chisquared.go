@@ -0,0 +1,37 @@
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package distributions
+
+// Import tsrand
+import (
+	"github.com/thorstenrie/tsrand" // tsrand
+)
+
+// ChiSquared provides chi-squared distributed random numbers with k degrees of freedom. It holds the source src and the parameter
+// of the distribution. The random numbers are generated as gamma distributed random numbers with shape k/2 and scale 2.
+// ChiSquared is safe for concurrent use by multiple goroutines only if src is.
+type ChiSquared struct {
+	src tsrand.Source // source of random numbers
+	k   float64       // degrees of freedom
+}
+
+// NewChiSquared returns a new instance of ChiSquared with k degrees of freedom using src. It returns an error, if k
+// is not finite and higher than 0, or if src is nil or not available on the platform.
+func NewChiSquared(src tsrand.Source, k float64) (*ChiSquared, error) {
+	// Return an error, if k is invalid
+	if e := checkPositive("k", k); e != nil {
+		return nil, e
+	}
+	// Return an error, if src is not available
+	if e := checkSource(src); e != nil {
+		return nil, e
+	}
+	// Return ChiSquared
+	return &ChiSquared{src: src, k: k}, nil
+}
+
+// Float64 returns a chi-squared distributed random number in the interval [0, +math.MaxFloat64].
+func (c *ChiSquared) Float64() float64 {
+	return 2 * stdGamma(c.src, c.k/2)
+}
@@ -0,0 +1,269 @@
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package distributions
+
+// Import standard library packages, tserr and tsrand
+import (
+	"fmt"     // fmt
+	"math"    // math
+	"testing" // testing
+
+	"github.com/thorstenrie/tserr"  // tserr
+	"github.com/thorstenrie/tsrand" // tsrand
+)
+
+// TestGamma tests the arithmetic mean, variance and goodness-of-fit of Gamma for shapes lower and higher than 1.
+func TestGamma(t *testing.T) {
+	for _, c := range []struct{ shape, scale float64 }{{0.3, 2}, {1, 1}, {4.5, 0.5}, {100, 3}} {
+		name := fmt.Sprintf("Gamma(%v, %v)", c.shape, c.scale)
+		// Retrieve the generator
+		g, err := NewGamma(testSource(), c.shape, c.scale)
+		// The test fails if an error occurs
+		if err != nil {
+			t.Fatal(tserr.NotAvailable(&tserr.NotAvailableArgs{S: name, Err: err}))
+		}
+		testMoments(t, name, g.Float64, c.shape*c.scale, c.shape*c.scale*c.scale)
+		testKS(t, name, g.Float64, func(x float64) float64 { return gammaP(c.shape, x/c.scale) })
+	}
+}
+
+// BenchmarkGamma performs a benchmark on Gamma
+func BenchmarkGamma(b *testing.B) {
+	// Retrieve the generator
+	g, err := NewGamma(tsrand.NewXoshiro256StarStarSource(), 2.5, 1)
+	// The benchmark fails if an error occurs
+	if err != nil {
+		b.Fatal(tserr.NotAvailable(&tserr.NotAvailableArgs{S: "Gamma", Err: err}))
+	}
+	for i := 0; i < b.N; i++ {
+		g.Float64()
+	}
+}
+
+// TestBeta tests the arithmetic mean and variance of Beta including small shape parameters.
+func TestBeta(t *testing.T) {
+	for _, c := range []struct{ alpha, beta float64 }{{0.01, 0.02}, {0.5, 0.5}, {2, 5}, {30, 10}} {
+		name := fmt.Sprintf("Beta(%v, %v)", c.alpha, c.beta)
+		// Retrieve the generator
+		bt, err := NewBeta(testSource(), c.alpha, c.beta)
+		// The test fails if an error occurs
+		if err != nil {
+			t.Fatal(tserr.NotAvailable(&tserr.NotAvailableArgs{S: name, Err: err}))
+		}
+		s := c.alpha + c.beta
+		testMoments(t, name, bt.Float64, c.alpha/s, c.alpha*c.beta/(s*s*(s+1)))
+	}
+	// The test fails, if Beta(1, 1) is not uniformly distributed
+	bt, _ := NewBeta(testSource(), 1, 1)
+	testKS(t, "Beta(1, 1)", bt.Float64, func(x float64) float64 { return x })
+}
+
+// BenchmarkBeta performs a benchmark on Beta
+func BenchmarkBeta(b *testing.B) {
+	// Retrieve the generator
+	bt, err := NewBeta(tsrand.NewXoshiro256StarStarSource(), 2, 5)
+	// The benchmark fails if an error occurs
+	if err != nil {
+		b.Fatal(tserr.NotAvailable(&tserr.NotAvailableArgs{S: "Beta", Err: err}))
+	}
+	for i := 0; i < b.N; i++ {
+		bt.Float64()
+	}
+}
+
+// TestChiSquared tests the arithmetic mean, variance and goodness-of-fit of ChiSquared.
+func TestChiSquared(t *testing.T) {
+	for _, k := range []float64{1, 3, 10} {
+		name := fmt.Sprintf("ChiSquared(%v)", k)
+		// Retrieve the generator
+		c, err := NewChiSquared(testSource(), k)
+		// The test fails if an error occurs
+		if err != nil {
+			t.Fatal(tserr.NotAvailable(&tserr.NotAvailableArgs{S: name, Err: err}))
+		}
+		testMoments(t, name, c.Float64, k, 2*k)
+		testKS(t, name, c.Float64, func(x float64) float64 { return gammaP(k/2, x/2) })
+	}
+}
+
+// TestStudentT tests the arithmetic mean and variance of StudentT and the goodness-of-fit for nu = 1, which is the standard Cauchy distribution.
+func TestStudentT(t *testing.T) {
+	for _, nu := range []float64{10, 30} {
+		name := fmt.Sprintf("StudentT(%v)", nu)
+		// Retrieve the generator
+		s, err := NewStudentT(testSource(), nu)
+		// The test fails if an error occurs
+		if err != nil {
+			t.Fatal(tserr.NotAvailable(&tserr.NotAvailableArgs{S: name, Err: err}))
+		}
+		testMoments(t, name, s.Float64, 0, nu/(nu-2))
+	}
+	s, _ := NewStudentT(testSource(), 1)
+	testKS(t, "StudentT(1)", s.Float64, func(x float64) float64 { return 0.5 + math.Atan(x)/math.Pi })
+}
+
+// TestLogNormal tests the arithmetic mean, variance and goodness-of-fit of LogNormal.
+func TestLogNormal(t *testing.T) {
+	mu, sigma := 1.0, 0.5
+	// Retrieve the generator
+	l, err := NewLogNormal(testSource(), mu, sigma)
+	// The test fails if an error occurs
+	if err != nil {
+		t.Fatal(tserr.NotAvailable(&tserr.NotAvailableArgs{S: "LogNormal", Err: err}))
+	}
+	s2 := sigma * sigma
+	testMoments(t, "LogNormal", l.Float64, math.Exp(mu+s2/2), math.Expm1(s2)*math.Exp(2*mu+s2))
+	testKS(t, "LogNormal", l.Float64, func(x float64) float64 { return normalCDF(math.Log(x), mu, sigma) })
+}
+
+// TestWeibull tests the arithmetic mean, variance and goodness-of-fit of Weibull.
+func TestWeibull(t *testing.T) {
+	for _, c := range []struct{ k, lambda float64 }{{0.8, 1}, {2, 3}, {5, 0.5}} {
+		name := fmt.Sprintf("Weibull(%v, %v)", c.k, c.lambda)
+		// Retrieve the generator
+		w, err := NewWeibull(testSource(), c.k, c.lambda)
+		// The test fails if an error occurs
+		if err != nil {
+			t.Fatal(tserr.NotAvailable(&tserr.NotAvailableArgs{S: name, Err: err}))
+		}
+		g1, g2 := math.Gamma(1+1/c.k), math.Gamma(1+2/c.k)
+		testMoments(t, name, w.Float64, c.lambda*g1, c.lambda*c.lambda*(g2-g1*g1))
+		testKS(t, name, w.Float64, func(x float64) float64 { return -math.Expm1(-math.Pow(x/c.lambda, c.k)) })
+	}
+}
+
+// TestPareto tests the arithmetic mean, variance and goodness-of-fit of Pareto.
+func TestPareto(t *testing.T) {
+	xm, alpha := 2.0, 10.0
+	// Retrieve the generator
+	p, err := NewPareto(testSource(), xm, alpha)
+	// The test fails if an error occurs
+	if err != nil {
+		t.Fatal(tserr.NotAvailable(&tserr.NotAvailableArgs{S: "Pareto", Err: err}))
+	}
+	testMoments(t, "Pareto", p.Float64, alpha*xm/(alpha-1), xm*xm*alpha/((alpha-1)*(alpha-1)*(alpha-2)))
+	testKS(t, "Pareto", p.Float64, func(x float64) float64 { return 1 - math.Pow(xm/x, alpha) })
+}
+
+// TestCauchy tests the goodness-of-fit of Cauchy. The Cauchy distribution has no mean and variance.
+func TestCauchy(t *testing.T) {
+	x0, gamma := -1.0, 2.0
+	// Retrieve the generator
+	c, err := NewCauchy(testSource(), x0, gamma)
+	// The test fails if an error occurs
+	if err != nil {
+		t.Fatal(tserr.NotAvailable(&tserr.NotAvailableArgs{S: "Cauchy", Err: err}))
+	}
+	testKS(t, "Cauchy", c.Float64, func(x float64) float64 { return 0.5 + math.Atan((x-x0)/gamma)/math.Pi })
+}
+
+// TestTriangular tests the arithmetic mean, variance and goodness-of-fit of Triangular including a mode at the limits.
+func TestTriangular(t *testing.T) {
+	for _, p := range [][3]float64{{0, 0.3, 1}, {-2, -2, 5}, {1, 4, 4}} {
+		a, c, b := p[0], p[1], p[2]
+		name := fmt.Sprintf("Triangular(%v, %v, %v)", a, c, b)
+		// Retrieve the generator
+		tr, err := NewTriangular(testSource(), a, c, b)
+		// The test fails if an error occurs
+		if err != nil {
+			t.Fatal(tserr.NotAvailable(&tserr.NotAvailableArgs{S: name, Err: err}))
+		}
+		testMoments(t, name, tr.Float64, (a+b+c)/3, (a*a+b*b+c*c-a*b-a*c-b*c)/18)
+		testKS(t, name, tr.Float64, func(x float64) float64 {
+			if x <= c {
+				return (x - a) * (x - a) / ((b - a) * (c - a))
+			}
+			return 1 - (b-x)*(b-x)/((b-a)*(b-c))
+		})
+	}
+}
+
+// TestVonMises tests the arithmetic mean and variance of the cosine of the deviation from the mean direction of VonMises.
+func TestVonMises(t *testing.T) {
+	for _, kappa := range []float64{1e-9, 1e-6, 0.5, 4, 50, 1e7} {
+		mu := 3.0
+		name := fmt.Sprintf("VonMises(%v, %v)", mu, kappa)
+		// Retrieve the generator
+		v, err := NewVonMises(testSource(), mu, kappa)
+		// The test fails if an error occurs
+		if err != nil {
+			t.Fatal(tserr.NotAvailable(&tserr.NotAvailableArgs{S: name, Err: err}))
+		}
+		// Expected mean and variance of cos(X-mu) with the modified Bessel functions, approximated for large kappa
+		var mean, vari float64
+		if kappa > 100 {
+			mean, vari = 1-1/(2*kappa), 1/(2*kappa*kappa)
+		} else {
+			i0 := besselI(0, kappa)
+			mean = besselI(1, kappa) / i0
+			vari = (1+besselI(2, kappa)/i0)/2 - mean*mean
+		}
+		// The test fails, if a random angle is not in the interval [-pi,pi]
+		testMoments(t, name, func() float64 {
+			x := v.Float64()
+			if x < -math.Pi || x > math.Pi {
+				t.Fatal(tserr.Equalf(&tserr.EqualfArgs{Var: name, Actual: x, Want: math.Pi}))
+			}
+			return math.Cos(x - mu)
+		}, mean, vari)
+	}
+}
+
+// BenchmarkVonMises performs a benchmark on VonMises
+func BenchmarkVonMises(b *testing.B) {
+	// Retrieve the generator
+	v, err := NewVonMises(tsrand.NewXoshiro256StarStarSource(), 0, 4)
+	// The benchmark fails if an error occurs
+	if err != nil {
+		b.Fatal(tserr.NotAvailable(&tserr.NotAvailableArgs{S: "VonMises", Err: err}))
+	}
+	for i := 0; i < b.N; i++ {
+		v.Float64()
+	}
+}
+
+// TestContinuousInvalid tests that invalid parameters and sources of the continuous distributions result in an error.
+func TestContinuousInvalid(t *testing.T) {
+	src := testSource()
+	nan, inf := math.NaN(), math.Inf(1)
+	// Constructors with invalid parameters or sources
+	for name, fn := range map[string]func() error{
+		"Gamma(0, 1)":            func() error { _, e := NewGamma(src, 0, 1); return e },
+		"Gamma(1, -1)":           func() error { _, e := NewGamma(src, 1, -1); return e },
+		"Gamma(NaN, 1)":          func() error { _, e := NewGamma(src, nan, 1); return e },
+		"Gamma(nil)":             func() error { _, e := NewGamma(nil, 1, 1); return e },
+		"Beta(0, 1)":             func() error { _, e := NewBeta(src, 0, 1); return e },
+		"Beta(1, Inf)":           func() error { _, e := NewBeta(src, 1, inf); return e },
+		"Beta(nil)":              func() error { _, e := NewBeta(nil, 1, 1); return e },
+		"ChiSquared(-1)":         func() error { _, e := NewChiSquared(src, -1); return e },
+		"ChiSquared(nil)":        func() error { _, e := NewChiSquared(nil, 1); return e },
+		"StudentT(0)":            func() error { _, e := NewStudentT(src, 0); return e },
+		"StudentT(nil)":          func() error { _, e := NewStudentT(nil, 1); return e },
+		"LogNormal(Inf, 1)":      func() error { _, e := NewLogNormal(src, inf, 1); return e },
+		"LogNormal(0, 0)":        func() error { _, e := NewLogNormal(src, 0, 0); return e },
+		"LogNormal(nil)":         func() error { _, e := NewLogNormal(nil, 0, 1); return e },
+		"Weibull(0, 1)":          func() error { _, e := NewWeibull(src, 0, 1); return e },
+		"Weibull(1, NaN)":        func() error { _, e := NewWeibull(src, 1, nan); return e },
+		"Weibull(nil)":           func() error { _, e := NewWeibull(nil, 1, 1); return e },
+		"Pareto(-1, 1)":          func() error { _, e := NewPareto(src, -1, 1); return e },
+		"Pareto(1, 0)":           func() error { _, e := NewPareto(src, 1, 0); return e },
+		"Pareto(nil)":            func() error { _, e := NewPareto(nil, 1, 1); return e },
+		"Cauchy(NaN, 1)":         func() error { _, e := NewCauchy(src, nan, 1); return e },
+		"Cauchy(0, 0)":           func() error { _, e := NewCauchy(src, 0, 0); return e },
+		"Cauchy(nil)":            func() error { _, e := NewCauchy(nil, 0, 1); return e },
+		"Triangular(1, 1, 1)":    func() error { _, e := NewTriangular(src, 1, 1, 1); return e },
+		"Triangular(0, 2, 1)":    func() error { _, e := NewTriangular(src, 0, 2, 1); return e },
+		"Triangular(0, NaN, 1)":  func() error { _, e := NewTriangular(src, 0, nan, 1); return e },
+		"Triangular(-Inf, 0, 1)": func() error { _, e := NewTriangular(src, -inf, 0, 1); return e },
+		"Triangular(nil)":        func() error { _, e := NewTriangular(nil, 0, 0.5, 1); return e },
+		"VonMises(Inf, 1)":       func() error { _, e := NewVonMises(src, inf, 1); return e },
+		"VonMises(0, -1)":        func() error { _, e := NewVonMises(src, 0, -1); return e },
+		"VonMises(nil)":          func() error { _, e := NewVonMises(nil, 0, 1); return e },
+	} {
+		// The test fails, if the constructor does not return an error
+		if fn() == nil {
+			t.Error(tserr.NilFailed(name))
+		}
+	}
+}
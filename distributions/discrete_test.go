@@ -0,0 +1,248 @@
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package distributions
+
+// Import standard library packages, tserr and tsrand
+import (
+	"fmt"     // fmt
+	"math"    // math
+	"testing" // testing
+
+	"github.com/thorstenrie/tserr"  // tserr
+	"github.com/thorstenrie/tsrand" // tsrand
+)
+
+// TestPoisson tests the arithmetic mean, variance and probability mass function of Poisson for the multiplication method and the PTRS method.
+func TestPoisson(t *testing.T) {
+	for _, lambda := range []float64{0.5, 3, 10, 42.5, 1e6} {
+		name := fmt.Sprintf("Poisson(%v)", lambda)
+		// Retrieve the generator
+		p, err := NewPoisson(testSource(), lambda)
+		// The test fails if an error occurs
+		if err != nil {
+			t.Fatal(tserr.NotAvailable(&tserr.NotAvailableArgs{S: name, Err: err}))
+		}
+		testMoments(t, name, p.Int64, lambda, lambda)
+		testPMF(t, name, p.Int64, func(k int64) float64 {
+			return math.Exp(float64(k)*math.Log(lambda) - lambda - logFactorial(float64(k)))
+		}, 0, int64(lambda+10*math.Sqrt(lambda)+10))
+	}
+	// The test fails, if Poisson with lambda 0 does not return 0
+	p, _ := NewPoisson(testSource(), 0)
+	if v := p.Int64(); v != 0 {
+		t.Error(tserr.Equal(&tserr.EqualArgs{Var: "Poisson(0)", Actual: v, Want: 0}))
+	}
+}
+
+// BenchmarkPoisson performs a benchmark on Poisson for the multiplication method and the PTRS method
+func BenchmarkPoisson(b *testing.B) {
+	for _, lambda := range []float64{3, 100} {
+		b.Run(fmt.Sprintf("lambda=%v", lambda), func(b *testing.B) {
+			// Retrieve the generator
+			p, err := NewPoisson(tsrand.NewXoshiro256StarStarSource(), lambda)
+			// The benchmark fails if an error occurs
+			if err != nil {
+				b.Fatal(tserr.NotAvailable(&tserr.NotAvailableArgs{S: "Poisson", Err: err}))
+			}
+			for i := 0; i < b.N; i++ {
+				p.Int64()
+			}
+		})
+	}
+}
+
+// TestBinomial tests the arithmetic mean, variance and probability mass function of Binomial for inversion and the BTPE method.
+func TestBinomial(t *testing.T) {
+	for _, c := range []struct {
+		n int64
+		p float64
+	}{{20, 0.3}, {50, 0.9}, {1000, 0.4}, {1000, 0.75}, {1000000, 0.5}} {
+		name := fmt.Sprintf("Binomial(%d, %v)", c.n, c.p)
+		// Retrieve the generator
+		bin, err := NewBinomial(testSource(), c.n, c.p)
+		// The test fails if an error occurs
+		if err != nil {
+			t.Fatal(tserr.NotAvailable(&tserr.NotAvailableArgs{S: name, Err: err}))
+		}
+		testMoments(t, name, bin.Int64, float64(c.n)*c.p, float64(c.n)*c.p*(1-c.p))
+		testPMF(t, name, bin.Int64, func(k int64) float64 {
+			return math.Exp(logChoose(c.n, k) + float64(k)*math.Log(c.p) + float64(c.n-k)*math.Log1p(-c.p))
+		}, 0, c.n)
+	}
+	// The test fails, if the trivial cases do not return 0 or n
+	for _, c := range []struct {
+		n    int64
+		p    float64
+		want int64
+	}{{0, 0.5, 0}, {10, 0, 0}, {10, 1, 10}} {
+		bin, _ := NewBinomial(testSource(), c.n, c.p)
+		if v := bin.Int64(); v != c.want {
+			t.Error(tserr.Equal(&tserr.EqualArgs{Var: fmt.Sprintf("Binomial(%d, %v)", c.n, c.p), Actual: v, Want: c.want}))
+		}
+	}
+}
+
+// TestStirling tests the correction term of Stirling's formula of the BTPE method against the logarithm of the gamma function.
+func TestStirling(t *testing.T) {
+	for _, x := range []float64{5, 10.5, 50, 1000} {
+		// Correction term ln Gamma(x) - (x-1/2)ln(x) + x - ln(2*pi)/2
+		lg, _ := math.Lgamma(x)
+		want := lg - (x-0.5)*math.Log(x) + x - 0.5*math.Log(2*math.Pi)
+		// The test fails, if the correction term differs from the expected value
+		if v := stirling(x); math.Abs(v-want) > 1e-10 {
+			t.Error(tserr.Equalf(&tserr.EqualfArgs{Var: fmt.Sprintf("stirling(%v)", x), Actual: v, Want: want}))
+		}
+	}
+}
+
+// BenchmarkBinomial performs a benchmark on Binomial for inversion and the BTPE method
+func BenchmarkBinomial(b *testing.B) {
+	for _, n := range []int64{20, 1000} {
+		b.Run(fmt.Sprintf("n=%d", n), func(b *testing.B) {
+			// Retrieve the generator
+			bin, err := NewBinomial(tsrand.NewXoshiro256StarStarSource(), n, 0.5)
+			// The benchmark fails if an error occurs
+			if err != nil {
+				b.Fatal(tserr.NotAvailable(&tserr.NotAvailableArgs{S: "Binomial", Err: err}))
+			}
+			for i := 0; i < b.N; i++ {
+				bin.Int64()
+			}
+		})
+	}
+}
+
+// TestGeometric tests the arithmetic mean, variance and probability mass function of Geometric.
+func TestGeometric(t *testing.T) {
+	for _, p := range []float64{0.05, 0.5, 0.9} {
+		name := fmt.Sprintf("Geometric(%v)", p)
+		// Retrieve the generator
+		g, err := NewGeometric(testSource(), p)
+		// The test fails if an error occurs
+		if err != nil {
+			t.Fatal(tserr.NotAvailable(&tserr.NotAvailableArgs{S: name, Err: err}))
+		}
+		testMoments(t, name, g.Int64, 1/p, (1-p)/(p*p))
+		testPMF(t, name, g.Int64, func(k int64) float64 { return math.Pow(1-p, float64(k-1)) * p }, 1, 1000)
+	}
+	// The test fails, if Geometric with p 1 does not return 1
+	g, _ := NewGeometric(testSource(), 1)
+	if v := g.Int64(); v != 1 {
+		t.Error(tserr.Equal(&tserr.EqualArgs{Var: "Geometric(1)", Actual: v, Want: 1}))
+	}
+}
+
+// BenchmarkGeometric performs a benchmark on Geometric
+func BenchmarkGeometric(b *testing.B) {
+	// Retrieve the generator
+	g, err := NewGeometric(tsrand.NewXoshiro256StarStarSource(), 0.1)
+	// The benchmark fails if an error occurs
+	if err != nil {
+		b.Fatal(tserr.NotAvailable(&tserr.NotAvailableArgs{S: "Geometric", Err: err}))
+	}
+	for i := 0; i < b.N; i++ {
+		g.Int64()
+	}
+}
+
+// TestNegativeBinomial tests the arithmetic mean, variance and probability mass function of NegativeBinomial.
+func TestNegativeBinomial(t *testing.T) {
+	for _, c := range []struct{ r, p float64 }{{0.5, 0.3}, {3, 0.5}, {20, 0.2}} {
+		name := fmt.Sprintf("NegativeBinomial(%v, %v)", c.r, c.p)
+		// Retrieve the generator
+		nb, err := NewNegativeBinomial(testSource(), c.r, c.p)
+		// The test fails if an error occurs
+		if err != nil {
+			t.Fatal(tserr.NotAvailable(&tserr.NotAvailableArgs{S: name, Err: err}))
+		}
+		testMoments(t, name, nb.Int64, c.r*(1-c.p)/c.p, c.r*(1-c.p)/(c.p*c.p))
+		testPMF(t, name, nb.Int64, func(k int64) float64 {
+			lk, _ := math.Lgamma(float64(k) + c.r)
+			lr, _ := math.Lgamma(c.r)
+			return math.Exp(lk - lr - logFactorial(float64(k)) + c.r*math.Log(c.p) + float64(k)*math.Log1p(-c.p))
+		}, 0, 1000)
+	}
+}
+
+// BenchmarkNegativeBinomial performs a benchmark on NegativeBinomial
+func BenchmarkNegativeBinomial(b *testing.B) {
+	// Retrieve the generator
+	nb, err := NewNegativeBinomial(tsrand.NewXoshiro256StarStarSource(), 3, 0.5)
+	// The benchmark fails if an error occurs
+	if err != nil {
+		b.Fatal(tserr.NotAvailable(&tserr.NotAvailableArgs{S: "NegativeBinomial", Err: err}))
+	}
+	for i := 0; i < b.N; i++ {
+		nb.Int64()
+	}
+}
+
+// TestHypergeometric tests the arithmetic mean, variance and probability mass function of Hypergeometric for drawing the items and the HRUA method.
+func TestHypergeometric(t *testing.T) {
+	for _, c := range []struct{ good, bad, sample int64 }{{10, 20, 5}, {10, 20, 25}, {100, 50, 60}, {40, 500, 100}, {5000, 3000, 4000}} {
+		name := fmt.Sprintf("Hypergeometric(%d, %d, %d)", c.good, c.bad, c.sample)
+		// Retrieve the generator
+		h, err := NewHypergeometric(testSource(), c.good, c.bad, c.sample)
+		// The test fails if an error occurs
+		if err != nil {
+			t.Fatal(tserr.NotAvailable(&tserr.NotAvailableArgs{S: name, Err: err}))
+		}
+		n, k, s := float64(c.good+c.bad), float64(c.good), float64(c.sample)
+		testMoments(t, name, h.Int64, s*k/n, s*k/n*(n-k)/n*(n-s)/(n-1))
+		testPMF(t, name, h.Int64, func(x int64) float64 {
+			if x > c.good || c.sample-x > c.bad {
+				return 0
+			}
+			return math.Exp(logChoose(c.good, x) + logChoose(c.bad, c.sample-x) - logChoose(c.good+c.bad, c.sample))
+		}, 0, c.sample)
+	}
+}
+
+// BenchmarkHypergeometric performs a benchmark on Hypergeometric for drawing the items and the HRUA method
+func BenchmarkHypergeometric(b *testing.B) {
+	for _, sample := range []int64{5, 500} {
+		b.Run(fmt.Sprintf("sample=%d", sample), func(b *testing.B) {
+			// Retrieve the generator
+			h, err := NewHypergeometric(tsrand.NewXoshiro256StarStarSource(), 1000, 1000, sample)
+			// The benchmark fails if an error occurs
+			if err != nil {
+				b.Fatal(tserr.NotAvailable(&tserr.NotAvailableArgs{S: "Hypergeometric", Err: err}))
+			}
+			for i := 0; i < b.N; i++ {
+				h.Int64()
+			}
+		})
+	}
+}
+
+// TestDiscreteInvalid tests that invalid parameters and sources of the discrete distributions result in an error.
+func TestDiscreteInvalid(t *testing.T) {
+	src := testSource()
+	// Constructors with invalid parameters or sources
+	for name, fn := range map[string]func() error{
+		"Poisson(-1)":                        func() error { _, e := NewPoisson(src, -1); return e },
+		"Poisson(NaN)":                       func() error { _, e := NewPoisson(src, math.NaN()); return e },
+		"Poisson(Inf)":                       func() error { _, e := NewPoisson(src, math.Inf(1)); return e },
+		"Poisson(nil)":                       func() error { _, e := NewPoisson(nil, 1); return e },
+		"Binomial(-1, 0.5)":                  func() error { _, e := NewBinomial(src, -1, 0.5); return e },
+		"Binomial(10, 1.5)":                  func() error { _, e := NewBinomial(src, 10, 1.5); return e },
+		"Binomial(10, NaN)":                  func() error { _, e := NewBinomial(src, 10, math.NaN()); return e },
+		"Binomial(nil)":                      func() error { _, e := NewBinomial(nil, 10, 0.5); return e },
+		"Geometric(0)":                       func() error { _, e := NewGeometric(src, 0); return e },
+		"Geometric(1.1)":                     func() error { _, e := NewGeometric(src, 1.1); return e },
+		"Geometric(nil)":                     func() error { _, e := NewGeometric(nil, 0.5); return e },
+		"NegativeBinomial(0, 0.5)":           func() error { _, e := NewNegativeBinomial(src, 0, 0.5); return e },
+		"NegativeBinomial(1, 0)":             func() error { _, e := NewNegativeBinomial(src, 1, 0); return e },
+		"NegativeBinomial(nil)":              func() error { _, e := NewNegativeBinomial(nil, 1, 0.5); return e },
+		"Hypergeometric(-1, 1, 1)":           func() error { _, e := NewHypergeometric(src, -1, 1, 1); return e },
+		"Hypergeometric(1, 1, 3)":            func() error { _, e := NewHypergeometric(src, 1, 1, 3); return e },
+		"Hypergeometric(MaxInt64, MaxInt64)": func() error { _, e := NewHypergeometric(src, math.MaxInt64, math.MaxInt64, 1); return e },
+		"Hypergeometric(nil)":                func() error { _, e := NewHypergeometric(nil, 1, 1, 1); return e },
+	} {
+		// The test fails, if the constructor does not return an error
+		if fn() == nil {
+			t.Error(tserr.NilFailed(name))
+		}
+	}
+}
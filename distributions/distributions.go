@@ -0,0 +1,92 @@
+// Package distributions provides random variates of probability distributions based on a tsrand Source.
+//
+// The generators are driven by any tsrand.Source, e.g., a reproducible pseudo-random number generator
+// like tsrand.PCG64Source or the cryptographically secure tsrand.CryptoSource. Each generator is created with
+// a constructor, which validates the parameters of the distribution and the availability of the source. If a parameter
+// is invalid or the source is not available, the constructor returns an error.
+//
+// - Normal provides normally distributed random numbers using the Ziggurat method
+// - Exponential provides exponentially distributed random numbers using the Ziggurat method
+// - Poisson provides Poisson distributed random numbers using the multiplication method or the PTRS method for large means
+// - Binomial provides binomially distributed random numbers using inversion or the BTPE method
+// - Geometric provides geometrically distributed random numbers using inversion
+// - NegativeBinomial provides negative binomially distributed random numbers as gamma-Poisson mixture
+// - Hypergeometric provides hypergeometrically distributed random numbers by drawing the items or using the HRUA method
+// - Gamma provides gamma distributed random numbers using the method of Marsaglia and Tsang
+// - Beta, ChiSquared and StudentT provide random numbers based on gamma distributed random numbers
+// - LogNormal, Weibull, Pareto, Cauchy and Triangular provide random numbers using transformation or inversion
+// - VonMises provides von Mises distributed random angles using the method of Best and Fisher
+// - AliasTable provides random indices of weighted categories in constant time using the alias method of Vose
+// - DynamicTable provides random indices of weighted categories with weight updates in logarithmic time
+//
+// The constructors return an error from tserr, if a parameter is invalid, e.g., NaN, infinite or out of range.
+//
+// A generator is safe for concurrent use by multiple goroutines only if its source is, e.g., a source wrapped by tsrand.NewLockedSource.
+// The output is as predictable as the output of the source.
+//
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package distributions
+
+// Import standard library packages, tserr and tsrand
+import (
+	"fmt"  // fmt
+	"math" // math
+
+	"github.com/thorstenrie/tserr"  // tserr
+	"github.com/thorstenrie/tsrand" // tsrand
+)
+
+// checkSource returns an error, if src is nil or not available on the platform.
+func checkSource(src tsrand.Source) error {
+	// Return an error, if src is nil
+	if src == nil {
+		return tserr.NilPtr()
+	}
+	// Call Assert and check if Err returns an error
+	if src.Assert(); src.Err() != nil {
+		return tserr.NotAvailable(&tserr.NotAvailableArgs{S: "Source", Err: src.Err()})
+	}
+	// Return nil
+	return nil
+}
+
+// errParam returns an error for the parameter name with the invalid value v, which is expected to be want.
+func errParam(name string, v float64, want string) error {
+	return tserr.Check(&tserr.CheckArgs{F: "parameter " + name, Err: fmt.Errorf("value is %v, but expected to be %v", v, want)})
+}
+
+// checkFinite returns an error, if the parameter name with value v is not a finite number.
+func checkFinite(name string, v float64) error {
+	if math.IsNaN(v) || math.IsInf(v, 0) {
+		return errParam(name, v, "finite")
+	}
+	return nil
+}
+
+// checkPositive returns an error, if the parameter name with value v is not a finite number higher than 0.
+func checkPositive(name string, v float64) error {
+	if math.IsNaN(v) || math.IsInf(v, 0) || v <= 0 {
+		return errParam(name, v, "finite and higher than 0")
+	}
+	return nil
+}
+
+// uniform returns a random number of src in the half-open interval [0,1) with 53 bits of precision.
+func uniform(src tsrand.Source) float64 {
+	return float64(src.Uint64()>>11) * 0x1.0p-53
+}
+
+// checkProbability returns an error, if the parameter name with value v is not in the interval [0,1].
+func checkProbability(name string, v float64) error {
+	if math.IsNaN(v) || v < 0 || v > 1 {
+		return errParam(name, v, "in the interval [0,1]")
+	}
+	return nil
+}
+
+// uniformOpen returns a random number of src in the open interval (0,1) with 53 bits of precision.
+func uniformOpen(src tsrand.Source) float64 {
+	return (float64(src.Uint64()>>11) + 0.5) * 0x1.0p-53
+}
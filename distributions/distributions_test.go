@@ -0,0 +1,127 @@
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package distributions
+
+// Import standard library packages, tserr and tsrand
+import (
+	"math"    // math
+	"testing" // testing
+
+	"github.com/thorstenrie/tserr"  // tserr
+	"github.com/thorstenrie/tsrand" // tsrand
+)
+
+// TestStdNormal tests the arithmetic mean, variance and goodness-of-fit of StdNormal.
+func TestStdNormal(t *testing.T) {
+	src := testSource()
+	fn := func() float64 { return StdNormal(src) }
+	testMoments(t, "StdNormal", fn, 0, 1)
+	testKS(t, "StdNormal", fn, func(x float64) float64 { return normalCDF(x, 0, 1) })
+}
+
+// TestStdNormalTail tests the probability of the tail beyond the base strip of the Ziggurat.
+func TestStdNormalTail(t *testing.T) {
+	src := testSource()
+	// Count random numbers beyond the start of the tail
+	n := 0
+	for i := 0; i < testItr; i++ {
+		if math.Abs(StdNormal(src)) > zigc.normalR {
+			n++
+		}
+	}
+	// Expected number of random numbers beyond the start of the tail
+	want := float64(testItr) * math.Erfc(zigc.normalR/math.Sqrt2)
+	// The test fails, if the number differs more than five standard deviations
+	if math.Abs(float64(n)-want) > 5*math.Sqrt(want) {
+		t.Error(tserr.Equalf(&tserr.EqualfArgs{Var: "random numbers in the tail", Actual: float64(n), Want: want}))
+	}
+}
+
+// TestNormal tests the arithmetic mean, variance and goodness-of-fit of Normal.
+func TestNormal(t *testing.T) {
+	mu, sigma := -3.0, 2.5
+	// Retrieve the generator
+	n, err := NewNormal(testSource(), mu, sigma)
+	// The test fails if an error occurs
+	if err != nil {
+		t.Fatal(tserr.NotAvailable(&tserr.NotAvailableArgs{S: "Normal", Err: err}))
+	}
+	testMoments(t, "Normal", n.Float64, mu, sigma*sigma)
+	testKS(t, "Normal", n.Float64, func(x float64) float64 { return normalCDF(x, mu, sigma) })
+}
+
+// BenchmarkNormal performs a benchmark on Normal
+func BenchmarkNormal(b *testing.B) {
+	// Retrieve the generator
+	n, err := NewNormal(tsrand.NewXoshiro256StarStarSource(), 0, 1)
+	// The benchmark fails if an error occurs
+	if err != nil {
+		b.Fatal(tserr.NotAvailable(&tserr.NotAvailableArgs{S: "Normal", Err: err}))
+	}
+	for i := 0; i < b.N; i++ {
+		n.Float64()
+	}
+}
+
+// TestStdExponential tests the arithmetic mean, variance and goodness-of-fit of StdExponential.
+func TestStdExponential(t *testing.T) {
+	src := testSource()
+	fn := func() float64 { return StdExponential(src) }
+	testMoments(t, "StdExponential", fn, 1, 1)
+	testKS(t, "StdExponential", fn, func(x float64) float64 { return -math.Expm1(-x) })
+}
+
+// TestExponential tests the arithmetic mean, variance and goodness-of-fit of Exponential.
+func TestExponential(t *testing.T) {
+	lambda := 0.25
+	// Retrieve the generator
+	x, err := NewExponential(testSource(), lambda)
+	// The test fails if an error occurs
+	if err != nil {
+		t.Fatal(tserr.NotAvailable(&tserr.NotAvailableArgs{S: "Exponential", Err: err}))
+	}
+	testMoments(t, "Exponential", x.Float64, 1/lambda, 1/(lambda*lambda))
+	testKS(t, "Exponential", x.Float64, func(v float64) float64 { return -math.Expm1(-lambda * v) })
+}
+
+// BenchmarkExponential performs a benchmark on Exponential
+func BenchmarkExponential(b *testing.B) {
+	// Retrieve the generator
+	x, err := NewExponential(tsrand.NewXoshiro256StarStarSource(), 1)
+	// The benchmark fails if an error occurs
+	if err != nil {
+		b.Fatal(tserr.NotAvailable(&tserr.NotAvailableArgs{S: "Exponential", Err: err}))
+	}
+	for i := 0; i < b.N; i++ {
+		x.Float64()
+	}
+}
+
+// TestNormalInvalid tests that invalid parameters and sources result in an error.
+func TestNormalInvalid(t *testing.T) {
+	// Invalid parameters of Normal
+	for _, p := range [][2]float64{{math.NaN(), 1}, {math.Inf(1), 1}, {0, 0}, {0, -1}, {0, math.NaN()}, {0, math.Inf(1)}} {
+		if _, err := NewNormal(testSource(), p[0], p[1]); err == nil {
+			t.Error(tserr.NilFailed("NewNormal"))
+		}
+	}
+	// The test fails, if a nil source does not result in an error
+	if _, err := NewNormal(nil, 0, 1); err == nil {
+		t.Error(tserr.NilFailed("NewNormal"))
+	}
+}
+
+// TestExponentialInvalid tests that invalid parameters and sources result in an error.
+func TestExponentialInvalid(t *testing.T) {
+	// Invalid parameters of Exponential
+	for _, lambda := range []float64{0, -1, math.NaN(), math.Inf(1)} {
+		if _, err := NewExponential(testSource(), lambda); err == nil {
+			t.Error(tserr.NilFailed("NewExponential"))
+		}
+	}
+	// The test fails, if a nil source does not result in an error
+	if _, err := NewExponential(nil, 1); err == nil {
+		t.Error(tserr.NilFailed("NewExponential"))
+	}
+}
@@ -0,0 +1,215 @@
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package distributions
+
+// Import standard library packages, lpstats, tserr and tsrand
+import (
+	"fmt"     // fmt
+	"math"    // math
+	"sort"    // sort
+	"testing" // testing
+
+	"github.com/thorstenrie/lpstats" // lpstats
+	"github.com/thorstenrie/tserr"   // tserr
+	"github.com/thorstenrie/tsrand"  // tsrand
+)
+
+// Parameters of the statistical tests
+const (
+	testItr   int     = 1000000 // number of iterations for random number generation tests
+	testKSItr int     = 100000  // number of random numbers for the Kolmogorov-Smirnov test
+	testCalls int     = 1000    // number of calls for tests of single values
+	maxDiff   float64 = 0.02    // maximum difference of near equal comparison relative to the standard deviation or variance
+	minP      float64 = 0.001   // minimum p-value of the Kolmogorov-Smirnov test
+)
+
+// Each test generates random numbers of a distribution using a deterministic source. It compares the arithmetic mean and
+// variance of the random numbers with the expected values for mean and variance. If they differ more than maxDiff relative to the
+// expected standard deviation or variance, the test fails. Additionally, continuous distributions are tested for goodness-of-fit
+// with the Kolmogorov-Smirnov test. The test fails, if the p-value is lower than minP.
+
+// testSource returns a deterministic source for the tests.
+func testSource() tsrand.Source {
+	return tsrand.NewPCG64Source()
+}
+
+// testMoments retrieves testItr random numbers from fn and compares their arithmetic mean and variance with the expected
+// values meane and varie. The test fails, if they differ more than maxDiff relative to the expected standard deviation or variance.
+func testMoments[T lpstats.Number](t *testing.T, name string, fn func() T, meane, varie float64) {
+	// Panic if t is nil
+	if t == nil {
+		panic("nil pointer")
+	}
+	// Allocate and initialize slice a with size testItr
+	a := make([]T, testItr)
+	// Iterate random number generator testItr times
+	for i := range a {
+		a[i] = fn()
+	}
+	// Calculate the arithmetic mean of the random numbers
+	mean, e := lpstats.ArithmeticMean(a)
+	// The test fails if arithmetic mean returns an error
+	if e != nil {
+		t.Error(tserr.Op(&tserr.OpArgs{Op: "ArithmeticMean", Fn: name, Err: e}))
+	}
+	// The test fails if the arithmetic mean does not equal the expected value
+	if !lpstats.NearEqual(mean, meane, maxDiff*math.Sqrt(varie)) {
+		t.Error(tserr.Equalf(&tserr.EqualfArgs{Var: "Arithmetic mean of " + name, Actual: mean, Want: meane}))
+	}
+	// Calculate the variance of the random numbers
+	vari, e := lpstats.Variance(a)
+	// The test fails if variance returns an error
+	if e != nil {
+		t.Error(tserr.Op(&tserr.OpArgs{Op: "Variance", Fn: name, Err: e}))
+	}
+	// The test fails if the variance does not equal the expected variance
+	if !lpstats.NearEqual(vari, varie, maxDiff*varie) {
+		t.Error(tserr.Equalf(&tserr.EqualfArgs{Var: "Variance of " + name, Actual: vari, Want: varie}))
+	}
+}
+
+// testKS retrieves testKSItr random numbers from fn and performs the Kolmogorov-Smirnov test against the cumulative
+// distribution function cdf. The test fails, if the p-value is lower than minP.
+func testKS(t *testing.T, name string, fn func() float64, cdf func(float64) float64) {
+	// Panic if t is nil
+	if t == nil {
+		panic("nil pointer")
+	}
+	// Retrieve and sort random numbers
+	a := make([]float64, testKSItr)
+	for i := range a {
+		a[i] = fn()
+	}
+	sort.Float64s(a)
+	// Compute the Kolmogorov-Smirnov statistic d as maximum distance between the empirical and expected distribution
+	n := float64(len(a))
+	d := 0.0
+	for i, x := range a {
+		c := cdf(x)
+		d = math.Max(d, math.Max(float64(i+1)/n-c, c-float64(i)/n))
+	}
+	// The test fails, if the p-value is lower than minP
+	if p := ksPValue(d, len(a)); p < minP {
+		t.Error(tserr.Equalf(&tserr.EqualfArgs{Var: "p-value of Kolmogorov-Smirnov test of " + name, Actual: p, Want: 1}))
+	}
+}
+
+// ksPValue returns the asymptotic p-value of the Kolmogorov-Smirnov statistic d for n samples based on the
+// Kolmogorov distribution with the correction of Stephens.
+func ksPValue(d float64, n int) float64 {
+	sn := math.Sqrt(float64(n))
+	l := (sn + 0.12 + 0.11/sn) * d
+	p := 0.0
+	for k := 1; k <= 100; k++ {
+		term := 2 * math.Exp(-2*float64(k*k)*l*l)
+		if k%2 == 0 {
+			term = -term
+		}
+		p += term
+	}
+	return math.Max(0, math.Min(1, p))
+}
+
+// normalCDF returns the cumulative distribution function of the normal distribution with mean mu and standard deviation sigma at x.
+func normalCDF(x, mu, sigma float64) float64 {
+	return 0.5 * math.Erfc(-(x-mu)/(sigma*math.Sqrt2))
+}
+
+// testPMF retrieves testItr random numbers from fn and compares the frequency of each value k with the probability mass function pmf
+// in the interval [lo,hi]. The test fails, if the frequency of a value with an expected count of at least 100 differs more than
+// five standard deviations from the expected count.
+func testPMF(t *testing.T, name string, fn func() int64, pmf func(int64) float64, lo, hi int64) {
+	// Panic if t is nil
+	if t == nil {
+		panic("nil pointer")
+	}
+	// Count the frequency of each value
+	c := make(map[int64]int)
+	for i := 0; i < testItr; i++ {
+		c[fn()]++
+	}
+	// Compare the frequencies with the expected counts
+	for k := lo; k <= hi; k++ {
+		want := float64(testItr) * pmf(k)
+		if want < 100 {
+			continue
+		}
+		if math.Abs(float64(c[k])-want) > 5*math.Sqrt(want) {
+			t.Error(tserr.Equalf(&tserr.EqualfArgs{Var: fmt.Sprintf("frequency of %d of %s", k, name), Actual: float64(c[k]), Want: want}))
+		}
+	}
+}
+
+// logChoose returns the natural logarithm of the binomial coefficient n over k.
+func logChoose(n, k int64) float64 {
+	return logFactorial(float64(n)) - logFactorial(float64(k)) - logFactorial(float64(n-k))
+}
+
+// gammaP returns the regularized lower incomplete gamma function P(a,x) based on its series for x < a+1
+// and its continued fraction otherwise.
+func gammaP(a, x float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+	lg, _ := math.Lgamma(a)
+	if x < a+1 {
+		// Series
+		sum, term := 1/a, 1/a
+		for n := 1; n < 1000; n++ {
+			term *= x / (a + float64(n))
+			sum += term
+			if math.Abs(term) < math.Abs(sum)*1e-15 {
+				break
+			}
+		}
+		return sum * math.Exp(-x+a*math.Log(x)-lg)
+	}
+	// Continued fraction with the modified Lentz method
+	tiny := 1e-300
+	b := x + 1 - a
+	c := 1 / tiny
+	d := 1 / b
+	h := d
+	for n := 1; n < 1000; n++ {
+		an := -float64(n) * (float64(n) - a)
+		b += 2
+		d = an*d + b
+		if math.Abs(d) < tiny {
+			d = tiny
+		}
+		c = b + an/c
+		if math.Abs(c) < tiny {
+			c = tiny
+		}
+		d = 1 / d
+		del := d * c
+		h *= del
+		if math.Abs(del-1) < 1e-15 {
+			break
+		}
+	}
+	return 1 - math.Exp(-x+a*math.Log(x)-lg)*h
+}
+
+// besselI returns the modified Bessel function of the first kind I_n(x) based on its series.
+func besselI(n int, x float64) float64 {
+	sum := 0.0
+	term := math.Pow(x/2, float64(n))
+	for k := 1; k <= n; k++ {
+		term /= float64(k)
+	}
+	for m := 0; m < 1000; m++ {
+		sum += term
+		term *= (x / 2) * (x / 2) / (float64(m+1) * float64(m+1+n))
+		if term < sum*1e-16 {
+			break
+		}
+	}
+	return sum
+}
+
+// nearEqualRel returns whether a and b near equal with a maximum relative difference of 1e-12.
+func nearEqualRel(a, b float64) bool {
+	return math.Abs(a-b) <= 1e-12*math.Max(math.Abs(a), math.Abs(b))
+}
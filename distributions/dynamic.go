@@ -0,0 +1,120 @@
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package distributions
+
+// Import standard library package fmt, math, tserr and tsrand
+import (
+	"fmt"  // fmt
+	"math" // math
+
+	"github.com/thorstenrie/tserr"  // tserr
+	"github.com/thorstenrie/tsrand" // tsrand
+)
+
+// DynamicTable provides random indices of weighted categories like AliasTable, but the weights can be updated. Index i is returned
+// with probability weights[i]/sum(weights). DynamicTable holds the source src and a complete binary tree, whose leaves are the weights and
+// whose inner nodes are the sums of their children. Both, updating a weight and retrieving a random index, need logarithmic time. Since the
+// sums are recomputed from the children on each update, rounding errors do not accumulate. DynamicTable is not safe for concurrent use by
+// multiple goroutines.
+type DynamicTable struct {
+	src  tsrand.Source // source of random numbers
+	n    int           // number of categories
+	size int           // number of leaves, a power of 2
+	tree []float64     // binary tree with root 1 and children 2*i and 2*i+1 of node i
+}
+
+// NewDynamicTable returns a new instance of DynamicTable for weights using src. The weights are not modified and not referenced afterwards.
+// It returns an error, if weights is empty, contains a negative, NaN or infinite weight, the sum of weights is 0 or infinite, or if src is nil
+// or not available on the platform.
+func NewDynamicTable(src tsrand.Source, weights []float64) (*DynamicTable, error) {
+	// Return an error, if the weights are invalid
+	if _, e := checkWeights(weights); e != nil {
+		return nil, e
+	}
+	// Return an error, if src is not available
+	if e := checkSource(src); e != nil {
+		return nil, e
+	}
+	// Number of leaves as power of 2
+	size := 1
+	for size < len(weights) {
+		size *= 2
+	}
+	d := &DynamicTable{src: src, n: len(weights), size: size, tree: make([]float64, 2*size)}
+	// Set leaves and compute sums of the inner nodes
+	copy(d.tree[size:], weights)
+	for i := size - 1; i >= 1; i-- {
+		d.tree[i] = d.tree[2*i] + d.tree[2*i+1]
+	}
+	// Return DynamicTable
+	return d, nil
+}
+
+// Len returns the number of categories of the DynamicTable.
+func (d *DynamicTable) Len() int {
+	return d.n
+}
+
+// Weight returns the weight of index i. It panics, if i is not in the half-open interval [0,Len()).
+func (d *DynamicTable) Weight(i int) float64 {
+	if i < 0 || i >= d.n {
+		panic(tserr.NotExistent(fmt.Sprintf("weights[%d]", i)))
+	}
+	return d.tree[d.size+i]
+}
+
+// Sum returns the sum of all weights.
+func (d *DynamicTable) Sum() float64 {
+	return d.tree[1]
+}
+
+// Update sets the weight of index i to w. It returns an error, if i is not in the half-open interval [0,Len()), w is negative,
+// NaN or infinite, or the sum of weights becomes 0 or infinite. In case of an error, the weights are not changed.
+func (d *DynamicTable) Update(i int, w float64) error {
+	// Return an error, if i or w is invalid
+	if i < 0 || i >= d.n {
+		return tserr.NotExistent(fmt.Sprintf("weights[%d]", i))
+	}
+	if e := checkWeight(i, w); e != nil {
+		return e
+	}
+	// Set weight and return an error, if the sum becomes invalid
+	old := d.tree[d.size+i]
+	d.set(i, w)
+	if s := d.tree[1]; s == 0 || math.IsInf(s, 0) {
+		d.set(i, old)
+		return errParam("sum of weights", s, "finite and higher than 0")
+	}
+	return nil
+}
+
+// set sets the weight of index i to w and recomputes the sums on the path to the root.
+func (d *DynamicTable) set(i int, w float64) {
+	p := d.size + i
+	d.tree[p] = w
+	for p /= 2; p >= 1; p /= 2 {
+		d.tree[p] = d.tree[2*p] + d.tree[2*p+1]
+	}
+}
+
+// Int returns a random index in the half-open interval [0,Len()) with the probability of its weight. An index with weight 0 is never returned.
+func (d *DynamicTable) Int() int {
+	// Random position in the interval [0, sum)
+	u := uniform(d.src) * d.tree[1]
+	// Descend from the root to the leaf containing the position
+	p := 1
+	for p < d.size {
+		l := 2 * p
+		// Go right, if the position is beyond the left subtree or the left subtree has weight 0. Due to rounding
+		// errors, the position may exceed the sum of the right subtree, in this case the right subtree is only
+		// chosen, if it has a weight higher than 0.
+		if (u >= d.tree[l] && d.tree[l+1] > 0) || d.tree[l] == 0 {
+			u -= d.tree[l]
+			p = l + 1
+		} else {
+			p = l
+		}
+	}
+	return p - d.size
+}
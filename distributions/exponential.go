@@ -0,0 +1,37 @@
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package distributions
+
+// Import tsrand
+import (
+	"github.com/thorstenrie/tsrand" // tsrand
+)
+
+// Exponential provides exponentially distributed random numbers with rate lambda and mean 1/lambda.
+// It holds the source src and the parameter of the distribution. The random numbers are generated with the
+// Ziggurat method by StdExponential. Exponential is safe for concurrent use by multiple goroutines only if src is.
+type Exponential struct {
+	src    tsrand.Source // source of random numbers
+	lambda float64       // rate
+}
+
+// NewExponential returns a new instance of Exponential with rate lambda using src. It returns an error,
+// if lambda is not finite and higher than 0, or if src is nil or not available on the platform.
+func NewExponential(src tsrand.Source, lambda float64) (*Exponential, error) {
+	// Return an error, if lambda is invalid
+	if e := checkPositive("lambda", lambda); e != nil {
+		return nil, e
+	}
+	// Return an error, if src is not available
+	if e := checkSource(src); e != nil {
+		return nil, e
+	}
+	// Return Exponential
+	return &Exponential{src: src, lambda: lambda}, nil
+}
+
+// Float64 returns an exponentially distributed random number in the interval [0, +math.MaxFloat64].
+func (x *Exponential) Float64() float64 {
+	return StdExponential(x.src) / x.lambda
+}
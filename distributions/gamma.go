@@ -0,0 +1,84 @@
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package distributions
+
+// Import standard library package math and tsrand
+import (
+	"math" // math
+
+	"github.com/thorstenrie/tsrand" // tsrand
+)
+
+// Gamma provides gamma distributed random numbers with shape k and scale theta. It holds the source src and the parameters of the
+// distribution. The random numbers are generated with the method of Marsaglia and Tsang. Gamma is safe for concurrent use by multiple
+// goroutines only if src is.
+type Gamma struct {
+	src   tsrand.Source // source of random numbers
+	shape float64       // shape k
+	scale float64       // scale theta
+}
+
+// NewGamma returns a new instance of Gamma with shape and scale using src. It returns an error, if shape or scale
+// is not finite and higher than 0, or if src is nil or not available on the platform.
+func NewGamma(src tsrand.Source, shape, scale float64) (*Gamma, error) {
+	// Return an error, if a parameter is invalid
+	if e := checkPositive("shape", shape); e != nil {
+		return nil, e
+	}
+	if e := checkPositive("scale", scale); e != nil {
+		return nil, e
+	}
+	// Return an error, if src is not available
+	if e := checkSource(src); e != nil {
+		return nil, e
+	}
+	// Return Gamma
+	return &Gamma{src: src, shape: shape, scale: scale}, nil
+}
+
+// Float64 returns a gamma distributed random number in the interval [0, +math.MaxFloat64].
+func (g *Gamma) Float64() float64 {
+	return g.scale * stdGamma(g.src, g.shape)
+}
+
+// stdGamma returns a gamma distributed random number with shape and scale 1 using src. It is based on the method of
+// Marsaglia and Tsang. For shape < 1, the random number is boosted by a random number with shape + 1. The shape must be higher than 0.
+func stdGamma(src tsrand.Source, shape float64) float64 {
+	// Boost for shape < 1 with X = Y * U^(1/shape), Y with shape + 1
+	if shape < 1 {
+		return stdGamma(src, shape+1) * math.Pow(uniformOpen(src), 1/shape)
+	}
+	d := shape - 1.0/3
+	c := 1 / math.Sqrt(9*d)
+	for {
+		// Retrieve normally distributed x with v = (1 + c*x)^3 > 0
+		var x, v float64
+		for {
+			x = StdNormal(src)
+			v = 1 + c*x
+			if v > 0 {
+				break
+			}
+		}
+		v = v * v * v
+		u := uniform(src)
+		// Fast acceptance with squeeze
+		if u < 1-0.0331*(x*x)*(x*x) {
+			return d * v
+		}
+		// Acceptance
+		if math.Log(u) < 0.5*x*x+d*(1-v+math.Log(v)) {
+			return d * v
+		}
+	}
+}
+
+// logStdGamma returns the natural logarithm of a gamma distributed random number with shape and scale 1 using src.
+// For shape < 1, it avoids the underflow of the boost in stdGamma.
+func logStdGamma(src tsrand.Source, shape float64) float64 {
+	if shape < 1 {
+		return math.Log(stdGamma(src, shape+1)) + math.Log(uniformOpen(src))/shape
+	}
+	return math.Log(stdGamma(src, shape))
+}
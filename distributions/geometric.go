@@ -0,0 +1,54 @@
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package distributions
+
+// Import standard library package math and tsrand
+import (
+	"math" // math
+
+	"github.com/thorstenrie/tsrand" // tsrand
+)
+
+// Geometric provides geometrically distributed random numbers, which are the number of independent trials up to and including
+// the first success with success probability p. It holds the source src and the parameter of the distribution. The random numbers
+// are generated by inversion based on an exponentially distributed random number. Geometric is safe for concurrent use by multiple
+// goroutines only if src is.
+type Geometric struct {
+	src tsrand.Source // source of random numbers
+	p   float64       // success probability
+}
+
+// NewGeometric returns a new instance of Geometric with success probability p using src. It returns an error,
+// if p is not in the interval (0,1], or if src is nil or not available on the platform.
+func NewGeometric(src tsrand.Source, p float64) (*Geometric, error) {
+	// Return an error, if p is invalid
+	if math.IsNaN(p) || p <= 0 || p > 1 {
+		return nil, errParam("p", p, "in the interval (0,1]")
+	}
+	// Return an error, if src is not available
+	if e := checkSource(src); e != nil {
+		return nil, e
+	}
+	// Return Geometric
+	return &Geometric{src: src, p: p}, nil
+}
+
+// Int64 returns a geometrically distributed random number in the interval [1, math.MaxInt64].
+func (g *Geometric) Int64() int64 {
+	// The first trial is a success, if p is 1
+	if g.p == 1 {
+		return 1
+	}
+	// Inversion with P(X > k) = (1-p)^k
+	x := math.Ceil(-StdExponential(g.src) / math.Log1p(-g.p))
+	// Return at least 1 and at most math.MaxInt64
+	switch {
+	case x < 1:
+		return 1
+	case x >= math.MaxInt64:
+		return math.MaxInt64
+	default:
+		return int64(x)
+	}
+}
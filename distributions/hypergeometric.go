@@ -0,0 +1,156 @@
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package distributions
+
+// Import standard library packages, tserr and tsrand
+import (
+	"math" // math
+
+	"github.com/thorstenrie/tserr"  // tserr
+	"github.com/thorstenrie/tsrand" // tsrand
+)
+
+// Parameters of the hypergeometric distribution
+var (
+	hypergeometricc = struct {
+		hrua   int64   // minimum sample size and distance to the population size for the HRUA method
+		d1, d2 float64 // constants of the HRUA method
+	}{
+		hrua: 10,
+		d1:   1.7155277699214135, // 2*sqrt(2/e)
+		d2:   0.8989161620588988, // 3 - 2*sqrt(3/e)
+	}
+)
+
+// Hypergeometric provides hypergeometrically distributed random numbers, which are the number of good items in a sample drawn without
+// replacement from a population of good and bad items. It holds the source src and the parameters of the distribution. For a small sample
+// or a sample of nearly the full population, the random numbers are generated by drawing the items. Otherwise, they are generated with the
+// ratio of uniforms method HRUA of Stadlober. Hypergeometric is safe for concurrent use by multiple goroutines only if src is.
+type Hypergeometric struct {
+	src    tsrand.Source // source of random numbers
+	good   int64         // number of good items
+	bad    int64         // number of bad items
+	sample int64         // number of drawn items
+}
+
+// NewHypergeometric returns a new instance of Hypergeometric with good and bad items and a sample size of sample using src. It returns an error,
+// if good, bad or sample is lower than 0, good + bad overflows, sample is higher than good + bad, or if src is nil or not available on the platform.
+func NewHypergeometric(src tsrand.Source, good, bad, sample int64) (*Hypergeometric, error) {
+	// Return an error, if a parameter is invalid
+	for _, p := range []struct {
+		name string
+		v    int64
+	}{{"good", good}, {"bad", bad}, {"sample", sample}} {
+		if p.v < 0 {
+			return nil, tserr.Higher(&tserr.HigherArgs{Var: p.name, Actual: p.v, LowerBound: 0})
+		}
+	}
+	if good > math.MaxInt64-bad {
+		return nil, tserr.Forbidden("good + bad higher than MaxInt64")
+	}
+	if sample > good+bad {
+		return nil, tserr.Higher(&tserr.HigherArgs{Var: "good + bad", Actual: good + bad, LowerBound: sample})
+	}
+	// Return an error, if src is not available
+	if e := checkSource(src); e != nil {
+		return nil, e
+	}
+	// Return Hypergeometric
+	return &Hypergeometric{src: src, good: good, bad: bad, sample: sample}, nil
+}
+
+// Int64 returns a hypergeometrically distributed random number in the interval [max(0, sample-bad), min(sample, good)].
+func (h *Hypergeometric) Int64() int64 {
+	if h.sample >= hypergeometricc.hrua && h.sample <= h.good+h.bad-hypergeometricc.hrua {
+		return hypergeometricHRUA(h.src, h.good, h.bad, h.sample)
+	}
+	return hypergeometricSample(h.src, h.good, h.bad, h.sample)
+}
+
+// hypergeometricSample returns a hypergeometrically distributed random number using src by drawing the items. If the sample
+// contains more than half of the population, the items not in the sample are drawn instead.
+func hypergeometricSample(src tsrand.Source, good, bad, sample int64) int64 {
+	total := good + bad
+	// Draw the smaller of the sample and its complement
+	selected := sample
+	if sample > total/2 {
+		selected = total - sample
+	}
+	remTotal, remGood := total, good
+	for selected > 0 && remGood > 0 && remTotal > remGood {
+		// Draw one of the remaining items and check if it is good
+		if int64(tsrand.Uint64n(src, uint64(remTotal))) < remGood {
+			remGood--
+		}
+		remTotal--
+		selected--
+	}
+	// Only good items are left
+	if remTotal == remGood {
+		remGood -= selected
+	}
+	// Return the good items in the sample
+	if sample > total/2 {
+		return remGood
+	}
+	return good - remGood
+}
+
+// hypergeometricHRUA returns a hypergeometrically distributed random number using src based on the ratio of uniforms method HRUA
+// of Stadlober, "The ratio of uniforms approach for generating discrete random variates", 1990.
+func hypergeometricHRUA(src tsrand.Source, good, bad, sample int64) int64 {
+	// Setup with the smaller of the sample and its complement and the smaller of good and bad
+	total := good + bad
+	s := min(sample, total-sample)
+	ming, maxg := min(good, bad), max(good, bad)
+	p := float64(ming) / float64(total)
+	q := float64(maxg) / float64(total)
+	mu := float64(s) * p
+	a := mu + 0.5
+	variance := float64(total-s) * float64(s) * p * q / float64(total-1)
+	c := math.Sqrt(variance + 0.5)
+	h := hypergeometricc.d1*c + hypergeometricc.d2
+	m := math.Floor(float64(s+1) * float64(ming+1) / float64(total+2))
+	g := logFactorial(m) + logFactorial(float64(ming)-m) + logFactorial(float64(s)-m) + logFactorial(float64(maxg-s)+m)
+	b := math.Min(float64(min(s, ming)+1), math.Floor(a+16*c))
+	var k float64
+	for {
+		u := uniform(src)
+		v := uniform(src)
+		x := a + h*(v-0.5)/u
+		// Fast rejection
+		if x < 0 || x >= b {
+			continue
+		}
+		k = math.Floor(x)
+		t := g - (logFactorial(k) + logFactorial(float64(ming)-k) + logFactorial(float64(s)-k) + logFactorial(float64(maxg-s)+k))
+		// Fast acceptance
+		if u*(4-u)-3 <= t {
+			break
+		}
+		// Fast rejection
+		if u*(u-t) >= 1 {
+			continue
+		}
+		// Acceptance
+		if 2*math.Log(u) <= t {
+			break
+		}
+	}
+	// Map the result back to good items in the sample
+	r := int64(k)
+	if good > bad {
+		r = s - r
+	}
+	if s < sample {
+		r = good - r
+	}
+	return r
+}
+
+// logFactorial returns the natural logarithm of x! for a non-negative integer x.
+func logFactorial(x float64) float64 {
+	lg, _ := math.Lgamma(x + 1)
+	return lg
+}
@@ -0,0 +1,43 @@
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package distributions
+
+// Import standard library package math and tsrand
+import (
+	"math" // math
+
+	"github.com/thorstenrie/tsrand" // tsrand
+)
+
+// LogNormal provides log-normally distributed random numbers, whose logarithm is normally distributed with mean mu and standard deviation sigma.
+// It holds the source src and the parameters of the distribution. The random numbers are generated as exp(mu + sigma*Z) with a standard normally
+// distributed random number Z. LogNormal is safe for concurrent use by multiple goroutines only if src is.
+type LogNormal struct {
+	src   tsrand.Source // source of random numbers
+	mu    float64       // mean of the logarithm
+	sigma float64       // standard deviation of the logarithm
+}
+
+// NewLogNormal returns a new instance of LogNormal with mean mu and standard deviation sigma of the logarithm using src. It returns an error,
+// if mu is not finite, sigma is not finite and higher than 0, or if src is nil or not available on the platform.
+func NewLogNormal(src tsrand.Source, mu, sigma float64) (*LogNormal, error) {
+	// Return an error, if a parameter is invalid
+	if e := checkFinite("mu", mu); e != nil {
+		return nil, e
+	}
+	if e := checkPositive("sigma", sigma); e != nil {
+		return nil, e
+	}
+	// Return an error, if src is not available
+	if e := checkSource(src); e != nil {
+		return nil, e
+	}
+	// Return LogNormal
+	return &LogNormal{src: src, mu: mu, sigma: sigma}, nil
+}
+
+// Float64 returns a log-normally distributed random number in the interval [0, +Inf].
+func (l *LogNormal) Float64() float64 {
+	return math.Exp(l.mu + l.sigma*StdNormal(l.src))
+}
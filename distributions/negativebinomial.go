@@ -0,0 +1,51 @@
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package distributions
+
+// Import standard library package math and tsrand
+import (
+	"math" // math
+
+	"github.com/thorstenrie/tsrand" // tsrand
+)
+
+// NegativeBinomial provides negative binomially distributed random numbers, which are the number of failures before the r-th success
+// in independent trials with success probability p. The number of successes r may be any positive real number. It holds the source src
+// and the parameters of the distribution. The random numbers are generated as Poisson distributed random numbers with a gamma distributed mean
+// with shape r and scale (1-p)/p. NegativeBinomial is safe for concurrent use by multiple goroutines only if src is.
+type NegativeBinomial struct {
+	src tsrand.Source // source of random numbers
+	r   float64       // number of successes
+	p   float64       // success probability
+}
+
+// NewNegativeBinomial returns a new instance of NegativeBinomial with r successes and success probability p using src. It returns an error,
+// if r is not finite and higher than 0, p is not in the interval (0,1], or if src is nil or not available on the platform.
+func NewNegativeBinomial(src tsrand.Source, r, p float64) (*NegativeBinomial, error) {
+	// Return an error, if a parameter is invalid
+	if e := checkPositive("r", r); e != nil {
+		return nil, e
+	}
+	if math.IsNaN(p) || p <= 0 || p > 1 {
+		return nil, errParam("p", p, "in the interval (0,1]")
+	}
+	// Return an error, if src is not available
+	if e := checkSource(src); e != nil {
+		return nil, e
+	}
+	// Return NegativeBinomial
+	return &NegativeBinomial{src: src, r: r, p: p}, nil
+}
+
+// Int64 returns a negative binomially distributed random number in the interval [0, math.MaxInt64].
+func (nb *NegativeBinomial) Int64() int64 {
+	// No failures occur, if p is 1
+	if nb.p == 1 {
+		return 0
+	}
+	// Gamma distributed mean of the Poisson distribution, limited to the maximum lambda
+	lambda := math.Min(stdGamma(nb.src, nb.r)*(1-nb.p)/nb.p, poissonc.max)
+	// Return Poisson distributed random number
+	return poisson(nb.src, lambda)
+}
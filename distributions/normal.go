@@ -0,0 +1,41 @@
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package distributions
+
+// Import tsrand
+import (
+	"github.com/thorstenrie/tsrand" // tsrand
+)
+
+// Normal provides normally distributed random numbers with mean mu and standard deviation sigma.
+// It holds the source src and the parameters of the distribution. The random numbers are generated with the
+// Ziggurat method by StdNormal. Normal is safe for concurrent use by multiple goroutines only if src is.
+type Normal struct {
+	src   tsrand.Source // source of random numbers
+	mu    float64       // mean
+	sigma float64       // standard deviation
+}
+
+// NewNormal returns a new instance of Normal with mean mu and standard deviation sigma using src. It returns an error,
+// if mu is not finite, sigma is not finite and higher than 0, or if src is nil or not available on the platform.
+func NewNormal(src tsrand.Source, mu, sigma float64) (*Normal, error) {
+	// Return an error, if a parameter is invalid
+	if e := checkFinite("mu", mu); e != nil {
+		return nil, e
+	}
+	if e := checkPositive("sigma", sigma); e != nil {
+		return nil, e
+	}
+	// Return an error, if src is not available
+	if e := checkSource(src); e != nil {
+		return nil, e
+	}
+	// Return Normal
+	return &Normal{src: src, mu: mu, sigma: sigma}, nil
+}
+
+// Float64 returns a normally distributed random number.
+func (n *Normal) Float64() float64 {
+	return n.mu + n.sigma*StdNormal(n.src)
+}
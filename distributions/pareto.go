@@ -0,0 +1,43 @@
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package distributions
+
+// Import standard library package math and tsrand
+import (
+	"math" // math
+
+	"github.com/thorstenrie/tsrand" // tsrand
+)
+
+// Pareto provides Pareto distributed random numbers with scale xm, which is the minimum value, and shape alpha. It holds the source src
+// and the parameters of the distribution. The random numbers are generated by inversion as xm*exp(E/alpha) with a standard exponentially
+// distributed random number E. Pareto is safe for concurrent use by multiple goroutines only if src is.
+type Pareto struct {
+	src   tsrand.Source // source of random numbers
+	xm    float64       // scale and minimum value
+	alpha float64       // shape
+}
+
+// NewPareto returns a new instance of Pareto with scale xm and shape alpha using src. It returns an error, if xm or alpha
+// is not finite and higher than 0, or if src is nil or not available on the platform.
+func NewPareto(src tsrand.Source, xm, alpha float64) (*Pareto, error) {
+	// Return an error, if a parameter is invalid
+	if e := checkPositive("xm", xm); e != nil {
+		return nil, e
+	}
+	if e := checkPositive("alpha", alpha); e != nil {
+		return nil, e
+	}
+	// Return an error, if src is not available
+	if e := checkSource(src); e != nil {
+		return nil, e
+	}
+	// Return Pareto
+	return &Pareto{src: src, xm: xm, alpha: alpha}, nil
+}
+
+// Float64 returns a Pareto distributed random number in the interval [xm, +Inf].
+func (p *Pareto) Float64() float64 {
+	return p.xm * math.Exp(StdExponential(p.src)/p.alpha)
+}
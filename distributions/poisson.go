@@ -0,0 +1,107 @@
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package distributions
+
+// Import standard library package math and tsrand
+import (
+	"math" // math
+
+	"github.com/thorstenrie/tsrand" // tsrand
+)
+
+// Parameters of the Poisson distribution
+var (
+	poissonc = struct {
+		ptrs float64 // minimum lambda for the PTRS method
+		max  float64 // maximum lambda
+	}{
+		ptrs: 10,
+		max:  float64(math.MaxInt64) - 10*math.Sqrt(float64(math.MaxInt64)),
+	}
+)
+
+// Poisson provides Poisson distributed random numbers with mean lambda. It holds the source src and the parameter of the distribution.
+// For lambda < 10, the random numbers are generated with the multiplication method. For lambda >= 10, they are generated with the
+// transformed rejection method with squeeze (PTRS) of Hörmann. Poisson is safe for concurrent use by multiple goroutines only if src is.
+type Poisson struct {
+	src    tsrand.Source // source of random numbers
+	lambda float64       // mean
+}
+
+// NewPoisson returns a new instance of Poisson with mean lambda using src. It returns an error, if lambda is not finite,
+// lower than 0 or too large for an int64, or if src is nil or not available on the platform.
+func NewPoisson(src tsrand.Source, lambda float64) (*Poisson, error) {
+	// Return an error, if lambda is invalid
+	if math.IsNaN(lambda) || lambda < 0 || lambda > poissonc.max {
+		return nil, errParam("lambda", lambda, "in the interval [0, MaxInt64 - 10*sqrt(MaxInt64)]")
+	}
+	// Return an error, if src is not available
+	if e := checkSource(src); e != nil {
+		return nil, e
+	}
+	// Return Poisson
+	return &Poisson{src: src, lambda: lambda}, nil
+}
+
+// Int64 returns a Poisson distributed random number.
+func (p *Poisson) Int64() int64 {
+	return poisson(p.src, p.lambda)
+}
+
+// poisson returns a Poisson distributed random number with mean lambda using src.
+func poisson(src tsrand.Source, lambda float64) int64 {
+	switch {
+	case lambda >= poissonc.ptrs:
+		return poissonPTRS(src, lambda)
+	case lambda == 0:
+		return 0
+	default:
+		return poissonMult(src, lambda)
+	}
+}
+
+// poissonMult returns a Poisson distributed random number with mean lambda using src based on the multiplication method.
+// The expected number of random values of src is lambda + 1.
+func poissonMult(src tsrand.Source, lambda float64) int64 {
+	enlam := math.Exp(-lambda)
+	x := int64(0)
+	prod := 1.0
+	for {
+		prod *= uniform(src)
+		if prod <= enlam {
+			return x
+		}
+		x++
+	}
+}
+
+// poissonPTRS returns a Poisson distributed random number with mean lambda >= 10 using src based on the transformed rejection
+// method with squeeze of Hörmann, "The transformed rejection method for generating Poisson random variables", 1993.
+func poissonPTRS(src tsrand.Source, lambda float64) int64 {
+	slam := math.Sqrt(lambda)
+	loglam := math.Log(lambda)
+	b := 0.931 + 2.53*slam
+	a := -0.059 + 0.02483*b
+	invalpha := 1.1239 + 1.1328/(b-3.4)
+	vr := 0.9277 - 3.6224/(b-2)
+	for {
+		u := uniform(src) - 0.5
+		v := uniform(src)
+		us := 0.5 - math.Abs(u)
+		k := math.Floor((2*a/us+b)*u + lambda + 0.43)
+		// Fast acceptance
+		if us >= 0.07 && v <= vr {
+			return int64(k)
+		}
+		// Fast rejection
+		if k < 0 || (us < 0.013 && v > us) {
+			continue
+		}
+		// Acceptance
+		lg, _ := math.Lgamma(k + 1)
+		if math.Log(v)+math.Log(invalpha)-math.Log(a/(us*us)+b) <= -lambda+k*loglam-lg {
+			return int64(k)
+		}
+	}
+}
@@ -0,0 +1,41 @@
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package distributions
+
+// Import standard library package math and tsrand
+import (
+	"math" // math
+
+	"github.com/thorstenrie/tsrand" // tsrand
+)
+
+// StudentT provides Student's t-distributed random numbers with nu degrees of freedom. It holds the source src and the parameter
+// of the distribution. The random numbers are generated as Z/sqrt(V/nu) with a standard normally distributed random number Z and a
+// chi-squared distributed random number V with nu degrees of freedom. StudentT is safe for concurrent use by multiple goroutines only if src is.
+type StudentT struct {
+	src tsrand.Source // source of random numbers
+	nu  float64       // degrees of freedom
+}
+
+// NewStudentT returns a new instance of StudentT with nu degrees of freedom using src. It returns an error, if nu
+// is not finite and higher than 0, or if src is nil or not available on the platform.
+func NewStudentT(src tsrand.Source, nu float64) (*StudentT, error) {
+	// Return an error, if nu is invalid
+	if e := checkPositive("nu", nu); e != nil {
+		return nil, e
+	}
+	// Return an error, if src is not available
+	if e := checkSource(src); e != nil {
+		return nil, e
+	}
+	// Return StudentT
+	return &StudentT{src: src, nu: nu}, nil
+}
+
+// Float64 returns a Student's t-distributed random number.
+func (s *StudentT) Float64() float64 {
+	z := StdNormal(s.src)
+	v := 2 * stdGamma(s.src, s.nu/2)
+	return z / math.Sqrt(v/s.nu)
+}
@@ -0,0 +1,57 @@
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package distributions
+
+// Import standard library package math and tsrand
+import (
+	"math" // math
+
+	"github.com/thorstenrie/tsrand" // tsrand
+)
+
+// Triangular provides triangular distributed random numbers with lower limit a, mode c and upper limit b. It holds the source src and
+// the parameters of the distribution. The random numbers are generated by inversion. Triangular is safe for concurrent use by multiple
+// goroutines only if src is.
+type Triangular struct {
+	src     tsrand.Source // source of random numbers
+	a, c, b float64       // lower limit, mode and upper limit
+}
+
+// NewTriangular returns a new instance of Triangular with lower limit a, mode c and upper limit b using src. It returns an error,
+// if a, c or b is not finite, a is not lower than b, c is not in the interval [a,b], or if src is nil or not available on the platform.
+func NewTriangular(src tsrand.Source, a, c, b float64) (*Triangular, error) {
+	// Return an error, if a parameter is invalid
+	for _, p := range []struct {
+		name string
+		v    float64
+	}{{"a", a}, {"c", c}, {"b", b}} {
+		if e := checkFinite(p.name, p.v); e != nil {
+			return nil, e
+		}
+	}
+	if a >= b {
+		return nil, errParam("a", a, "lower than b")
+	}
+	if c < a || c > b {
+		return nil, errParam("c", c, "in the interval [a,b]")
+	}
+	// Return an error, if src is not available
+	if e := checkSource(src); e != nil {
+		return nil, e
+	}
+	// Return Triangular
+	return &Triangular{src: src, a: a, c: c, b: b}, nil
+}
+
+// Float64 returns a triangular distributed random number in the interval [a,b].
+func (t *Triangular) Float64() float64 {
+	u := uniform(t.src)
+	// Cumulative distribution function at the mode
+	fc := (t.c - t.a) / (t.b - t.a)
+	// Inversion of the left or right part of the cumulative distribution function
+	if u < fc {
+		return t.a + math.Sqrt(u*(t.b-t.a)*(t.c-t.a))
+	}
+	return t.b - math.Sqrt((1-u)*(t.b-t.a)*(t.b-t.c))
+}
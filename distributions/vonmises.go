@@ -0,0 +1,100 @@
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package distributions
+
+// Import standard library package math and tsrand
+import (
+	"math" // math
+
+	"github.com/thorstenrie/tsrand" // tsrand
+)
+
+// Parameters of the von Mises distribution
+var (
+	vonMisesc = struct {
+		uniform float64 // maximum kappa of the uniform distribution
+		small   float64 // maximum kappa of the approximation of s for small kappa
+		normal  float64 // minimum kappa of the wrapped normal distribution
+	}{
+		uniform: 1e-8,
+		small:   1e-5,
+		normal:  1e6,
+	}
+)
+
+// VonMises provides von Mises distributed random angles with mean direction mu and concentration kappa. It holds the source src and the
+// parameters of the distribution. The random numbers are generated with the rejection method of Best and Fisher. For kappa < 1e-8, the
+// distribution is approximated by the uniform distribution on the circle and for kappa > 1e6 by the wrapped normal distribution.
+// VonMises is safe for concurrent use by multiple goroutines only if src is.
+type VonMises struct {
+	src   tsrand.Source // source of random numbers
+	mu    float64       // mean direction
+	kappa float64       // concentration
+	s     float64       // parameter of the method of Best and Fisher
+}
+
+// NewVonMises returns a new instance of VonMises with mean direction mu and concentration kappa using src. It returns an error, if mu
+// is not finite, kappa is not finite and higher than or equal to 0, or if src is nil or not available on the platform.
+func NewVonMises(src tsrand.Source, mu, kappa float64) (*VonMises, error) {
+	// Return an error, if a parameter is invalid
+	if e := checkFinite("mu", mu); e != nil {
+		return nil, e
+	}
+	if math.IsNaN(kappa) || math.IsInf(kappa, 0) || kappa < 0 {
+		return nil, errParam("kappa", kappa, "finite and higher than or equal to 0")
+	}
+	// Return an error, if src is not available
+	if e := checkSource(src); e != nil {
+		return nil, e
+	}
+	// Compute parameter s of the method of Best and Fisher
+	var s float64
+	if kappa < vonMisesc.small {
+		s = 1/kappa + kappa
+	} else {
+		r := 1 + math.Sqrt(1+4*kappa*kappa)
+		rho := (r - math.Sqrt(2*r)) / (2 * kappa)
+		s = (1 + rho*rho) / (2 * rho)
+	}
+	// Return VonMises
+	return &VonMises{src: src, mu: mu, kappa: kappa, s: s}, nil
+}
+
+// Float64 returns a von Mises distributed random angle in the interval [-pi,pi].
+func (v *VonMises) Float64() float64 {
+	// Uniform distribution on the circle for very small kappa
+	if v.kappa < vonMisesc.uniform {
+		return math.Pi * (2*uniform(v.src) - 1)
+	}
+	// Wrapped normal distribution for very large kappa
+	if v.kappa > vonMisesc.normal {
+		return wrapAngle(v.mu + StdNormal(v.src)/math.Sqrt(v.kappa))
+	}
+	// Rejection method of Best and Fisher
+	var w float64
+	for {
+		z := math.Cos(math.Pi * uniform(v.src))
+		w = (1 + v.s*z) / (v.s + z)
+		y := v.kappa * (v.s - w)
+		u := uniform(v.src)
+		if y*(2-y)-u >= 0 || math.Log(y/u)+1-y >= 0 {
+			break
+		}
+	}
+	// Random sign of the angle
+	x := math.Acos(w)
+	if uniform(v.src) < 0.5 {
+		x = -x
+	}
+	return wrapAngle(x + v.mu)
+}
+
+// wrapAngle returns the angle x wrapped to the interval [-pi,pi].
+func wrapAngle(x float64) float64 {
+	x = math.Mod(x+math.Pi, 2*math.Pi)
+	if x < 0 {
+		x += 2 * math.Pi
+	}
+	return x - math.Pi
+}
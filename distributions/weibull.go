@@ -0,0 +1,43 @@
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package distributions
+
+// Import standard library package math and tsrand
+import (
+	"math" // math
+
+	"github.com/thorstenrie/tsrand" // tsrand
+)
+
+// Weibull provides Weibull distributed random numbers with shape k and scale lambda. It holds the source src and the parameters
+// of the distribution. The random numbers are generated by inversion as lambda*E^(1/k) with a standard exponentially distributed random
+// number E. Weibull is safe for concurrent use by multiple goroutines only if src is.
+type Weibull struct {
+	src    tsrand.Source // source of random numbers
+	k      float64       // shape
+	lambda float64       // scale
+}
+
+// NewWeibull returns a new instance of Weibull with shape k and scale lambda using src. It returns an error, if k or lambda
+// is not finite and higher than 0, or if src is nil or not available on the platform.
+func NewWeibull(src tsrand.Source, k, lambda float64) (*Weibull, error) {
+	// Return an error, if a parameter is invalid
+	if e := checkPositive("k", k); e != nil {
+		return nil, e
+	}
+	if e := checkPositive("lambda", lambda); e != nil {
+		return nil, e
+	}
+	// Return an error, if src is not available
+	if e := checkSource(src); e != nil {
+		return nil, e
+	}
+	// Return Weibull
+	return &Weibull{src: src, k: k, lambda: lambda}, nil
+}
+
+// Float64 returns a Weibull distributed random number in the interval [0, +Inf].
+func (w *Weibull) Float64() float64 {
+	return w.lambda * math.Pow(StdExponential(w.src), 1/w.k)
+}
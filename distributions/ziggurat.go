@@ -0,0 +1,145 @@
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package distributions
+
+// Import standard library package math and tsrand
+import (
+	"math" // math
+
+	"github.com/thorstenrie/tsrand" // tsrand
+)
+
+// ziggurat holds the tables of the Ziggurat method of Marsaglia and Tsang for a monotone decreasing density f with
+// 256 layers of equal area. Layer 0 is the base strip including the tail, layer i > 0 covers [0, w[i]*m] with m the
+// range of the random integer. A random integer r of layer i is accepted immediately, if r < k[i]. Otherwise, the point
+// is accepted, if it is below f, which lies between f[i] and f[i-1].
+type ziggurat struct {
+	k [256]uint64  // acceptance bounds of the layers
+	w [256]float64 // widths of the layers divided by the range of the random integer
+	f [256]float64 // values of the density at the right edges of the layers
+}
+
+// Parameters of the Ziggurat tables
+var (
+	zigc = struct {
+		layers        int
+		normalR, expR float64
+		normalM, expM float64
+		normalMask    uint64
+		expMask       uint64
+		layerMask     uint64
+		layerBits     uint
+		normalShift   uint
+	}{
+		layers:      256,               // number of layers
+		normalR:     3.654152885361009, // start of the tail of the normal distribution for 256 layers
+		expR:        7.697117470131049, // start of the tail of the exponential distribution for 256 layers
+		normalM:     0x1.0p52,          // range of the 52-bit random integer for the normal distribution
+		expM:        0x1.0p53,          // range of the 53-bit random integer for the exponential distribution
+		normalMask:  (1 << 52) - 1,     // mask of the 52-bit random integer for the normal distribution
+		expMask:     (1 << 53) - 1,     // mask of the 53-bit random integer for the exponential distribution
+		layerMask:   0xff,              // mask of the layer index
+		layerBits:   8,                 // bits of the layer index
+		normalShift: 9,                 // bits of the layer index and the sign
+	}
+	// zigNormal holds the tables for the standard normal distribution with density exp(-x*x/2)
+	zigNormal = newZiggurat(zigc.normalR, zigc.normalM,
+		func(x float64) float64 { return math.Exp(-0.5 * x * x) },
+		func(y float64) float64 { return math.Sqrt(-2 * math.Log(y)) },
+		func(r float64) float64 { return math.Sqrt(math.Pi/2) * math.Erfc(r/math.Sqrt2) })
+	// zigExp holds the tables for the standard exponential distribution with density exp(-x)
+	zigExp = newZiggurat(zigc.expR, zigc.expM,
+		func(x float64) float64 { return math.Exp(-x) },
+		func(y float64) float64 { return -math.Log(y) },
+		func(r float64) float64 { return math.Exp(-r) })
+)
+
+// newZiggurat returns the tables of the Ziggurat method for the density f with inverse finv and tail area tail beyond r.
+// The random integers have range m. The computation is based on the table setup of Marsaglia and Tsang.
+func newZiggurat(r, m float64, f, finv, tail func(float64) float64) *ziggurat {
+	z := &ziggurat{}
+	n := zigc.layers
+	// Area of each layer
+	v := r*f(r) + tail(r)
+	// Width of the base strip
+	q := v / f(r)
+	z.k[0] = uint64(r / q * m)
+	z.k[1] = 0
+	z.w[0] = q / m
+	z.w[n-1] = r / m
+	z.f[0] = 1
+	z.f[n-1] = f(r)
+	// Compute layers from bottom to top
+	x, t := r, r
+	for i := n - 2; i >= 1; i-- {
+		x = finv(v/x + f(x))
+		z.k[i+1] = uint64(x / t * m)
+		t = x
+		z.f[i] = f(x)
+		z.w[i] = x / m
+	}
+	return z
+}
+
+// StdNormal returns a normally distributed random number with mean 0 and standard deviation 1 using src. It is based on the
+// Ziggurat method of Marsaglia and Tsang with 256 layers and 64-bit random integers. On average, it uses about 1.02 random
+// values of src for each returned random number.
+func StdNormal(src tsrand.Source) float64 {
+	for {
+		u := src.Uint64()
+		// Lowest 8 bits select the layer, the next bit the sign and the following 52 bits the position
+		i := u & zigc.layerMask
+		neg := (u>>zigc.layerBits)&1 == 1
+		r := (u >> zigc.normalShift) & zigc.normalMask
+		x := float64(r) * zigNormal.w[i]
+		if neg {
+			x = -x
+		}
+		// Accept immediately, if the point is inside the rectangle of the layer
+		if r < zigNormal.k[i] {
+			return x
+		}
+		// Sample from the tail, if the point lies in the base strip
+		if i == 0 {
+			for {
+				xx := -math.Log1p(-uniform(src)) / zigc.normalR
+				yy := -math.Log1p(-uniform(src))
+				if yy+yy > xx*xx {
+					if neg {
+						return -(zigc.normalR + xx)
+					}
+					return zigc.normalR + xx
+				}
+			}
+		}
+		// Accept, if the point is below the density in the wedge of the layer
+		if (zigNormal.f[i-1]-zigNormal.f[i])*uniform(src)+zigNormal.f[i] < math.Exp(-0.5*x*x) {
+			return x
+		}
+	}
+}
+
+// StdExponential returns an exponentially distributed random number with rate 1 using src. It is based on the
+// Ziggurat method of Marsaglia and Tsang with 256 layers and 64-bit random integers.
+func StdExponential(src tsrand.Source) float64 {
+	for {
+		u := src.Uint64()
+		// Lowest 8 bits select the layer and the following 53 bits the position
+		i := u & zigc.layerMask
+		r := (u >> zigc.layerBits) & zigc.expMask
+		x := float64(r) * zigExp.w[i]
+		// Accept immediately, if the point is inside the rectangle of the layer
+		if r < zigExp.k[i] {
+			return x
+		}
+		// Sample from the tail, if the point lies in the base strip. The tail of the exponential distribution is exponential.
+		if i == 0 {
+			return zigc.expR - math.Log1p(-uniform(src))
+		}
+		// Accept, if the point is below the density in the wedge of the layer
+		if (zigExp.f[i-1]-zigExp.f[i])*uniform(src)+zigExp.f[i] < math.Exp(-x) {
+			return x
+		}
+	}
+}
@@ -0,0 +1,123 @@
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tsrand
+
+// Import standard library packages
+import (
+	"math"      // math
+	"math/bits" // math/bits
+)
+
+// floatc holds the constants of the float64 and float32 formats for the full-range floats
+var (
+	floatc = struct {
+		exp64, exp32   int    // biased exponent of the binade [0.5,1)
+		mant64, mant32 uint   // number of bits of the mantissa
+		mask64, mask32 uint64 // bitmask of the mantissa
+	}{
+		exp64:  1022,      // biased exponent of the binade [0.5,1) of float64
+		exp32:  126,       // biased exponent of the binade [0.5,1) of float32
+		mant64: 52,        // number of bits of the mantissa of float64
+		mant32: 23,        // number of bits of the mantissa of float32
+		mask64: 1<<52 - 1, // bitmask of the mantissa of float64
+		mask32: 1<<23 - 1, // bitmask of the mantissa of float32
+	}
+)
+
+// Float64Full returns a random float64 in the half-open interval [0,1) using src. In contrast to Float64 of rand.Rand, which returns
+// multiples of 2^-53 only, it returns every representable float64 in [0,1) including subnormal numbers. The probability of each value x
+// equals the distance to the next representable float64 above x, i.e., it is the result of rounding down a uniformly distributed real number
+// in [0,1). It is based on the method of Downey: the exponent is chosen with a geometric distribution and the mantissa uniformly.
+// It uses one 64-bit random value for most values and an additional value only with probability 2^-12.
+func Float64Full(src Source) float64 {
+	e, m := exponent(src, floatc.exp64, floatc.mant64)
+	return math.Float64frombits(uint64(e)<<floatc.mant64 | m&floatc.mask64)
+}
+
+// Float64Open returns a random float64 in the open interval (0,1) using src. It returns every representable float64 in (0,1) with the
+// probability of Float64Full.
+func Float64Open(src Source) float64 {
+	for {
+		if f := Float64Full(src); f > 0 {
+			return f
+		}
+	}
+}
+
+// Float64OpenClosed returns a random float64 in the half-open interval (0,1] using src. It returns every representable float64 in (0,1]. The
+// probability of each value x equals the distance to the next representable float64 below x, i.e., it is the result of rounding up a uniformly
+// distributed real number in [0,1).
+func Float64OpenClosed(src Source) float64 {
+	return math.Nextafter(Float64Full(src), 1)
+}
+
+// Float64Closed returns a random float64 in the closed interval [0,1] using src. It returns every representable float64 in [0,1]. The
+// probability of each value is the result of rounding a uniformly distributed real number in [0,1] to the nearest representable float64.
+// Hence, 0 and 1 are returned with half the probability of their neighbors. If the mantissa is zero, a random bit decides whether the value
+// is rounded up to the next binade as described by Downey.
+func Float64Closed(src Source) float64 {
+	e, m := exponent(src, floatc.exp64, floatc.mant64)
+	// Round up to the next binade with probability 1/2, if the mantissa is zero
+	if m&floatc.mask64 == 0 && src.Uint64()>>63 == 1 {
+		e++
+	}
+	return math.Float64frombits(uint64(e)<<floatc.mant64 | m&floatc.mask64)
+}
+
+// Float32Full returns a random float32 in the half-open interval [0,1) using src. It returns every representable float32 in [0,1) with the
+// probability described for Float64Full.
+func Float32Full(src Source) float32 {
+	e, m := exponent(src, floatc.exp32, floatc.mant32)
+	return math.Float32frombits(uint32(e)<<floatc.mant32 | uint32(m&floatc.mask32))
+}
+
+// Float32Open returns a random float32 in the open interval (0,1) using src. It returns every representable float32 in (0,1) with the
+// probability of Float32Full.
+func Float32Open(src Source) float32 {
+	for {
+		if f := Float32Full(src); f > 0 {
+			return f
+		}
+	}
+}
+
+// Float32OpenClosed returns a random float32 in the half-open interval (0,1] using src. It returns every representable float32 in (0,1] with the
+// probability described for Float64OpenClosed.
+func Float32OpenClosed(src Source) float32 {
+	return math.Nextafter32(Float32Full(src), 1)
+}
+
+// Float32Closed returns a random float32 in the closed interval [0,1] using src. It returns every representable float32 in [0,1] with the
+// probability described for Float64Closed.
+func Float32Closed(src Source) float32 {
+	e, m := exponent(src, floatc.exp32, floatc.mant32)
+	// Round up to the next binade with probability 1/2, if the mantissa is zero
+	if m&floatc.mask32 == 0 && src.Uint64()>>63 == 1 {
+		e++
+	}
+	return math.Float32frombits(uint32(e)<<floatc.mant32 | uint32(m&floatc.mask32))
+}
+
+// exponent returns a random biased exponent of the binades up to the binade with biased exponent top and a 64-bit random value m, whose lowest
+// mant bits are the random mantissa. The exponent is top with probability 1/2, top-1 with probability 1/4 and so on, which is determined by the
+// number of leading zero bits of random values. The upper 64-mant bits of m are used first. If the exponent falls below 1, the exponent is 0
+// for subnormal numbers, which have the same spacing as the binade with exponent 1.
+func exponent(src Source, top int, mant uint) (int, uint64) {
+	// Random value with the mantissa in the lowest mant bits
+	m := src.Uint64()
+	// Count the leading zero bits of the upper 64-mant bits
+	if h := m >> mant; h != 0 {
+		return max(top-(bits.LeadingZeros64(h)-int(mant)), 0), m
+	}
+	// All upper bits are zero, count the leading zero bits of further random values until a bit is set or the exponent is subnormal
+	e := top - (64 - int(mant))
+	for e > 0 {
+		r := src.Uint64()
+		e -= bits.LeadingZeros64(r)
+		if r != 0 {
+			break
+		}
+	}
+	return max(e, 0), m
+}
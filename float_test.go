@@ -0,0 +1,143 @@
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tsrand
+
+// Import standard library packages and tserr
+import (
+	"fmt"       // fmt
+	"math"      // math
+	"math/rand" // math/rand
+	"testing"   // testing
+
+	"github.com/thorstenrie/tserr" // tserr
+)
+
+// testFloatBins is the number of bins of the chi-square tests of the full-range floats
+const (
+	testFloatBins int = 16
+)
+
+// testFloat64Funcs returns the float64 functions with their name, the lower bound and the upper bound and whether the bounds are included.
+func testFloat64Funcs() map[string]struct {
+	f      func(Source) float64
+	lo, hi bool
+} {
+	return map[string]struct {
+		f      func(Source) float64
+		lo, hi bool
+	}{
+		"Float64Full":       {Float64Full, true, false},
+		"Float64Open":       {Float64Open, false, false},
+		"Float64OpenClosed": {Float64OpenClosed, false, true},
+		"Float64Closed":     {Float64Closed, true, true},
+		"Float32Full":       {func(s Source) float64 { return float64(Float32Full(s)) }, true, false},
+		"Float32Open":       {func(s Source) float64 { return float64(Float32Open(s)) }, false, false},
+		"Float32OpenClosed": {func(s Source) float64 { return float64(Float32OpenClosed(s)) }, false, true},
+		"Float32Closed":     {func(s Source) float64 { return float64(Float32Closed(s)) }, true, true},
+	}
+}
+
+// TestFloatFull tests that each full-range float function returns values in its interval, which are uniformly distributed in testFloatBins bins
+// and whose binades [2^-(k+1),2^-k) have probability 2^-(k+1).
+func TestFloatFull(t *testing.T) {
+	src := testSampleSource()
+	for name, f := range testFloat64Funcs() {
+		bins, binades := make(map[int]int), make(map[int]int)
+		wantBins, wantBinades := make(map[int]float64), make(map[int]float64)
+		for i := 0; i < testFloatBins; i++ {
+			wantBins[i] = float64(testItr) / float64(testFloatBins)
+		}
+		for k := 0; k < 10; k++ {
+			wantBinades[k] = float64(testItr) / math.Exp2(float64(k+1))
+		}
+		wantBinades[10] = float64(testItr) / math.Exp2(10)
+		for i := 0; i < testItr; i++ {
+			v := f.f(src)
+			// The test fails, if v is not in the interval
+			if v < 0 || v > 1 || (v == 0 && !f.lo) || (v == 1 && !f.hi) {
+				t.Fatal(tserr.Return(&tserr.ReturnArgs{Op: name, Actual: fmt.Sprint(v), Want: "value in interval"}))
+			}
+			bins[min(int(v*float64(testFloatBins)), testFloatBins-1)]++
+			// Binade of v, values below 2^-10 are counted in binade 10
+			_, e := math.Frexp(v)
+			binades[min(-e, 10)]++
+		}
+		testChiSquare(t, name+" bins", bins, wantBins)
+		testChiSquare(t, name+" binades", binades, wantBinades)
+	}
+}
+
+// TestFloatFullPrecision tests that Float64Full returns small values, which are not multiples of 2^-53 as returned by Float64 of rand.Rand.
+func TestFloatFullPrecision(t *testing.T) {
+	src := testSampleSource()
+	for i := 0; i < testItr; i++ {
+		if v := Float64Full(src); v < 0x1p-10 && v*0x1p53 != math.Floor(v*0x1p53) {
+			return
+		}
+	}
+	// The test fails, if all small values are multiples of 2^-53
+	t.Error(tserr.NotExistent("value of Float64Full below 2^-10, which is not a multiple of 2^-53"))
+}
+
+// TestFloatFullValues tests the full-range float functions with fixed random values for the largest value below 1, the smallest subnormal
+// values, the rejection of 0 and the rounding up to the next binade.
+func TestFloatFullValues(t *testing.T) {
+	// Random values, which select the exponent of the binade [0.5,1) and a zero mantissa
+	half := []uint64{1 << 63}
+	// Random values, which select the smallest subnormal mantissa 1 and leading zeros only
+	sub := append([]uint64{1}, make([]uint64, 16)...)
+	// Random values resulting in 0 for float64 and float32, followed by random values resulting in 0.5
+	zero, zero32 := append(make([]uint64, 17), 1<<63), append(make([]uint64, 3), 1<<63)
+	for _, c := range []struct {
+		name string
+		v    []uint64
+		got  func(Source) float64
+		want float64
+	}{
+		{"Float64Full of half", half, Float64Full, 0.5},
+		{"Float64Full of max", []uint64{math.MaxUint64}, Float64Full, 1 - 0x1p-53},
+		{"Float64Full of subnormal", sub, Float64Full, math.SmallestNonzeroFloat64},
+		{"Float64Full of zero", zero, Float64Full, 0},
+		{"Float64Open of zero", zero, Float64Open, 0.5},
+		{"Float64OpenClosed of max", []uint64{math.MaxUint64}, Float64OpenClosed, 1},
+		{"Float64OpenClosed of subnormal", sub, Float64OpenClosed, 2 * math.SmallestNonzeroFloat64},
+		{"Float64Closed of half rounded down", []uint64{1 << 63, 0}, Float64Closed, 0.5},
+		{"Float64Closed of half rounded up", []uint64{1 << 63, 1 << 63}, Float64Closed, 1},
+		{"Float64Closed of subnormal", sub, Float64Closed, math.SmallestNonzeroFloat64},
+		{"Float32Full of half", half, func(s Source) float64 { return float64(Float32Full(s)) }, 0.5},
+		{"Float32Full of max", []uint64{math.MaxUint64}, func(s Source) float64 { return float64(Float32Full(s)) }, 1 - 0x1p-24},
+		{"Float32Full of subnormal", sub, func(s Source) float64 { return float64(Float32Full(s)) }, math.SmallestNonzeroFloat32},
+		{"Float32Open of zero", zero32, func(s Source) float64 { return float64(Float32Open(s)) }, 0.5},
+		{"Float32OpenClosed of max", []uint64{math.MaxUint64}, func(s Source) float64 { return float64(Float32OpenClosed(s)) }, 1},
+		{"Float32Closed of half rounded up", []uint64{1 << 63, 1 << 63}, func(s Source) float64 { return float64(Float32Closed(s)) }, 1},
+	} {
+		// The test fails, if the returned value does not equal the expected value
+		if v := c.got(&testSeqSource{v: c.v}); v != c.want {
+			t.Error(tserr.Equalf(&tserr.EqualfArgs{Var: c.name, Actual: v, Want: c.want}))
+		}
+	}
+}
+
+// BenchmarkFloatFull performs a benchmark on the full-range float functions compared to Float64 and Float32 of rand.Rand.
+func BenchmarkFloatFull(b *testing.B) {
+	src := testSampleSource()
+	rnd := rand.New(src)
+	b.Run("rand.Float64", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			rnd.Float64()
+		}
+	})
+	b.Run("rand.Float32", func(b *testing.B) {
+		for i := 0; i < b.N; i++ {
+			rnd.Float32()
+		}
+	})
+	for name, f := range testFloat64Funcs() {
+		b.Run(name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				f.f(src)
+			}
+		})
+	}
+}
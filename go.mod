@@ -1,6 +1,6 @@
 module github.com/thorstenrie/tsrand
 
-go 1.20
+go 1.22
 
 require (
 	github.com/thorstenrie/lpstats v1.1.0
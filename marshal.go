@@ -0,0 +1,185 @@
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tsrand
+
+// Import standard library packages and tserr
+import (
+	"encoding"        // encoding
+	"encoding/base64" // encoding/base64
+	"encoding/binary" // encoding/binary
+	"hash/crc32"      // hash/crc32
+	"strconv"         // strconv
+
+	"github.com/thorstenrie/tserr" // tserr
+)
+
+// The stateful sources implement encoding.BinaryMarshaler, encoding.BinaryUnmarshaler, encoding.TextMarshaler
+// and encoding.TextUnmarshaler to save and restore the exact state of a source, e.g., to checkpoint long-running
+// simulations. The binary format is versioned and validated. It consists of
+//
+//   - the magic "tsrand",
+//   - the version of the format as one byte,
+//   - the type of the source as one byte,
+//   - the state of the source in big-endian byte order and
+//   - the CRC-32 (IEEE) checksum of all preceding bytes in big-endian byte order.
+//
+// The text format is the binary format encoded with standard base64 encoding. UnmarshalBinary and UnmarshalText
+// return an error, if the data is corrupted, has an unsupported version, belongs to another type of source or
+// contains an invalid state. In case of an error, the state of the source is not changed.
+
+// Parameters of the binary format
+const (
+	marshalMagic   string = "tsrand" // magic at the beginning of the binary format
+	marshalVersion byte   = 1        // current version of the binary format
+)
+
+// Type ids of the sources in the binary format. The ids must not be changed.
+const (
+	marshalMT32 byte = iota + 1
+	marshalMT64
+	marshalSimple
+	marshalPCG32
+	marshalPCG64
+	marshalXoshiro256StarStar
+	marshalXoshiro256Plus
+	marshalXoroshiro128PlusPlus
+	marshalSplitMix64
+	marshalChaCha
+	marshalPhilox
+	marshalThreefry
+)
+
+// marshalTypes contains the names of the sources for the type ids
+var (
+	marshalTypes = map[byte]string{
+		marshalMT32:                 "MT32Source",
+		marshalMT64:                 "MT64Source",
+		marshalSimple:               "SimpleSource",
+		marshalPCG32:                "PCG32Source",
+		marshalPCG64:                "PCG64Source",
+		marshalXoshiro256StarStar:   "Xoshiro256StarStarSource",
+		marshalXoshiro256Plus:       "Xoshiro256PlusSource",
+		marshalXoroshiro128PlusPlus: "Xoroshiro128PlusPlusSource",
+		marshalSplitMix64:           "SplitMix64Source",
+		marshalChaCha:               "ChaChaSource",
+		marshalPhilox:               "PhiloxSource",
+		marshalThreefry:             "ThreefrySource",
+	}
+)
+
+// marshalHeader returns a new slice containing the header of the binary format for type id with capacity
+// for the header, a state of size n bytes and the checksum.
+func marshalHeader(id byte, n int) []byte {
+	b := make([]byte, 0, len(marshalMagic)+2+n+crc32.Size)
+	b = append(b, marshalMagic...)
+	return append(b, marshalVersion, id)
+}
+
+// marshalChecksum appends the checksum of b to b and returns the result.
+func marshalChecksum(b []byte) []byte {
+	return binary.BigEndian.AppendUint32(b, crc32.ChecksumIEEE(b))
+}
+
+// unmarshalState validates the header and checksum of data for type id and returns a stateReader on the
+// state contained in data. It returns an error, if the state does not have a size of n bytes.
+func unmarshalState(data []byte, id byte, n int) (*stateReader, error) {
+	// Name of the source for error messages
+	name := marshalTypes[id]
+	// Minimum length of the binary format
+	l := len(marshalMagic) + 2 + crc32.Size
+	// Return an error, if data is too short to contain header and checksum
+	if len(data) < l {
+		return nil, unmarshalError(name, tserr.Higher(&tserr.HigherArgs{Var: "length of data", Actual: int64(len(data)), LowerBound: int64(l)}))
+	}
+	// Return an error, if the magic does not match
+	p := data[:len(data)-crc32.Size]
+	if m := string(p[:len(marshalMagic)]); m != marshalMagic {
+		return nil, unmarshalError(name, tserr.NotEqualStr(&tserr.NotEqualStrArgs{X: strconv.Quote(m), Y: strconv.Quote(marshalMagic)}))
+	}
+	// Return an error, if the version is not supported
+	if v := p[len(marshalMagic)]; v != marshalVersion {
+		return nil, unmarshalError(name, tserr.Equal(&tserr.EqualArgs{Var: "version", Actual: int64(v), Want: int64(marshalVersion)}))
+	}
+	// Return an error, if the checksum does not match
+	if c, w := binary.BigEndian.Uint32(data[len(data)-crc32.Size:]), crc32.ChecksumIEEE(p); c != w {
+		return nil, unmarshalError(name, tserr.Equal(&tserr.EqualArgs{Var: "checksum", Actual: int64(c), Want: int64(w)}))
+	}
+	// Return an error, if data contains the state of another type of source
+	if t := p[len(marshalMagic)+1]; t != id {
+		act, ok := marshalTypes[t]
+		if !ok {
+			act = "unknown source"
+		}
+		return nil, unmarshalError(name, tserr.TypeNotMatching(&tserr.TypeNotMatchingArgs{Act: act, Want: name}))
+	}
+	// Return an error, if the state does not have the expected size
+	s := p[len(marshalMagic)+2:]
+	if len(s) != n {
+		return nil, unmarshalError(name, tserr.Equal(&tserr.EqualArgs{Var: "length of state", Actual: int64(len(s)), Want: int64(n)}))
+	}
+	// Return a stateReader on the state
+	return &stateReader{b: s}, nil
+}
+
+// unmarshalError returns an error for the failed unmarshaling of source name caused by err.
+func unmarshalError(name string, err error) error {
+	return tserr.Op(&tserr.OpArgs{Op: "unmarshal state of", Fn: name, Err: err})
+}
+
+// unmarshalInvalid returns an error for source name containing the invalid state field f.
+func unmarshalInvalid(name, f string) error {
+	return unmarshalError(name, tserr.Forbidden(f))
+}
+
+// stateReader reads the state of a source in big-endian byte order. The size of the state is validated
+// by unmarshalState before. Therefore, stateReader does not check bounds.
+type stateReader struct {
+	b []byte // remaining bytes of the state
+}
+
+// uint8 reads one byte from the state.
+func (r *stateReader) uint8() byte {
+	v := r.b[0]
+	r.b = r.b[1:]
+	return v
+}
+
+// uint32 reads a 32-bit value from the state.
+func (r *stateReader) uint32() uint32 {
+	v := binary.BigEndian.Uint32(r.b)
+	r.b = r.b[4:]
+	return v
+}
+
+// uint64 reads a 64-bit value from the state.
+func (r *stateReader) uint64() uint64 {
+	v := binary.BigEndian.Uint64(r.b)
+	r.b = r.b[8:]
+	return v
+}
+
+// marshalText returns the binary format of m encoded with standard base64 encoding.
+func marshalText(m encoding.BinaryMarshaler) ([]byte, error) {
+	// Retrieve binary format
+	b, e := m.MarshalBinary()
+	if e != nil {
+		return nil, e
+	}
+	// Encode binary format with base64
+	t := make([]byte, base64.StdEncoding.EncodedLen(len(b)))
+	base64.StdEncoding.Encode(t, b)
+	return t, nil
+}
+
+// unmarshalText decodes text with standard base64 encoding and restores the state of u.
+func unmarshalText(u encoding.BinaryUnmarshaler, name string, text []byte) error {
+	// Decode text with base64
+	b := make([]byte, base64.StdEncoding.DecodedLen(len(text)))
+	n, e := base64.StdEncoding.Decode(b, text)
+	if e != nil {
+		return unmarshalError(name, tserr.Op(&tserr.OpArgs{Op: "base64 decode", Fn: "text", Err: e}))
+	}
+	// Restore state from binary format
+	return u.UnmarshalBinary(b[:n])
+}
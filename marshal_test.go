@@ -0,0 +1,150 @@
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tsrand
+
+// Import standard library packages and tserr
+import (
+	"encoding"        // encoding
+	"encoding/binary" // encoding/binary
+	"fmt"             // fmt
+	"testing"         // testing
+
+	"github.com/thorstenrie/tserr" // tserr
+)
+
+// marshalSource is implemented by all stateful sources
+type marshalSource interface {
+	Source
+	encoding.BinaryMarshaler
+	encoding.BinaryUnmarshaler
+	encoding.TextMarshaler
+	encoding.TextUnmarshaler
+}
+
+// testMarshalSources returns new instances of all stateful sources with their names
+func testMarshalSources() map[string]func() marshalSource {
+	return map[string]func() marshalSource{
+		"MT32Source":                 func() marshalSource { return NewMT32Source() },
+		"MT64Source":                 func() marshalSource { return NewMT64Source() },
+		"SimpleSource":               func() marshalSource { return NewSimpleSource() },
+		"PCG32Source":                func() marshalSource { return NewPCG32Source() },
+		"PCG64Source":                func() marshalSource { return NewPCG64Source() },
+		"Xoshiro256StarStarSource":   func() marshalSource { return NewXoshiro256StarStarSource() },
+		"Xoshiro256PlusSource":       func() marshalSource { return NewXoshiro256PlusSource() },
+		"Xoroshiro128PlusPlusSource": func() marshalSource { return NewXoroshiro128PlusPlusSource() },
+		"SplitMix64Source":           func() marshalSource { return NewSplitMix64Source() },
+		"ChaChaSource":               func() marshalSource { return NewChaCha8Source() },
+		"PhiloxSource":               func() marshalSource { return NewPhiloxSource() },
+		"ThreefrySource":             func() marshalSource { return NewThreefrySource() },
+	}
+}
+
+// TestMarshal saves the state of each stateful source in the binary and text format after retrieving some values,
+// restores the state in a new instance and compares the subsequent output of both instances.
+// The test fails, if marshaling or unmarshaling returns an error or if the output differs.
+func TestMarshal(t *testing.T) {
+	for name, f := range testMarshalSources() {
+		// Create source, seed it and retrieve some values to advance the state
+		src := f()
+		src.Seed(42)
+		for i := 0; i < 1000; i++ {
+			src.Uint64()
+		}
+		// Save state in binary and text format
+		b, e := src.MarshalBinary()
+		if e != nil {
+			t.Fatal(tserr.Op(&tserr.OpArgs{Op: "MarshalBinary", Fn: name, Err: e}))
+		}
+		txt, e := src.MarshalText()
+		if e != nil {
+			t.Fatal(tserr.Op(&tserr.OpArgs{Op: "MarshalText", Fn: name, Err: e}))
+		}
+		// Restore state in new instances
+		srcb, srct := f(), f()
+		if e = srcb.UnmarshalBinary(b); e != nil {
+			t.Fatal(tserr.Op(&tserr.OpArgs{Op: "UnmarshalBinary", Fn: name, Err: e}))
+		}
+		if e = srct.UnmarshalText(txt); e != nil {
+			t.Fatal(tserr.Op(&tserr.OpArgs{Op: "UnmarshalText", Fn: name, Err: e}))
+		}
+		// Compare the subsequent output
+		for i := 0; i < 1000; i++ {
+			v, vb, vt := src.Uint64(), srcb.Uint64(), srct.Uint64()
+			if v != vb || v != vt {
+				t.Fatal(tserr.NotEqualStr(&tserr.NotEqualStrArgs{X: fmt.Sprintf("%v output %d: %#x, %#x", name, i, vb, vt), Y: fmt.Sprintf("%#x", v)}))
+			}
+		}
+	}
+}
+
+// TestMarshalZero tests, if the state of the zero value of MT32Source and MT64Source can be saved and restored.
+func TestMarshalZero(t *testing.T) {
+	for name, src := range map[string]marshalSource{"MT32Source": &MT32Source{}, "MT64Source": &MT64Source{}} {
+		// Save and restore the state of the zero value
+		b, e := src.MarshalBinary()
+		if e != nil {
+			t.Fatal(tserr.Op(&tserr.OpArgs{Op: "MarshalBinary", Fn: name, Err: e}))
+		}
+		if e = src.UnmarshalBinary(b); e != nil {
+			t.Error(tserr.Op(&tserr.OpArgs{Op: "UnmarshalBinary", Fn: name, Err: e}))
+		}
+	}
+}
+
+// TestUnmarshalInvalid tests, if UnmarshalBinary and UnmarshalText return an error for truncated, corrupted,
+// wrong version, wrong type and invalid payloads and if the state of the source is not changed.
+// The test fails, if no error is returned or if the state is changed.
+func TestUnmarshalInvalid(t *testing.T) {
+	// Retrieve a valid binary format of a PCG32Source
+	b, e := NewPCG32Source().MarshalBinary()
+	if e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "MarshalBinary", Fn: "PCG32Source", Err: e}))
+	}
+	// Retrieve a valid binary format of a PCG64Source
+	b64, e := NewPCG64Source().MarshalBinary()
+	if e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "MarshalBinary", Fn: "PCG64Source", Err: e}))
+	}
+	// Corrupted copy of b
+	corrupted := append([]byte{}, b...)
+	corrupted[len(marshalMagic)+3] ^= 0x01
+	// Copy of b with a wrong magic and a wrong version and valid checksums
+	magic := marshalChecksum(append([]byte("tsranD"), b[len(marshalMagic):len(b)-4]...))
+	version := marshalChecksum(append(append([]byte(marshalMagic), marshalVersion+1), b[len(marshalMagic)+1:len(b)-4]...))
+	// Valid binary format with an even increment
+	even := marshalHeader(marshalPCG32, 16)
+	even = binary.BigEndian.AppendUint64(even, 1)
+	even = binary.BigEndian.AppendUint64(even, 2)
+	even = marshalChecksum(even)
+	// Valid binary format with a wrong size of the state
+	size := marshalChecksum(marshalHeader(marshalPCG32, 0))
+	// Invalid payloads
+	invalid := map[string][]byte{
+		"nil":       nil,
+		"truncated": b[:len(b)-1],
+		"corrupted": corrupted,
+		"magic":     magic,
+		"version":   version,
+		"type":      b64,
+		"increment": even,
+		"size":      size,
+	}
+	for name, data := range invalid {
+		// Create a source and retrieve its first value
+		src := NewPCG32Source()
+		want := NewPCG32Source().Uint64()
+		// The test fails, if UnmarshalBinary does not return an error
+		if e := src.UnmarshalBinary(data); e == nil {
+			t.Error(tserr.NilFailed("UnmarshalBinary of " + name))
+		}
+		// The test fails, if the state changed
+		if v := src.Uint64(); v != want {
+			t.Error(tserr.NotEqualStr(&tserr.NotEqualStrArgs{X: fmt.Sprintf("output after %v: %#x", name, v), Y: fmt.Sprintf("%#x", want)}))
+		}
+	}
+	// The test fails, if UnmarshalText does not return an error for text which is not base64 encoded
+	if e := NewPCG32Source().UnmarshalText([]byte("not base64!")); e == nil {
+		t.Error(tserr.NilFailed("UnmarshalText"))
+	}
+}
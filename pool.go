@@ -0,0 +1,194 @@
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tsrand
+
+// Import standard library packages and tserr
+import (
+	"math/rand" // math/rand
+	"sync"      // sync
+
+	"github.com/thorstenrie/tserr" // tserr
+)
+
+// Pool provides random numbers for high-throughput concurrent use without a global mutex. It holds a sync.Pool of
+// independent pseudo-random number generators, which are cached per P by the Go runtime. Each generator is an instance of
+// rand.Rand using a Xoshiro256StarStarSource. The generators are derived from a base generator seeded from a parent Source.
+// Each new generator is a copy of the base generator, which is advanced by Jump afterwards. Therefore, all generators of
+// a Pool produce non-overlapping subsequences of length 2^128. The methods of Pool are safe for concurrent use by multiple
+// goroutines. Since the assignment of generators to goroutines depends on the scheduler, the output of a Pool is not reproducible.
+// The output might be easily predictable and is unsuitable for security-sensitive services. A Pool must be created with NewPool,
+// the zero value of Pool has no base generator and is not usable.
+type Pool struct {
+	mu   sync.Mutex               // mutex to protect base
+	base Xoshiro256StarStarSource // base generator of new generators
+	rnds sync.Pool                // pool of generators of type *rand.Rand
+}
+
+// NewPool returns a new Pool with a base generator seeded from parent. The parent source is only used
+// in NewPool. If parent is nil or not available on the platform, NewPool returns an error and *Pool is nil.
+func NewPool(parent Source) (*Pool, error) {
+	// Return an error, if parent is nil
+	if parent == nil {
+		return nil, tserr.NilPtr()
+	}
+	// Call Assert and check if Err returns an error
+	if parent.Assert(); parent.Err() != nil {
+		// If it returns an error, then return nil and the error
+		return nil, tserr.NotAvailable(&tserr.NotAvailableArgs{S: "Source", Err: parent.Err()})
+	}
+	// Create new Pool
+	p := &Pool{}
+	// Seed the base generator with four values of parent
+	for i := range p.base.s {
+		p.base.s[i] = parent.Uint64()
+	}
+	// Return an error, if reading from parent failed
+	if e := parent.Err(); e != nil {
+		return nil, tserr.NotAvailable(&tserr.NotAvailableArgs{S: "Source", Err: e})
+	}
+	// The state of xoshiro256** must not be zero
+	if p.base.s == [4]uint64{} {
+		p.base.Seed(defaultSeed)
+	}
+	// New generators are derived from the base generator
+	p.rnds.New = p.newRand
+	// Return p
+	return p, nil
+}
+
+// newRand returns a new generator as a copy of the base generator and advances the base generator by Jump.
+func (p *Pool) newRand() any {
+	// Lock base generator
+	p.mu.Lock()
+	// Copy base generator
+	src := p.base
+	// Advance base generator by 2^128 steps
+	p.base.Jump()
+	// Unlock base generator
+	p.mu.Unlock()
+	// Return new generator
+	return rand.New(&src)
+}
+
+// Get returns a generator of the Pool for exclusive use. It can be used to retrieve many random numbers
+// without accessing the Pool for each number. The generator must be returned with Put and must not be used afterwards.
+// It must not be seeded, since it would lose its non-overlapping subsequence.
+func (p *Pool) Get() *rand.Rand {
+	return p.rnds.Get().(*rand.Rand)
+}
+
+// Put returns the generator rnd retrieved by Get to the Pool.
+func (p *Pool) Put(rnd *rand.Rand) {
+	p.rnds.Put(rnd)
+}
+
+// Uint64 returns a pseudo-random 64-bit value as a uint64.
+func (p *Pool) Uint64() uint64 {
+	rnd := p.Get()
+	v := rnd.Uint64()
+	p.Put(rnd)
+	return v
+}
+
+// Uint32 returns a pseudo-random 32-bit value as a uint32.
+func (p *Pool) Uint32() uint32 {
+	rnd := p.Get()
+	v := rnd.Uint32()
+	p.Put(rnd)
+	return v
+}
+
+// Int63 returns a non-negative pseudo-random 63-bit integer as an int64.
+func (p *Pool) Int63() int64 {
+	rnd := p.Get()
+	v := rnd.Int63()
+	p.Put(rnd)
+	return v
+}
+
+// Int31 returns a non-negative pseudo-random 31-bit integer as an int32.
+func (p *Pool) Int31() int32 {
+	rnd := p.Get()
+	v := rnd.Int31()
+	p.Put(rnd)
+	return v
+}
+
+// Int returns a non-negative pseudo-random int.
+func (p *Pool) Int() int {
+	rnd := p.Get()
+	v := rnd.Int()
+	p.Put(rnd)
+	return v
+}
+
+// Int63n returns, as an int64, a non-negative pseudo-random number in the half-open interval [0,n). It panics if n <= 0.
+func (p *Pool) Int63n(n int64) int64 {
+	rnd := p.Get()
+	defer p.Put(rnd)
+	return rnd.Int63n(n)
+}
+
+// Int31n returns, as an int32, a non-negative pseudo-random number in the half-open interval [0,n). It panics if n <= 0.
+func (p *Pool) Int31n(n int32) int32 {
+	rnd := p.Get()
+	defer p.Put(rnd)
+	return rnd.Int31n(n)
+}
+
+// Intn returns, as an int, a non-negative pseudo-random number in the half-open interval [0,n). It panics if n <= 0.
+func (p *Pool) Intn(n int) int {
+	rnd := p.Get()
+	defer p.Put(rnd)
+	return rnd.Intn(n)
+}
+
+// Float64 returns, as a float64, a pseudo-random number in the half-open interval [0.0,1.0).
+func (p *Pool) Float64() float64 {
+	rnd := p.Get()
+	v := rnd.Float64()
+	p.Put(rnd)
+	return v
+}
+
+// Float32 returns, as a float32, a pseudo-random number in the half-open interval [0.0,1.0).
+func (p *Pool) Float32() float32 {
+	rnd := p.Get()
+	v := rnd.Float32()
+	p.Put(rnd)
+	return v
+}
+
+// NormFloat64 returns a normally distributed float64 in the range [-math.MaxFloat64, +math.MaxFloat64]
+// with standard normal distribution (mean = 0, stddev = 1).
+func (p *Pool) NormFloat64() float64 {
+	rnd := p.Get()
+	v := rnd.NormFloat64()
+	p.Put(rnd)
+	return v
+}
+
+// ExpFloat64 returns an exponentially distributed float64 in the range (0, +math.MaxFloat64]
+// with an exponential distribution whose rate parameter (lambda) is 1.
+func (p *Pool) ExpFloat64() float64 {
+	rnd := p.Get()
+	v := rnd.ExpFloat64()
+	p.Put(rnd)
+	return v
+}
+
+// Perm returns, as a slice of n ints, a pseudo-random permutation of the integers in the half-open interval [0,n).
+func (p *Pool) Perm(n int) []int {
+	rnd := p.Get()
+	defer p.Put(rnd)
+	return rnd.Perm(n)
+}
+
+// Shuffle pseudo-randomizes the order of elements. n is the number of elements. Shuffle panics if n < 0.
+// swap swaps the elements with indexes i and j.
+func (p *Pool) Shuffle(n int, swap func(i, j int)) {
+	rnd := p.Get()
+	defer p.Put(rnd)
+	rnd.Shuffle(n, swap)
+}
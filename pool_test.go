@@ -0,0 +1,154 @@
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tsrand
+
+// Import standard library packages, lpstats and tserr
+import (
+	"errors"  // errors
+	"sync"    // sync
+	"testing" // testing
+
+	"github.com/thorstenrie/lpstats" // lpstats
+	"github.com/thorstenrie/tserr"   // tserr
+)
+
+// TestPool retrieves random values from a Pool concurrently from multiple goroutines and performs the defined
+// tests on arithmetic mean and variance on the retrieved floats. Run with the race detector, e.g., go test -race,
+// the test fails, if a data race occurs.
+func TestPool(t *testing.T) {
+	// Create a new Pool seeded from the deterministic source
+	p, err := NewPool(newDeterministicSource(defaultSeed))
+	// The test fails if an error occurs
+	if err != nil {
+		t.Fatal(tserr.NotAvailable(&tserr.NotAvailableArgs{S: "NewPool", Err: err}))
+	}
+	// Retrieve random values concurrently
+	a := make([]float64, testItr)
+	var wg sync.WaitGroup
+	for g := 0; g < testGoroutines; g++ {
+		wg.Add(1)
+		go func(g int) {
+			defer wg.Done()
+			for i := g; i < testItr; i += testGoroutines {
+				a[i] = p.Float64()
+				p.Uint64()
+				p.Intn(testIntn)
+			}
+		}(g)
+	}
+	wg.Wait()
+	// Calculate the arithmetic mean of the retrieved random values
+	mean, e := lpstats.ArithmeticMean(a)
+	// The test fails if arithmetic mean returns an error
+	if e != nil {
+		t.Error(tserr.Op(&tserr.OpArgs{Op: "ArithmeticMean", Fn: "a", Err: e}))
+	}
+	// The test fails if the arithmetic mean does not equal the expected value with a maximum difference of maxDiff
+	if meane := lpstats.ExpectedValueU(0, 1); !lpstats.NearEqual(mean, meane, maxDiff) {
+		t.Error(tserr.Equalf(&tserr.EqualfArgs{Var: "Arithmetic mean of a", Actual: mean, Want: meane}))
+	}
+	// Calculate the variance of the random values
+	vari, e := lpstats.Variance(a)
+	// The test fails if variance returns an error
+	if e != nil {
+		t.Error(tserr.Op(&tserr.OpArgs{Op: "Variance", Fn: "a", Err: e}))
+	}
+	// The test fails if the variance does not equal the expected variance with a maximum difference of maxDiff
+	if varie := lpstats.VarianceU(0, 1); !lpstats.NearEqual(vari, varie, maxDiff) {
+		t.Error(tserr.Equalf(&tserr.EqualfArgs{Var: "Variance of a", Actual: vari, Want: varie}))
+	}
+}
+
+// TestPoolIndependent tests, if the generators of a Pool are derived by Jump from the base generator.
+// The test fails, if the first value of the second generator does not equal the first value after a Jump.
+func TestPoolIndependent(t *testing.T) {
+	// Create a new Pool seeded from the deterministic source
+	p, err := NewPool(newDeterministicSource(defaultSeed))
+	// The test fails if an error occurs
+	if err != nil {
+		t.Fatal(tserr.NotAvailable(&tserr.NotAvailableArgs{S: "NewPool", Err: err}))
+	}
+	// Copy the base generator before and after a Jump
+	src1, src2 := p.base, p.base
+	src2.Jump()
+	// Retrieve two new generators
+	r1, r2 := p.Get(), p.Get()
+	// The first generator equals the base generator and the second generator equals the base generator after a Jump
+	for i := 0; i < testCalls; i++ {
+		if v, w := r1.Uint64(), src1.Uint64(); v != w {
+			t.Fatal(tserr.Equal(&tserr.EqualArgs{Var: "output of first generator", Actual: int64(v), Want: int64(w)}))
+		}
+		if v, w := r2.Uint64(), src2.Uint64(); v != w {
+			t.Fatal(tserr.Equal(&tserr.EqualArgs{Var: "output of second generator", Actual: int64(v), Want: int64(w)}))
+		}
+	}
+	// Return the generators
+	p.Put(r1)
+	p.Put(r2)
+}
+
+// TestPoolNil tests, if NewPool returns an error for a nil parent and for an unavailable parent.
+func TestPoolNil(t *testing.T) {
+	// The test fails, if NewPool does not return an error for a nil parent
+	if _, err := NewPool(nil); err == nil {
+		t.Error(tserr.NilFailed("NewPool"))
+	}
+	// Error returned by the reader
+	errRead := errors.New("read failed")
+	// The test fails, if NewPool does not return an error for a parent failing in Assert
+	if _, err := NewPool(newCryptoSource(&testReader{err: errRead})); !errors.Is(err, errRead) {
+		t.Error(tserr.NilFailed("NewPool with unavailable parent"))
+	}
+	// The test fails, if NewPool does not return an error for a parent failing while seeding the base generator
+	if _, err := NewPool(newCryptoSource(&testReader{n: 8, err: errRead})); !errors.Is(err, errRead) {
+		t.Error(tserr.NilFailed("NewPool with failing parent"))
+	}
+}
+
+// BenchmarkPoolParallel performs a parallel benchmark on a Pool seeded from crypto/rand
+func BenchmarkPoolParallel(b *testing.B) {
+	// Create a new Pool seeded from the cryptographically secure random number generator
+	p, err := NewPool(NewCryptoSource())
+	// The test fails if an error occurs
+	if err != nil {
+		b.Fatal(tserr.NotAvailable(&tserr.NotAvailableArgs{S: "NewPool", Err: err}))
+	}
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			p.Uint64()
+		}
+	})
+}
+
+// BenchmarkLockedRandParallel performs a parallel benchmark on a single 64-bit Mersenne Twister wrapped by NewLockedSource
+// for comparison with BenchmarkPoolParallel
+func BenchmarkLockedRandParallel(b *testing.B) {
+	// Retrieve the random number generator
+	rnd, err := NewLockedRand(NewMT64Source())
+	// The test fails if an error occurs
+	if err != nil {
+		b.Fatal(tserr.NotAvailable(&tserr.NotAvailableArgs{S: "NewLockedRand", Err: err}))
+	}
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			rnd.Uint64()
+		}
+	})
+}
+
+// BenchmarkCryptoRandParallel performs a parallel benchmark on the cryptographically secure random number generator
+// for comparison with BenchmarkPoolParallel
+func BenchmarkCryptoRandParallel(b *testing.B) {
+	// Retrieve the cryptographically secure random number generator
+	rnd, err := NewCryptoRand()
+	// The test fails if an error occurs
+	if err != nil {
+		b.Fatal(tserr.NotAvailable(&tserr.NotAvailableArgs{S: "CryptoRand", Err: err}))
+	}
+	b.RunParallel(func(pb *testing.PB) {
+		for pb.Next() {
+			rnd.Uint64()
+		}
+	})
+}
@@ -0,0 +1,182 @@
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package quality
+
+// Import standard library packages, tserr and tsrand
+import (
+	"fmt"     // fmt
+	"strings" // strings
+	"time"    // time
+
+	"github.com/thorstenrie/tserr"  // tserr
+	"github.com/thorstenrie/tsrand" // tsrand
+)
+
+// batteryc holds the constants of the batteries
+var (
+	batteryc = struct {
+		suspect float64 // p-values lower than suspect or higher than 1-suspect are suspect
+		eps     float64 // p-values lower than eps are shown as eps
+		eps1    float64 // p-values lower than eps1 are shown as eps1
+	}{
+		suspect: 0.001,  // p-values lower than suspect or higher than 1-suspect are suspect
+		eps:     1e-300, // p-values lower than eps are shown as eps
+		eps1:    1e-15,  // p-values lower than eps1 are shown as eps1
+	}
+)
+
+// BatteryTest holds the name of a test of a battery and the function running the test on a source.
+type BatteryTest struct {
+	Name string                                    // name of the test
+	Run  func(src tsrand.Source) ([]Result, error) // runs the test on src and returns its results
+}
+
+// BatteryReport holds the results of a battery of tests on a source.
+type BatteryReport struct {
+	Battery string        // name of the battery
+	Source  string        // type of the source
+	Tests   []string      // names of the tests
+	Results [][]Result    // results of each test in the order of the tests
+	Time    time.Duration // total run time of the battery
+}
+
+// RunBattery runs the tests of the battery with the name on src in order and returns a report with the results. It returns an error, if src is not
+// available, the battery has no tests or a test returns an error.
+func RunBattery(src tsrand.Source, name string, tests []BatteryTest) (*BatteryReport, error) {
+	// Check source and tests
+	if e := checkSource(src); e != nil {
+		return nil, e
+	}
+	if len(tests) == 0 {
+		return nil, tserr.Empty("battery " + name)
+	}
+	// Run the tests
+	r := &BatteryReport{Battery: name, Source: fmt.Sprintf("%T", src)}
+	start := time.Now()
+	for _, t := range tests {
+		res, e := t.Run(src)
+		if e != nil {
+			return nil, tserr.Op(&tserr.OpArgs{Op: name, Fn: t.Name, Err: e})
+		}
+		r.Tests = append(r.Tests, t.Name)
+		r.Results = append(r.Results, res)
+	}
+	r.Time = time.Since(start)
+	return r, nil
+}
+
+// SmallCrush runs a battery of ten tests on src with the parameters of SmallCrush of TestU01 of L'Ecuyer and Simard and returns a report with 15 statistics.
+// The parameter r of TestU01 is the number of dropped most significant bits of each random value. For the 64-bit values of the sources,
+// birthday spacings and collisions are tested in 60 and 32 bits of a single value. It returns an error, if src is not available.
+func SmallCrush(src tsrand.Source) (*BatteryReport, error) {
+	return RunBattery(src, "SmallCrush", smallCrush())
+}
+
+// smallCrush returns the tests of SmallCrush.
+func smallCrush() []BatteryTest {
+	// single returns the result of a test with a single result as slice
+	single := func(r Result, e error) ([]Result, error) {
+		if e != nil {
+			return nil, e
+		}
+		return []Result{r}, nil
+	}
+	return []BatteryTest{
+		{"BirthdaySpacings", func(src tsrand.Source) ([]Result, error) { return single(BirthdaySpacings(src, 1, 5000000, 60)) }},
+		{"Collision", func(src tsrand.Source) ([]Result, error) { return single(Collision(src, 5000000, 65536, 2)) }},
+		{"Gap", func(src tsrand.Source) ([]Result, error) { return single(Gap(drop(src, 22), 200000, 0, 1.0/256, 1024)) }},
+		{"SimplePoker", func(src tsrand.Source) ([]Result, error) { return single(Poker(drop(src, 24), 400000, 64, 64)) }},
+		{"CouponCollector", func(src tsrand.Source) ([]Result, error) {
+			return single(CouponCollector(drop(src, 26), 500000, 16, 200))
+		}},
+		{"MaxOfT", func(src tsrand.Source) ([]Result, error) { return MaxOfT(src, 2000000, 100000, 6) }},
+		{"WeightDistribution", func(src tsrand.Source) ([]Result, error) {
+			return single(WeightDistribution(drop(src, 27), 200000, 256, 0, 0.125))
+		}},
+		{"MatrixRank", func(src tsrand.Source) ([]Result, error) { return single(MatrixRank(drop(src, 20), 20000, 10, 60, 60)) }},
+		{"HammingIndependence", func(src tsrand.Source) ([]Result, error) {
+			return single(HammingIndependence(drop(src, 20), 250000, 10, 300))
+		}},
+		{"RandomWalk", func(src tsrand.Source) ([]Result, error) { return RandomWalk(src, 1000000, 30, 150) }},
+	}
+}
+
+// Statistics returns the number of statistics of the report.
+func (r *BatteryReport) Statistics() int {
+	n := 0
+	for _, res := range r.Results {
+		n += len(res)
+	}
+	return n
+}
+
+// Suspect returns the results with a p-value outside of [0.001,0.999].
+func (r *BatteryReport) Suspect() []Result {
+	var s []Result
+	for _, res := range r.Results {
+		for _, t := range res {
+			if suspect(t.P) {
+				s = append(s, t)
+			}
+		}
+	}
+	return s
+}
+
+// suspect returns true, if p is outside of [0.001,0.999].
+func suspect(p float64) bool {
+	return p < batteryc.suspect || p > 1-batteryc.suspect
+}
+
+// String returns the summary of the report as table of the tests with suspect p-values similar to the summary of TestU01.
+func (r *BatteryReport) String() string {
+	var b strings.Builder
+	line := strings.Repeat("-", 60)
+	fmt.Fprintf(&b, "========= Summary results of %s =========\n\n", r.Battery)
+	fmt.Fprintf(&b, " Generator:             %s\n", r.Source)
+	fmt.Fprintf(&b, " Number of statistics:  %d\n", r.Statistics())
+	fmt.Fprintf(&b, " Total time:            %v\n", r.Time.Round(time.Millisecond))
+	if len(r.Suspect()) == 0 {
+		fmt.Fprintf(&b, "\n All tests were passed\n")
+		return b.String()
+	}
+	fmt.Fprintf(&b, " The following tests gave p-values outside [%v, %v]:\n", batteryc.suspect, 1-batteryc.suspect)
+	fmt.Fprintf(&b, " (eps  means a value < %v):\n (eps1 means a value < %v):\n\n", batteryc.eps, batteryc.eps1)
+	fmt.Fprintf(&b, " %-3s %-45s %s\n %s\n", "", "Test", "p-value", line)
+	for i, res := range r.Results {
+		for _, t := range res {
+			if suspect(t.P) {
+				fmt.Fprintf(&b, " %-3d %-45s %s\n", i+1, r.Tests[i]+suffix(t.Test), pString(t.P))
+			}
+		}
+	}
+	fmt.Fprintf(&b, " %s\n All other tests were passed\n", line)
+	return b.String()
+}
+
+// suffix returns the suffix of the name of a test after its parameters, e.g., AD or the statistic of a random walk.
+func suffix(name string) string {
+	if i := strings.LastIndex(name, ")"); i >= 0 {
+		return name[i+1:]
+	}
+	return ""
+}
+
+// pString returns p formatted as in the summary of TestU01.
+func pString(p float64) string {
+	switch {
+	case p < batteryc.eps:
+		return "eps"
+	case p < batteryc.eps1:
+		return "eps1"
+	case p < 0.01:
+		return fmt.Sprintf("%.1e", p)
+	case p > 1-batteryc.eps1:
+		return "1 - eps1"
+	case p > 0.99:
+		return fmt.Sprintf("1 - %.1e", 1-p)
+	default:
+		return fmt.Sprintf("%.4f", p)
+	}
+}
@@ -0,0 +1,194 @@
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package quality
+
+// Import standard library packages, tserr and tsrand
+import (
+	"errors"  // errors
+	"fmt"     // fmt
+	"math"    // math
+	"strings" // strings
+	"testing" // testing
+
+	"github.com/thorstenrie/tserr"  // tserr
+	"github.com/thorstenrie/tsrand" // tsrand
+)
+
+// testFixedSource is a source returning a fixed value.
+type testFixedSource struct {
+	testCounterSource        // methods of a source
+	v                 uint64 // returned value
+}
+
+// Uint64 returns the fixed value.
+func (f *testFixedSource) Uint64() uint64 { return f.v }
+
+// testBattery returns a small battery of the tests of SmallCrush with reduced sample sizes.
+func testBattery() []BatteryTest {
+	// single returns the result of a test with a single result as slice
+	single := func(r Result, e error) ([]Result, error) { return []Result{r}, e }
+	return []BatteryTest{
+		{"BirthdaySpacings", func(s tsrand.Source) ([]Result, error) { return single(BirthdaySpacings(s, 10, 4096, 30)) }},
+		{"Collision", func(s tsrand.Source) ([]Result, error) { return single(Collision(s, 20000, 1024, 2)) }},
+		{"Gap", func(s tsrand.Source) ([]Result, error) { return single(Gap(drop(s, 22), 20000, 0, 1.0/16, 64)) }},
+		{"SimplePoker", func(s tsrand.Source) ([]Result, error) { return single(Poker(drop(s, 24), 20000, 16, 16)) }},
+		{"CouponCollector", func(s tsrand.Source) ([]Result, error) { return single(CouponCollector(drop(s, 26), 20000, 8, 60)) }},
+		{"MaxOfT", func(s tsrand.Source) ([]Result, error) { return MaxOfT(s, 20000, 100, 5) }},
+		{"WeightDistribution", func(s tsrand.Source) ([]Result, error) {
+			return single(WeightDistribution(drop(s, 27), 10000, 64, 0, 0.25))
+		}},
+		{"MatrixRank", func(s tsrand.Source) ([]Result, error) { return single(MatrixRank(drop(s, 20), 10000, 10, 32, 32)) }},
+		{"HammingIndependence", func(s tsrand.Source) ([]Result, error) {
+			return single(HammingIndependence(drop(s, 20), 20000, 10, 64))
+		}},
+		{"RandomWalk", func(s tsrand.Source) ([]Result, error) { return RandomWalk(s, 20000, 30, 50) }},
+	}
+}
+
+// TestBattery tests that a battery passes for good sources and reports suspect p-values for a poor counter source.
+func TestBattery(t *testing.T) {
+	for name, c := range map[string]struct {
+		src     tsrand.Source
+		suspect int
+	}{
+		"PCG64Source":       {testSource(), 0},
+		"MT64Source":        {tsrand.NewMT64Source(), 0},
+		"testCounterSource": {&testCounterSource{}, 15},
+	} {
+		// The test fails, if RunBattery returns an error
+		r, e := RunBattery(c.src, "test", testBattery())
+		if e != nil {
+			t.Fatal(tserr.Op(&tserr.OpArgs{Op: "RunBattery", Fn: name, Err: e}))
+		}
+		// The test fails, if the number of statistics or suspect p-values does not match
+		if s := r.Statistics(); s != 15 {
+			t.Error(tserr.Equal(&tserr.EqualArgs{Var: "statistics of " + name, Actual: int64(s), Want: 15}))
+		}
+		if s := len(r.Suspect()); s != c.suspect {
+			t.Error(tserr.Equal(&tserr.EqualArgs{Var: "suspect p-values of " + name, Actual: int64(s), Want: int64(c.suspect)}))
+		}
+		// The test fails, if the summary does not list the suspect tests
+		s := r.String()
+		if strings.Contains(s, "All tests were passed") != (c.suspect == 0) || (c.suspect > 0 && !strings.Contains(s, "RandomWalk C")) {
+			t.Error(tserr.Check(&tserr.CheckArgs{F: "summary of " + name, Err: errors.New(s)}))
+		}
+	}
+}
+
+// TestSmallCrush tests that SmallCrush passes for PCG64Source.
+func TestSmallCrush(t *testing.T) {
+	// The test fails, if SmallCrush returns an error
+	r, e := SmallCrush(testSource())
+	if e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "SmallCrush", Fn: "PCG64Source", Err: e}))
+	}
+	// The test fails, if the number of statistics does not match or a p-value is suspect
+	if s := r.Statistics(); s != 15 {
+		t.Error(tserr.Equal(&tserr.EqualArgs{Var: "statistics", Actual: int64(s), Want: 15}))
+	}
+	if len(r.Suspect()) > 0 {
+		t.Error(tserr.Check(&tserr.CheckArgs{F: "SmallCrush", Err: errors.New(r.String())}))
+	}
+}
+
+// TestBatteryInvalid tests that RunBattery and the tests return an error for a nil or unavailable source, an empty battery and invalid parameters,
+// and that the tests terminate for a fixed source.
+func TestBatteryInvalid(t *testing.T) {
+	// Nil and unavailable sources
+	for name, src := range map[string]tsrand.Source{"nil": nil, "BufferedCryptoSource": tsrand.NewBufferedCryptoSource(1)} {
+		// The test fails, if RunBattery does not return an error
+		if _, e := RunBattery(src, "test", testBattery()); e == nil {
+			t.Error(tserr.NilFailed("RunBattery of " + name))
+		}
+	}
+	// The test fails, if RunBattery does not return an error for an empty battery
+	if _, e := RunBattery(testSource(), "test", nil); e == nil {
+		t.Error(tserr.NilFailed("RunBattery of empty battery"))
+	}
+	// Invalid parameters
+	src := testSource()
+	for name, f := range map[string]func() error{
+		"Collision cells":           func() error { _, e := Collision(src, 20000, 16, 2); return e },
+		"Collision expected":        func() error { _, e := Collision(src, 100, 1024, 2); return e },
+		"MaxOfT t":                  func() error { _, e := MaxOfT(src, 1000, 10, 0); return e },
+		"MaxOfT n":                  func() error { _, e := MaxOfT(src, 100, 100, 2); return e },
+		"WeightDistribution beta":   func() error { _, e := WeightDistribution(src, 1000, 16, 0, 1); return e },
+		"WeightDistribution k":      func() error { _, e := WeightDistribution(src, 1000, 0, 0, 0.5); return e },
+		"MatrixRank s":              func() error { _, e := MatrixRank(src, 1000, 0, 32, 32); return e },
+		"MatrixRank k":              func() error { _, e := MatrixRank(src, 1000, 32, 32, 65); return e },
+		"HammingIndependence l":     func() error { _, e := HammingIndependence(src, 1000, 32, 1); return e },
+		"HammingIndependence n":     func() error { _, e := HammingIndependence(src, 10, 32, 64); return e },
+		"RandomWalk odd":            func() error { _, e := RandomWalk(src, 1000, 32, 51); return e },
+		"RandomWalk s":              func() error { _, e := RandomWalk(src, 1000, 65, 50); return e },
+		"RandomWalk n":              func() error { _, e := RandomWalk(src, 10, 32, 50); return e },
+		"BirthdaySpacings bits low": func() error { _, e := BirthdaySpacings(src, 10, 512, 0); return e },
+	} {
+		// The test fails, if the test does not return an error
+		if e := f(); e == nil {
+			t.Error(tserr.NilFailed(name))
+		}
+	}
+	// The test fails, if a test does not terminate with a suspect p-value for a fixed source
+	for _, test := range testBattery() {
+		res, e := test.Run(&testFixedSource{v: 1 << 63})
+		if e != nil {
+			t.Fatal(tserr.Op(&tserr.OpArgs{Op: test.Name, Fn: "testFixedSource", Err: e}))
+		}
+		for _, r := range res {
+			if !suspect(r.P) {
+				t.Error(tserr.Check(&tserr.CheckArgs{F: test.Name + " of testFixedSource", Err: fmt.Errorf("not suspect %v", r)}))
+			}
+		}
+	}
+}
+
+// TestWalkProbs tests the distributions of the statistics of random walks against the enumeration of all walks of l steps.
+func TestWalkProbs(t *testing.T) {
+	for _, l := range []int{2, 4, 12} {
+		probs := walkProbs(l)
+		// Count the statistics of all walks with weight 2^-l
+		act := make([][]float64, len(probs))
+		for i := range act {
+			act[i] = make([]float64, len(probs[i]))
+		}
+		for w := 0; w < 1<<l; w++ {
+			for i, v := range walkStats(newBitStream(&testFixedSource{v: uint64(w) << (64 - l)}, l), l) {
+				act[i][v] += math.Ldexp(1, -l)
+			}
+		}
+		// The test fails, if a probability differs from the enumeration
+		for i := range act {
+			for j := range act[i] {
+				if math.Abs(act[i][j]-probs[i][j]) > maxDiff {
+					t.Error(tserr.Equalf(&tserr.EqualfArgs{Var: fmt.Sprintf("probability %d of statistic %d of %d steps", j, i, l), Actual: probs[i][j], Want: act[i][j]}))
+				}
+			}
+		}
+	}
+}
+
+// TestBits tests that dropSource drops the most significant bits and bitStream reads the most significant bits of the values.
+func TestBits(t *testing.T) {
+	src := &testFixedSource{v: 0xF0F0_0000_0000_0000}
+	// The test fails, if dropSource does not shift the values
+	if v, w := drop(src, 4).Uint64(), uint64(0x0F00_0000_0000_0000); v != w {
+		t.Error(tserr.Equal(&tserr.EqualArgs{Var: "drop", Actual: int64(v), Want: int64(w)}))
+	}
+	// The test fails, if bitStream does not read the 12 most significant bits of each value
+	b := newBitStream(src, 12)
+	if v, w := b.read(20), uint64(0xF0FF0); v != w {
+		t.Error(tserr.Equal(&tserr.EqualArgs{Var: "read", Actual: int64(v), Want: int64(w)}))
+	}
+	if v, w := b.read(4), uint64(0xF); v != w {
+		t.Error(tserr.Equal(&tserr.EqualArgs{Var: "read", Actual: int64(v), Want: int64(w)}))
+	}
+}
+
+// BenchmarkSmallCrush performs a benchmark on SmallCrush with a PCG64Source.
+func BenchmarkSmallCrush(b *testing.B) {
+	src := testSource()
+	for i := 0; i < b.N; i++ {
+		SmallCrush(src)
+	}
+}
@@ -0,0 +1,59 @@
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package quality
+
+// Import standard library packages and tsrand
+import (
+	"fmt"    // fmt
+	"math"   // math
+	"slices" // slices
+
+	"github.com/thorstenrie/tsrand" // tsrand
+)
+
+// BirthdaySpacings performs the birthday spacings test of Marsaglia n times. In each repetition, it draws m birthdays in a year of 2^bits
+// days from the upper bits of random 64-bit values of src, sorts the birthdays and counts the number of spacings between consecutive birthdays,
+// which are equal to another spacing. The total count over all repetitions is approximately Poisson distributed with mean n*m^3/(4*2^bits).
+// The p-value is the upper tail probability of the total count. It returns an error, if src is not available, n is lower than 1, m is lower than 2, bits is not
+// in [1,64] or the mean is lower than 5.
+func BirthdaySpacings(src tsrand.Source, n, m, bits int) (Result, error) {
+	// Check source and parameters
+	if e := checkSource(src); e != nil {
+		return Result{}, e
+	}
+	if e := checkMin("n", n, 1); e != nil {
+		return Result{}, e
+	}
+	if e := checkMin("m", m, 2); e != nil {
+		return Result{}, e
+	}
+	if e := checkRange("bits", bits, 1, 64); e != nil {
+		return Result{}, e
+	}
+	// Expected total count
+	mf := float64(m)
+	lambda := float64(n) * mf * mf * mf / (4 * math.Ldexp(1, bits))
+	if e := checkExpected(lambda, n); e != nil {
+		return Result{}, e
+	}
+	// Count equal spacings in each repetition
+	days, spacings, total := make([]uint64, m), make([]uint64, m-1), 0
+	for i := 0; i < n; i++ {
+		for j := range days {
+			days[j] = src.Uint64() >> (64 - bits)
+		}
+		slices.Sort(days)
+		for j := range spacings {
+			spacings[j] = days[j+1] - days[j]
+		}
+		slices.Sort(spacings)
+		for j := 1; j < len(spacings); j++ {
+			if spacings[j] == spacings[j-1] {
+				total++
+			}
+		}
+	}
+	// Upper tail probability of the Poisson distribution
+	return Result{Test: fmt.Sprintf("BirthdaySpacings(n=%d, m=%d, bits=%d)", n, m, bits), Statistic: float64(total), P: poissonP(total, lambda)}, nil
+}
@@ -0,0 +1,65 @@
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package quality
+
+// Import tsrand
+import (
+	"github.com/thorstenrie/tsrand" // tsrand
+)
+
+// dropSource is a Source returning the random 64-bit values of the embedded Source shifted to the left by r bits. It drops the r most
+// significant bits, so that tests using the most significant bits test the following bits.
+type dropSource struct {
+	tsrand.Source      // source of the random values
+	r             uint // number of dropped most significant bits
+}
+
+// drop returns src, if r is 0, and otherwise a source dropping the r most significant bits of the random values of src.
+func drop(src tsrand.Source, r uint) tsrand.Source {
+	if r == 0 {
+		return src
+	}
+	return &dropSource{Source: src, r: r}
+}
+
+// Uint64 returns a random value of the source shifted to the left by r bits.
+func (d *dropSource) Uint64() uint64 {
+	return d.Source.Uint64() << d.r
+}
+
+// Int63 returns the upper 63 bits of Uint64.
+func (d *dropSource) Int63() int64 {
+	return int64(d.Uint64() >> 1)
+}
+
+// bitStream reads bits from the s most significant bits of the random values of a source.
+type bitStream struct {
+	src  tsrand.Source // source of the random values
+	s    int           // number of used bits of each random value
+	v    uint64        // unread bits of the last random value, starting with the most significant bit
+	left int           // number of unread bits of v
+}
+
+// newBitStream returns a new bitStream reading the s most significant bits of the random values of src.
+func newBitStream(src tsrand.Source, s int) *bitStream {
+	return &bitStream{src: src, s: s}
+}
+
+// read returns the next n bits in the n least significant bits of the returned value. n must be in [1,64].
+func (b *bitStream) read(n int) uint64 {
+	x := uint64(0)
+	for n > 0 {
+		// Read the next random value, if all bits are read
+		if b.left == 0 {
+			b.v, b.left = b.src.Uint64(), b.s
+		}
+		// Take up to n bits of v
+		c := min(n, b.left)
+		x = x<<c | b.v>>(64-c)
+		b.v <<= c
+		b.left -= c
+		n -= c
+	}
+	return x
+}
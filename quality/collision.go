@@ -0,0 +1,53 @@
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package quality
+
+// Import standard library packages, tserr and tsrand
+import (
+	"fmt"    // fmt
+	"math"   // math
+	"slices" // slices
+
+	"github.com/thorstenrie/tserr"  // tserr
+	"github.com/thorstenrie/tsrand" // tsrand
+)
+
+// Collision draws n tuples of t random integers in [0,d) from src, which are thrown into k = d^t cells, and counts the collisions. A collision occurs,
+// if a tuple falls into an occupied cell. In the sparse case k >= 32n, the number of collisions is approximately Poisson distributed with the
+// exact expected value n - k + k(1-1/k)^n. The p-value is the upper tail probability of the number of collisions. It returns an error, if src is not
+// available, d is lower than 2, t is lower than 1, k exceeds 2^64, k is lower than 32n or the expected value is lower than 5.
+func Collision(src tsrand.Source, n, d, t int) (Result, error) {
+	// Check source and parameters
+	if e := checkSource(src); e != nil {
+		return Result{}, e
+	}
+	if e := checkMin("d", d, 2); e != nil {
+		return Result{}, e
+	}
+	if e := checkMin("t", t, 1); e != nil {
+		return Result{}, e
+	}
+	k := math.Pow(float64(d), float64(t))
+	if k > math.Ldexp(1, 64) || k < 32*float64(n) {
+		return Result{}, tserr.Check(&tserr.CheckArgs{F: "number of cells", Err: fmt.Errorf("value is %v, but it must be in [%v,2^64]", k, 32*n)})
+	}
+	// Expected number of collisions
+	nf := float64(n)
+	lambda := nf + k*math.Expm1(nf*math.Log1p(-1/k))
+	if e := checkExpected(lambda, n); e != nil {
+		return Result{}, e
+	}
+	// Cells of the tuples, the number of collisions is the number of tuples minus the number of distinct cells
+	cells := make([]uint64, n)
+	for i := range cells {
+		c := uint64(0)
+		for j := 0; j < t; j++ {
+			c = c*uint64(d) + tsrand.Uint64n(src, uint64(d))
+		}
+		cells[i] = c
+	}
+	slices.Sort(cells)
+	c := n - len(slices.Compact(cells))
+	return Result{Test: fmt.Sprintf("Collision(n=%d, d=%d, t=%d)", n, d, t), Statistic: float64(c), P: poissonP(c, lambda)}, nil
+}
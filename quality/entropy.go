@@ -0,0 +1,245 @@
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package quality
+
+// Import standard library packages and tsrand
+import (
+	"fmt"     // fmt
+	"math"    // math
+	"strings" // strings
+
+	"github.com/thorstenrie/tsrand" // tsrand
+)
+
+// entropyc holds the parameters of the min-entropy estimators of NIST SP 800-90B
+var (
+	entropyc = struct {
+		z          float64 // quantile of the standard normal distribution of the upper bound of a confidence level of 99 %
+		markov     int     // length of the sequences of the Markov estimate
+		block      int     // number of bits of a block of the compression estimate
+		dictionary int     // number of blocks initializing the dictionary of the compression estimate
+		c          float64 // correction factor of the standard deviation of the compression estimate
+		minBits    int     // minimum number of bits
+	}{
+		z:          2.576,  // quantile of the standard normal distribution of the upper bound of a confidence level of 99 %
+		markov:     128,    // length of the sequences of the Markov estimate
+		block:      6,      // number of bits of a block of the compression estimate
+		dictionary: 1000,   // number of blocks initializing the dictionary of the compression estimate
+		c:          0.5907, // correction factor of the standard deviation of the compression estimate
+		minBits:    12000,  // minimum number of bits, at least as many blocks as the dictionary are tested by the compression estimate
+	}
+)
+
+// EntropyReport holds the number of bits and the min-entropy estimates in bits per bit of the estimators of NIST SP 800-90B, section 6.3.
+// The min-entropy of the bits is the minimum of the estimates. A min-entropy of 1 indicates full entropy, a min-entropy of 0 a predictable sequence.
+type EntropyReport struct {
+	Bits            int     // number of bits
+	MostCommonValue float64 // most common value estimate
+	Collision       float64 // collision estimate
+	Markov          float64 // Markov estimate
+	Compression     float64 // compression estimate
+	MinEntropy      float64 // minimum of the estimates
+}
+
+// Entropy estimates the min-entropy of n bits drawn from src with the most common value, collision, Markov and compression estimates of NIST SP 800-90B.
+// The bits of each random 64-bit value are used from the most significant bit. It returns an error, if src is not available or n is lower than 12,000.
+// NIST SP 800-90B recommends at least 1,000,000 bits.
+func Entropy(src tsrand.Source, n int) (*EntropyReport, error) {
+	// Check source and parameters
+	if e := checkSource(src); e != nil {
+		return nil, e
+	}
+	if e := checkMin("n", n, entropyc.minBits); e != nil {
+		return nil, e
+	}
+	// Draw the bits from src and return the estimates
+	s := make([]byte, n)
+	bitsOf(src, s)
+	return estimate(s), nil
+}
+
+// EntropyBytes estimates the min-entropy of data sampled from the output of a random number generator, e.g., written by cmd/tsrand in raw format, with the most common value,
+// collision, Markov and compression estimates of NIST SP 800-90B. The bits of each byte are used from the most significant bit. It returns an error, if data holds less
+// than 1,500 bytes. NIST SP 800-90B recommends at least 125,000 bytes.
+func EntropyBytes(data []byte) (*EntropyReport, error) {
+	// Check the number of bits
+	if e := checkMin("bits", 8*len(data), entropyc.minBits); e != nil {
+		return nil, e
+	}
+	// Split data into bits and return the estimates
+	s := make([]byte, 8*len(data))
+	for i := range s {
+		s[i] = data[i/8] >> (7 - i%8) & 1
+	}
+	return estimate(s), nil
+}
+
+// estimate returns the report of the estimates of the bits s.
+func estimate(s []byte) *EntropyReport {
+	r := &EntropyReport{Bits: len(s), MostCommonValue: mostCommonValue(s), Collision: collision(s), Markov: markov(s), Compression: compression(s)}
+	r.MinEntropy = min(r.MostCommonValue, r.Collision, r.Markov, r.Compression)
+	return r
+}
+
+// String returns the estimates of the report as table.
+func (r *EntropyReport) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "NIST SP 800-90B: min-entropy of %d bits\n", r.Bits)
+	fmt.Fprintf(&b, "%-10s %s\n", "ENTROPY", "ESTIMATE")
+	for _, e := range []struct {
+		name string
+		h    float64
+	}{{"most common value", r.MostCommonValue}, {"collision", r.Collision}, {"Markov", r.Markov}, {"compression", r.Compression}, {"min-entropy", r.MinEntropy}} {
+		fmt.Fprintf(&b, "%-10.6f %s\n", e.h, e.name)
+	}
+	return b.String()
+}
+
+// mostCommonValue returns the most common value estimate of NIST SP 800-90B, section 6.3.1, of the bits s. It is based on the upper bound of the
+// confidence interval of the proportion of the most common value.
+func mostCommonValue(s []byte) float64 {
+	// Count ones
+	ones := 0
+	for _, b := range s {
+		ones += int(b)
+	}
+	// Proportion of the most common value and upper bound of its confidence interval
+	l := float64(len(s))
+	p := float64(max(ones, len(s)-ones)) / l
+	pu := min(1, p+entropyc.z*math.Sqrt(p*(1-p)/(l-1)))
+	return math.Log2(1 / pu)
+}
+
+// collision returns the collision estimate of NIST SP 800-90B, section 6.3.2, of the bits s. It is based on the mean number of bits until
+// the first repeated bit, which is 2, if the first two bits are equal, and 3 otherwise.
+func collision(s []byte) float64 {
+	// Sum and sum of squares of the numbers of bits until a collision
+	var v, sum, sq float64
+	for i := 0; i+1 < len(s); {
+		t := 3.0
+		if s[i] == s[i+1] {
+			t = 2
+		} else if i+2 >= len(s) {
+			// The last bits do not contain a collision
+			break
+		}
+		v, sum, sq = v+1, sum+t, sq+t*t
+		i += int(t)
+	}
+	// Lower bound of the confidence interval of the mean
+	mean := sum / v
+	sd := math.Sqrt(max(0, (sq-v*mean*mean)/(v-1)))
+	m := mean - entropyc.z*sd/math.Sqrt(v)
+	// The expected mean is 2+2p(1-p) for the probability p of the most likely bit. Solve for p in [1/2,1] in closed form.
+	p := 0.5
+	switch {
+	case m <= 2:
+		p = 1
+	case m < 2.5:
+		p = (1 + math.Sqrt(5-2*m)) / 2
+	}
+	return math.Log2(1 / p)
+}
+
+// markov returns the Markov estimate of NIST SP 800-90B, section 6.3.3, of the bits s. It is based on the probability of the most likely
+// sequence of 128 bits of a first-order Markov model estimated from s.
+func markov(s []byte) float64 {
+	// Count the initial bits and the transitions between consecutive bits
+	var c [2]float64
+	var t [2][2]float64
+	for i, b := range s {
+		c[b]++
+		if i > 0 {
+			t[s[i-1]][b]++
+		}
+	}
+	// Initial and transition probabilities
+	l := float64(len(s))
+	p := [2]float64{c[0] / l, c[1] / l}
+	var pt [2][2]float64
+	for i := range t {
+		if n := t[i][0] + t[i][1]; n > 0 {
+			pt[i][0], pt[i][1] = t[i][0]/n, t[i][1]/n
+		}
+	}
+	// Probabilities of the most likely sequences: constant, alternating and a single change
+	k := float64(entropyc.markov)
+	pmax := max(
+		p[0]*math.Pow(pt[0][0], k-1),
+		p[0]*math.Pow(pt[0][1], k/2)*math.Pow(pt[1][0], k/2-1),
+		p[0]*pt[0][1]*math.Pow(pt[1][1], k-2),
+		p[1]*pt[1][0]*math.Pow(pt[0][0], k-2),
+		p[1]*math.Pow(pt[1][0], k/2)*math.Pow(pt[0][1], k/2-1),
+		p[1]*math.Pow(pt[1][1], k-1),
+	)
+	return min(math.Log2(1/pmax)/k, 1)
+}
+
+// compression returns the compression estimate of NIST SP 800-90B, section 6.3.4, of the bits s. It is based on the mean logarithm of the distances
+// between repeated blocks of 6 bits similar to Maurer's universal statistical test.
+func compression(s []byte) float64 {
+	// Blocks of 6 bits
+	b := entropyc.block
+	blocks := make([]int, len(s)/b)
+	for i := range blocks {
+		for _, x := range s[i*b : (i+1)*b] {
+			blocks[i] = blocks[i]<<1 | int(x)
+		}
+	}
+	// Initialize the dictionary with the last positions of the first blocks, the positions start with 1
+	d := entropyc.dictionary
+	dict := make([]int, 1<<b)
+	for i := 0; i < d; i++ {
+		dict[blocks[i]] = i + 1
+	}
+	// Sum and sum of squares of the logarithms of the distances to the last position of each tested block
+	var sum, sq float64
+	for i := d; i < len(blocks); i++ {
+		l := math.Log2(float64(i + 1 - dict[blocks[i]]))
+		sum, sq = sum+l, sq+l*l
+		dict[blocks[i]] = i + 1
+	}
+	// Lower bound of the confidence interval of the mean
+	v := float64(len(blocks) - d)
+	mean := sum / v
+	sd := entropyc.c * math.Sqrt(max(0, sq/(v-1)-mean*mean))
+	m := mean - entropyc.z*sd/math.Sqrt(v)
+	// Expected mean for the probability p of the most likely block and equally likely other blocks. It decreases from full entropy to 0 for p=1.
+	k := float64(int(1) << b)
+	f := func(p float64) float64 {
+		return compressionG(p, d, len(blocks)) + (k-1)*compressionG((1-p)/(k-1), d, len(blocks))
+	}
+	// Solve for p with a binary search, full entropy if the mean is not lower than expected for uniformly distributed blocks
+	lo, hi := 1/k, 1.0
+	if m >= f(lo) {
+		return 1
+	}
+	for i := 0; i < 64 && hi-lo > 1e-12; i++ {
+		if p := (lo + hi) / 2; f(p) > m {
+			lo = p
+		} else {
+			hi = p
+		}
+	}
+	return math.Log2(1/hi) / float64(b)
+}
+
+// compressionG returns the expected sum of the logarithms of the distances of a block with probability z at the positions d+1 to n divided by the number
+// of tested blocks n-d. A distance u is either the distance to the last occurrence of the block at position t-u or the position t, if the block did not occur before.
+func compressionG(z float64, d, n int) float64 {
+	// Sum of the expected logarithms of the distances
+	var g, s float64
+	// Probability (1-z)^(u-1) of u-1 blocks without the block
+	q := 1.0
+	for t := 1; t <= n; t++ {
+		// Add the expected logarithm of the distance at position t
+		if t > d {
+			g += s + math.Log2(float64(t))*z*q
+		}
+		// Add the distance t to the sum of the distances lower than the next position
+		s += math.Log2(float64(t)) * z * z * q
+		q *= 1 - z
+	}
+	return g / float64(n-d)
+}
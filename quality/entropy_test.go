@@ -0,0 +1,105 @@
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package quality
+
+// Import standard library packages, tserr and tsrand
+import (
+	"fmt"     // fmt
+	"math"    // math
+	"strings" // strings
+	"testing" // testing
+
+	"github.com/thorstenrie/tserr"  // tserr
+	"github.com/thorstenrie/tsrand" // tsrand
+)
+
+// testEntropy returns an error, if an estimate of report r is not in [lo,hi].
+func testEntropy(r *EntropyReport, lo, hi float64) error {
+	for name, h := range map[string]float64{"most common value": r.MostCommonValue, "collision": r.Collision, "Markov": r.Markov, "compression": r.Compression} {
+		if h < lo || h > hi || math.IsNaN(h) {
+			return tserr.Check(&tserr.CheckArgs{F: name + " estimate", Err: fmt.Errorf("value is %.6f, but it must be in [%v,%v]", h, lo, hi)})
+		}
+	}
+	return nil
+}
+
+// TestEntropy tests that the min-entropy estimates of a good source are close to full entropy and the estimates of a stuck source are close to 0.
+func TestEntropy(t *testing.T) {
+	// Estimate the min-entropy of a good source and a stuck source
+	good, e := Entropy(testSource(), 200000)
+	if e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "Entropy", Fn: "testSource", Err: e}))
+	}
+	stuck, e := Entropy(&testFixedSource{v: 1 << 63}, 200000)
+	if e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "Entropy", Fn: "testFixedSource", Err: e}))
+	}
+	// The test fails, if an estimate is not in the expected range
+	if e := testEntropy(good, 0.7, 1); e != nil {
+		t.Error(e)
+	}
+	if e := testEntropy(stuck, 0, 0.05); e != nil {
+		t.Error(e)
+	}
+	// The test fails, if the min-entropy is not the minimum of the estimates
+	if m := min(good.MostCommonValue, good.Collision, good.Markov, good.Compression); good.MinEntropy != m {
+		t.Error(tserr.Equalf(&tserr.EqualfArgs{Var: "MinEntropy", Actual: good.MinEntropy, Want: m}))
+	}
+	// The test fails, if the report does not contain the estimates
+	if s := good.String(); !strings.Contains(s, "compression") || !strings.Contains(s, fmt.Sprintf("%.6f", good.MinEntropy)) {
+		t.Error(tserr.NotExistent("estimates in report"))
+	}
+}
+
+// TestEntropyBytes tests the estimates of sampled bytes with biased bits and of a periodic pattern, which is only detected by the Markov and the
+// compression estimate.
+func TestEntropyBytes(t *testing.T) {
+	// Bytes with bits, which are 1 with probability 3/4
+	src, data := testSource(), make([]byte, 25000)
+	for i := range data {
+		data[i] = byte(src.Uint64() | src.Uint64())
+	}
+	r, e := EntropyBytes(data)
+	if e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "EntropyBytes", Fn: "biased bytes", Err: e}))
+	}
+	// The test fails, if the most common value estimate is not close to -log2(3/4) or the min-entropy is higher
+	if w := math.Log2(4.0 / 3); math.Abs(r.MostCommonValue-w) > 0.02 || r.MinEntropy > w+0.01 {
+		t.Error(tserr.Equalf(&tserr.EqualfArgs{Var: "MostCommonValue", Actual: r.MostCommonValue, Want: w}))
+	}
+	// Periodic pattern of alternating bits
+	for i := range data {
+		data[i] = 0x55
+	}
+	if r, e = EntropyBytes(data); e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "EntropyBytes", Fn: "periodic bytes", Err: e}))
+	}
+	// The test fails, if the pattern is not detected by the Markov and the compression estimate
+	if r.MostCommonValue < 0.9 || r.Markov > 1.0/64 || r.Compression != 0 || r.MinEntropy != 0 {
+		t.Error(tserr.Check(&tserr.CheckArgs{F: "estimates of periodic bytes", Err: fmt.Errorf("%v", r)}))
+	}
+}
+
+// TestEntropyInvalid tests that Entropy and EntropyBytes return an error for an unavailable source or too few bits.
+func TestEntropyInvalid(t *testing.T) {
+	if _, e := Entropy(nil, 100000); e == nil {
+		t.Error(tserr.NilFailed("Entropy with nil source"))
+	}
+	if _, e := Entropy(testSource(), 11999); e == nil {
+		t.Error(tserr.NilFailed("Entropy with too few bits"))
+	}
+	if _, e := EntropyBytes(make([]byte, 1499)); e == nil {
+		t.Error(tserr.NilFailed("EntropyBytes with too few bytes"))
+	}
+}
+
+// BenchmarkEntropy performs a benchmark of the min-entropy estimates of 1,000,000 bits.
+func BenchmarkEntropy(b *testing.B) {
+	src := tsrand.NewPCG64Source()
+	for i := 0; i < b.N; i++ {
+		if _, e := Entropy(src, 1000000); e != nil {
+			b.Fatal(tserr.Op(&tserr.OpArgs{Op: "Entropy", Fn: "PCG64Source", Err: e}))
+		}
+	}
+}
@@ -0,0 +1,79 @@
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package quality
+
+// Import standard library packages and tsrand
+import (
+	"fmt" // fmt
+
+	"github.com/thorstenrie/tsrand" // tsrand
+)
+
+// Frequency draws n random integers in [0,d) from src and tests with a chi-square test, if each value occurs with probability 1/d.
+// It returns an error, if src is not available, d is lower than 2 or the expected count n/d of a value is lower than 5.
+func Frequency(src tsrand.Source, n, d int) (Result, error) {
+	// Check source and parameters
+	if e := checkSource(src); e != nil {
+		return Result{}, e
+	}
+	if e := checkMin("d", d, 2); e != nil {
+		return Result{}, e
+	}
+	if e := checkExpected(float64(n)/float64(d), n); e != nil {
+		return Result{}, e
+	}
+	// Count the occurrences of each value
+	counts := make([]int, d)
+	for i := 0; i < n; i++ {
+		counts[tsrand.Uint64n(src, uint64(d))]++
+	}
+	// Chi-square test with equal probabilities
+	x, p, e := chiSquare(counts, equal(d), n)
+	return Result{Test: fmt.Sprintf("Frequency(n=%d, d=%d)", n, d), Statistic: x, P: p}, e
+}
+
+// Serial draws n non-overlapping tuples of t random integers in [0,d) from src and tests with a chi-square test, if each of the d^t tuples
+// occurs with probability 1/d^t. With t = 2 it tests pairs and with t = 3 it tests triples. It returns an error, if src is not available, d or t is
+// lower than 2 or the expected count n/d^t of a tuple is lower than 5.
+func Serial(src tsrand.Source, n, d, t int) (Result, error) {
+	// Check source and parameters
+	if e := checkSource(src); e != nil {
+		return Result{}, e
+	}
+	if e := checkMin("d", d, 2); e != nil {
+		return Result{}, e
+	}
+	if e := checkMin("t", t, 2); e != nil {
+		return Result{}, e
+	}
+	// Number of cells d^t, stop as soon as the expected count is too low to avoid an overflow
+	cells := 1
+	for i := 0; i < t && float64(n)/float64(cells) >= qualityc.minExpected; i++ {
+		cells *= d
+	}
+	if e := checkExpected(float64(n)/float64(cells), n); e != nil {
+		return Result{}, e
+	}
+	// Count the occurrences of each tuple
+	counts := make([]int, cells)
+	for i := 0; i < n; i++ {
+		c := 0
+		for j := 0; j < t; j++ {
+			c = c*d + int(tsrand.Uint64n(src, uint64(d)))
+		}
+		counts[c]++
+	}
+	// Chi-square test with equal probabilities
+	x, p, e := chiSquare(counts, equal(cells), n)
+	return Result{Test: fmt.Sprintf("Serial(n=%d, d=%d, t=%d)", n, d, t), Statistic: x, P: p}, e
+}
+
+// equal returns d equal probabilities 1/d.
+func equal(d int) []float64 {
+	p := make([]float64, d)
+	for i := range p {
+		p[i] = 1 / float64(d)
+	}
+	return p
+}
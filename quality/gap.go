@@ -0,0 +1,55 @@
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package quality
+
+// Import standard library packages, tserr and tsrand
+import (
+	"fmt"  // fmt
+	"math" // math
+
+	"github.com/thorstenrie/tserr"  // tserr
+	"github.com/thorstenrie/tsrand" // tsrand
+)
+
+// Gap draws random numbers in [0,1) from src until n gaps are observed and tests the lengths of the gaps with a chi-square test. A gap is
+// the number of random numbers outside of the interval [alpha,beta) between two random numbers in the interval. With p = beta-alpha, a gap has
+// the length r with probability p(1-p)^r for r < t and the length t or longer with probability (1-p)^t. It returns an error, if src is not available,
+// the interval is empty or not in [0,1], t is lower than 1 or the expected count of a gap length is lower than 5.
+func Gap(src tsrand.Source, n int, alpha, beta float64, t int) (Result, error) {
+	// Check source and parameters
+	if e := checkSource(src); e != nil {
+		return Result{}, e
+	}
+	if !(alpha >= 0 && alpha < beta && beta <= 1) {
+		return Result{}, tserr.Check(&tserr.CheckArgs{F: "interval", Err: fmt.Errorf("[%v,%v) is not a non-empty interval in [0,1]", alpha, beta)})
+	}
+	if e := checkMin("t", t, 1); e != nil {
+		return Result{}, e
+	}
+	// Probabilities of the gap lengths 0, ..., t-1 and t or longer
+	p := beta - alpha
+	probs := make([]float64, t+1)
+	for r := 0; r < t; r++ {
+		probs[r] = p * math.Pow(1-p, float64(r))
+	}
+	probs[t] = math.Pow(1-p, float64(t))
+	if e := checkExpected(min(probs[0], probs[t])*float64(n), n); e != nil {
+		return Result{}, e
+	}
+	// Count the gap lengths. A gap is counted as length t or longer as soon as it reaches t. Due to the independence of the random numbers, the
+	// next gap can start immediately and the number of random numbers is bounded by nt even for a poor source.
+	counts := make([]int, t+1)
+	for g, r := 0, 0; g < n; {
+		if u := uniform(src); u >= alpha && u < beta {
+			counts[r]++
+			g, r = g+1, 0
+		} else if r++; r == t {
+			counts[t]++
+			g, r = g+1, 0
+		}
+	}
+	// Chi-square test
+	x, pv, e := chiSquare(counts, probs, n)
+	return Result{Test: fmt.Sprintf("Gap(n=%d, alpha=%v, beta=%v, t=%d)", n, alpha, beta, t), Statistic: x, P: pv}, e
+}
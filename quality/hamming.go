@@ -0,0 +1,76 @@
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package quality
+
+// Import standard library packages and tsrand
+import (
+	"fmt"       // fmt
+	"math"      // math
+	"math/bits" // math/bits
+
+	"github.com/thorstenrie/tsrand" // tsrand
+)
+
+// HammingIndependence draws n pairs of consecutive blocks of l bits from the s most significant bits of the random 64-bit values of src and tests
+// the independence of the Hamming weights of the blocks of each pair with a chi-square test. The Hamming weights are binomially distributed with l trials
+// and the probability 1/2. Weights at both ends with a low probability are lumped into classes, so that the expected count of each pair of classes is
+// at least 5. It returns an error, if src is not available, s is not in [1,64], l is lower than 2 or less than two classes remain.
+func HammingIndependence(src tsrand.Source, n, s, l int) (Result, error) {
+	// Check source and parameters
+	if e := checkSource(src); e != nil {
+		return Result{}, e
+	}
+	if e := checkRange("s", s, 1, 64); e != nil {
+		return Result{}, e
+	}
+	if e := checkMin("l", l, 2); e != nil {
+		return Result{}, e
+	}
+	// Classes of the weights, the lowest and highest class lump the weights with a probability lower than the square root of minExpected/n
+	probs := binomial(l, 0.5)
+	minP := math.Sqrt(qualityc.minExpected / float64(n))
+	lo, sum := 0, probs[0]
+	for lo < l/2 && sum < minP {
+		lo++
+		sum += probs[lo]
+	}
+	hi := l - lo
+	c := hi - lo + 1
+	if e := checkMin("number of classes", c, 2); e != nil {
+		return Result{}, e
+	}
+	q := make([]float64, c)
+	for w, p := range probs {
+		q[class(w, lo, hi)] += p
+	}
+	// Probabilities of the pairs of classes
+	pq := make([]float64, c*c)
+	for i := range q {
+		for j := range q {
+			pq[i*c+j] = q[i] * q[j]
+		}
+	}
+	// Count the pairs of classes of the weights
+	b, counts := newBitStream(src, s), make([]int, c*c)
+	for i := 0; i < n; i++ {
+		counts[class(weight(b, l), lo, hi)*c+class(weight(b, l), lo, hi)]++
+	}
+	// Chi-square test
+	x, p, e := chiSquare(counts, pq, n)
+	return Result{Test: fmt.Sprintf("HammingIndependence(n=%d, s=%d, l=%d)", n, s, l), Statistic: x, P: p}, e
+}
+
+// weight returns the Hamming weight of the next l bits of b.
+func weight(b *bitStream, l int) int {
+	w := 0
+	for ; l > 0; l -= 64 {
+		w += bits.OnesCount64(b.read(min(l, 64)))
+	}
+	return w
+}
+
+// class returns the class of weight w, weights up to lo are in class 0 and weights from hi in class hi-lo.
+func class(w, lo, hi int) int {
+	return min(max(w, lo), hi) - lo
+}
@@ -0,0 +1,37 @@
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package quality
+
+// Import standard library packages and tsrand
+import (
+	"fmt"    // fmt
+	"slices" // slices
+
+	"github.com/thorstenrie/tsrand" // tsrand
+)
+
+// KolmogorovSmirnov draws n random numbers in [0,1) from src and tests with the Kolmogorov-Smirnov test, if they are uniformly distributed.
+// The statistic is the maximum distance D between the empirical distribution function and the uniform distribution function. The p-value is
+// the upper tail probability of D based on the Kolmogorov distribution. It returns an error, if src is not available or n is lower than 35.
+func KolmogorovSmirnov(src tsrand.Source, n int) (Result, error) {
+	// Check source and parameters, the asymptotic distribution requires a sample size of at least 35
+	if e := checkSource(src); e != nil {
+		return Result{}, e
+	}
+	if e := checkMin("n", n, 35); e != nil {
+		return Result{}, e
+	}
+	// Draw and sort the random numbers
+	u := make([]float64, n)
+	for i := range u {
+		u[i] = uniform(src)
+	}
+	slices.Sort(u)
+	// Maximum distance of the empirical distribution function
+	d, nf := 0.0, float64(n)
+	for i, x := range u {
+		d = max(d, float64(i+1)/nf-x, x-float64(i)/nf)
+	}
+	return Result{Test: fmt.Sprintf("KolmogorovSmirnov(n=%d)", n), Statistic: d, P: kolmogorovP(d, n)}, nil
+}
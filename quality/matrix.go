@@ -0,0 +1,43 @@
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package quality
+
+// Import standard library packages and tsrand
+import (
+	"fmt" // fmt
+
+	"github.com/thorstenrie/tsrand" // tsrand
+)
+
+// MatrixRank draws n random l x k binary matrices from src and tests the ranks of the matrices over GF(2) with a chi-square test. The rows of a
+// matrix are filled with the s most significant bits of the random 64-bit values. Ranks with an expected count lower than 5 are lumped together.
+// It returns an error, if src is not available, s is not in [1,64], l or k is not in [1,64] or less than two ranks remain after lumping.
+func MatrixRank(src tsrand.Source, n, s, l, k int) (Result, error) {
+	// Check source and parameters
+	if e := checkSource(src); e != nil {
+		return Result{}, e
+	}
+	for name, v := range map[string]int{"s": s, "l": l, "k": k} {
+		if e := checkRange(name, v, 1, 64); e != nil {
+			return Result{}, e
+		}
+	}
+	// Probabilities of the ranks 0, ..., min(l,k)
+	probs := make([]float64, min(l, k)+1)
+	for r := range probs {
+		probs[r] = rankP(r, l, k)
+	}
+	// Count the ranks of the matrices, the k bits of each row are in the most significant bits
+	b, rows := newBitStream(src, s), make([]uint64, l)
+	counts := make([]int, len(probs))
+	for i := 0; i < n; i++ {
+		for j := range rows {
+			rows[j] = b.read(k) << (64 - k)
+		}
+		counts[rank(rows)]++
+	}
+	// Chi-square test
+	x, p, e := chiSquare(counts, probs, n)
+	return Result{Test: fmt.Sprintf("MatrixRank(n=%d, s=%d, l=%d, k=%d)", n, s, l, k), Statistic: x, P: p}, e
+}
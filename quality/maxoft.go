@@ -0,0 +1,79 @@
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package quality
+
+// Import standard library packages and tsrand
+import (
+	"fmt"    // fmt
+	"math"   // math
+	"slices" // slices
+
+	"github.com/thorstenrie/tsrand" // tsrand
+)
+
+// MaxOfT draws n groups of t random numbers in [0,1) from src and tests the maxima of the groups. The t-th power of the maximum of a group is uniformly
+// distributed in [0,1). It returns two results: the chi-square test of the powers in d classes of equal probability and the Anderson-Darling test
+// of the powers. It returns an error, if src is not available, t is lower than 1, d is lower than 2, the expected count n/d of a class is lower than 5
+// or n is lower than 35.
+func MaxOfT(src tsrand.Source, n, d, t int) ([]Result, error) {
+	// Check source and parameters
+	if e := checkSource(src); e != nil {
+		return nil, e
+	}
+	if e := checkMin("t", t, 1); e != nil {
+		return nil, e
+	}
+	if e := checkMin("d", d, 2); e != nil {
+		return nil, e
+	}
+	if e := checkMin("n", n, 35); e != nil {
+		return nil, e
+	}
+	if e := checkExpected(float64(n)/float64(d), n); e != nil {
+		return nil, e
+	}
+	// Powers of the maxima and their classes
+	u, counts := make([]float64, n), make([]int, d)
+	for i := range u {
+		m := 0.0
+		for j := 0; j < t; j++ {
+			m = max(m, uniform(src))
+		}
+		u[i] = math.Pow(m, float64(t))
+		counts[min(int(u[i]*float64(d)), d-1)]++
+	}
+	name := fmt.Sprintf("MaxOfT(n=%d, d=%d, t=%d)", n, d, t)
+	x, p, e := chiSquare(counts, equal(d), n)
+	if e != nil {
+		return nil, e
+	}
+	a := andersonDarling(u)
+	return []Result{{Test: name, Statistic: x, P: p}, {Test: name + " AD", Statistic: a, P: 1 - adCDF(a)}}, nil
+}
+
+// andersonDarling returns the Anderson-Darling statistic of the random numbers u in [0,1) for the uniform distribution. The random numbers are sorted.
+func andersonDarling(u []float64) float64 {
+	slices.Sort(u)
+	n := len(u)
+	// Bound the random numbers away from 0 and 1 to avoid infinite logarithms
+	lo, hi := 1e-300, math.Nextafter(1, 0)
+	sum := 0.0
+	for i := range u {
+		a, b := min(max(u[i], lo), hi), min(max(u[n-1-i], lo), hi)
+		sum += float64(2*i+1) * (math.Log(a) + math.Log1p(-b))
+	}
+	return -float64(n) - sum/float64(n)
+}
+
+// adCDF returns the asymptotic cumulative distribution function of the Anderson-Darling statistic z based on the approximation of
+// Marsaglia and Marsaglia, Evaluating the Anderson-Darling distribution, 2004.
+func adCDF(z float64) float64 {
+	if z <= 0 {
+		return 0
+	}
+	if z < 2 {
+		return math.Exp(-1.2337141/z) / math.Sqrt(z) * (2.00012 + (0.247105-(0.0649821-(0.0347962-(0.011672-0.00168691*z)*z)*z)*z)*z)
+	}
+	return math.Exp(-math.Exp(1.0776 - (2.30695-(0.43424-(0.082433-(0.008056-0.0003146*z)*z)*z)*z)*z))
+}
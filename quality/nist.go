@@ -0,0 +1,222 @@
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package quality
+
+// Import standard library packages and tsrand
+import (
+	"fmt"     // fmt
+	"math"    // math
+	"strings" // strings
+
+	"github.com/thorstenrie/tsrand" // tsrand
+)
+
+// nistc holds the parameters of the NIST SP 800-22 tests, which are the defaults of the NIST statistical test suite
+var (
+	nistc = struct {
+		alpha            float64 // significance level of each test
+		uniformityAlpha  float64 // significance level of the uniformity of the p-values
+		uniformityBins   int     // number of bins of the uniformity test of the p-values
+		uniformityMin    int     // minimum number of p-values for the uniformity test
+		minBits          int     // minimum number of bits of a sequence
+		blockFrequency   int     // block length of the block frequency test
+		template         int     // length of the templates of the template matching tests
+		templateBlocks   int     // number of blocks of the non-overlapping template matching test
+		overlappingBlock int     // block length of the overlapping template matching test
+		overlappingK     int     // highest counted number of occurrences of the overlapping template matching test
+		linearBlock      int     // block length of the linear complexity test
+		serial           int     // pattern length of the serial test
+		entropy          int     // pattern length of the approximate entropy test
+	}{
+		alpha:            0.01,   // significance level of each test
+		uniformityAlpha:  0.0001, // significance level of the uniformity of the p-values
+		uniformityBins:   10,     // number of bins of the uniformity test of the p-values
+		uniformityMin:    55,     // minimum number of p-values for the uniformity test
+		minBits:          100,    // minimum number of bits of a sequence
+		blockFrequency:   128,    // block length of the block frequency test
+		template:         9,      // length of the templates of the template matching tests
+		templateBlocks:   8,      // number of blocks of the non-overlapping template matching test
+		overlappingBlock: 1032,   // block length of the overlapping template matching test
+		overlappingK:     5,      // highest counted number of occurrences of the overlapping template matching test
+		linearBlock:      500,    // block length of the linear complexity test
+		serial:           16,     // pattern length of the serial test
+		entropy:          10,     // pattern length of the approximate entropy test
+	}
+)
+
+// nistTest holds a test of NIST SP 800-22, the names of its p-values and the minimum number of bits of a sequence
+type nistTest struct {
+	names   []string               // names of the p-values
+	minBits int                    // minimum number of bits of a sequence
+	f       func([]byte) []float64 // test returning the p-values in the order of names or nil, if it is not applicable to the sequence
+}
+
+// nistTests returns the tests of NIST SP 800-22 in the order of the specification. The minimum numbers of bits follow the recommendations
+// of the specification. The non-overlapping template matching test requires an expected number of at least 5 occurrences in each block.
+func nistTests() []nistTest {
+	// Names of the states of the random excursions tests
+	states := func(name string, x []int) []string {
+		s := make([]string, len(x))
+		for i, v := range x {
+			s[i] = fmt.Sprintf("%s x=%+d", name, v)
+		}
+		return s
+	}
+	return []nistTest{
+		{[]string{"Frequency"}, nistc.minBits, nistFrequency},
+		{[]string{"BlockFrequency"}, max(nistc.minBits, nistc.blockFrequency), nistBlockFrequency},
+		{[]string{"Runs"}, nistc.minBits, nistRuns},
+		{[]string{"LongestRun"}, 128, nistLongestRun},
+		{[]string{"Rank"}, 38 * 32 * 32, nistRank},
+		{[]string{"DFT"}, 1000, nistDFT},
+		{templateNames(), nistc.templateBlocks * (5<<nistc.template + nistc.template - 1), nistNonOverlappingTemplate},
+		{[]string{"OverlappingTemplate"}, 1000000, nistOverlappingTemplate},
+		{[]string{"Universal"}, universalc.minBits[0], nistUniversal},
+		{[]string{"LinearComplexity"}, 1000000, nistLinearComplexity},
+		{[]string{"Serial 1", "Serial 2"}, 1 << (nistc.serial + 3), nistSerial},
+		{[]string{"ApproximateEntropy"}, 1 << (nistc.entropy + 6), nistApproximateEntropy},
+		{[]string{"CumulativeSums forward", "CumulativeSums backward"}, nistc.minBits, nistCumulativeSums},
+		{states("RandomExcursions", excursionsc.states), 1000000, nistRandomExcursions},
+		{states("RandomExcursionsVariant", excursionsc.variant), 1000000, nistRandomExcursionsVariant},
+	}
+}
+
+// NISTResult holds the p-values of a test of NIST SP 800-22 for all sequences, for which the test is applicable, and the analysis of the p-values.
+type NISTResult struct {
+	Test       string    // name of the test, including the template or state, if any
+	P          []float64 // p-values of the sequences, for which the test is applicable
+	Passed     int       // number of p-values not lower than the significance level 0.01
+	Proportion float64   // proportion of passed sequences, NaN if the test is not applicable to any sequence
+	Uniformity float64   // p-value of the chi-square test of the uniformity of the p-values in 10 bins, NaN for less than 55 p-values
+	Pass       bool      // true, if the proportion is in the confidence interval and the p-values are uniform
+}
+
+// NISTReport holds the results of the tests of NIST SP 800-22 for a number of sequences of a number of bits.
+type NISTReport struct {
+	Sequences int          // number of sequences
+	Bits      int          // number of bits of each sequence
+	Results   []NISTResult // results of the tests
+}
+
+// NIST runs the statistical tests of NIST SP 800-22 on the number of sequences with n bits each drawn from src. The bits of each random 64-bit value are
+// used from the most significant bit. It returns a report with the p-values of each test and each sequence and the proportion of passed sequences.
+// The tests use the default parameters of the NIST statistical test suite. Tests, which require more than n bits, are omitted: for all 15 tests, n must be
+// at least 1,000,000. The random excursions tests are only applied to sequences with at least 500 cycles. NIST returns an error, if src is not
+// available, the number of sequences is lower than 1 or n is lower than 100.
+func NIST(src tsrand.Source, sequences, n int) (*NISTReport, error) {
+	// Check source and parameters
+	if e := checkSource(src); e != nil {
+		return nil, e
+	}
+	if e := checkMin("sequences", sequences, 1); e != nil {
+		return nil, e
+	}
+	if e := checkMin("n", n, nistc.minBits); e != nil {
+		return nil, e
+	}
+	// Results of the applicable tests
+	var (
+		tests   []nistTest
+		results []NISTResult
+	)
+	for _, t := range nistTests() {
+		if n >= t.minBits {
+			tests = append(tests, t)
+			for _, name := range t.names {
+				results = append(results, NISTResult{Test: name})
+			}
+		}
+	}
+	// Run the tests on each sequence
+	e := make([]byte, n)
+	for s := 0; s < sequences; s++ {
+		bitsOf(src, e)
+		r := 0
+		for _, t := range tests {
+			p := t.f(e)
+			for i := range t.names {
+				if p != nil {
+					results[r+i].P = append(results[r+i].P, p[i])
+				}
+			}
+			r += len(t.names)
+		}
+	}
+	// Analyze the p-values
+	for i := range results {
+		results[i].analyze()
+	}
+	return &NISTReport{Sequences: sequences, Bits: n, Results: results}, nil
+}
+
+// bitsOf fills e with bits of random 64-bit values of src, starting with the most significant bit.
+func bitsOf(src tsrand.Source, e []byte) {
+	var v uint64
+	for i := range e {
+		if i%64 == 0 {
+			v = src.Uint64()
+		}
+		e[i] = byte(v >> 63)
+		v <<= 1
+	}
+}
+
+// analyze computes the number and proportion of passed sequences and the uniformity of the p-values. The proportion passes, if it is not lower than
+// the lower bound of the confidence interval of three standard deviations. A test, which is not applicable to any sequence, passes.
+func (r *NISTResult) analyze() {
+	k := len(r.P)
+	r.Proportion, r.Uniformity, r.Pass = math.NaN(), math.NaN(), true
+	if k == 0 {
+		return
+	}
+	// Count passed sequences and the p-values in bins
+	bins := make([]int, nistc.uniformityBins)
+	r.Passed = 0
+	for _, p := range r.P {
+		if p >= nistc.alpha {
+			r.Passed++
+		}
+		bins[min(int(p*float64(nistc.uniformityBins)), nistc.uniformityBins-1)]++
+	}
+	r.Proportion = float64(r.Passed) / float64(k)
+	ph := 1 - nistc.alpha
+	r.Pass = r.Proportion >= ph-3*math.Sqrt(ph*(1-ph)/float64(k))
+	// Uniformity of the p-values
+	if k >= nistc.uniformityMin {
+		r.Uniformity = chiSquareP(nistChiSquare(bins, equal(nistc.uniformityBins), k), float64(nistc.uniformityBins-1))
+		r.Pass = r.Pass && r.Uniformity >= nistc.uniformityAlpha
+	}
+}
+
+// Pass returns true, if all tests passed.
+func (r *NISTReport) Pass() bool {
+	for _, t := range r.Results {
+		if !t.Pass {
+			return false
+		}
+	}
+	return true
+}
+
+// String returns the report as table with the uniformity of the p-values, the proportion of passed sequences and the name of each test.
+// Failed tests are marked with an asterisk.
+func (r *NISTReport) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "NIST SP 800-22: %d sequences of %d bits\n", r.Sequences, r.Bits)
+	fmt.Fprintf(&b, "%-10s %-11s %s\n", "P-VALUE", "PROPORTION", "STATISTICAL TEST")
+	for _, t := range r.Results {
+		u, p, m := "-", "n/a", " "
+		if !math.IsNaN(t.Uniformity) {
+			u = fmt.Sprintf("%.6f", t.Uniformity)
+		}
+		if len(t.P) > 0 {
+			p = fmt.Sprintf("%d/%d", t.Passed, len(t.P))
+		}
+		if !t.Pass {
+			m = "*"
+		}
+		fmt.Fprintf(&b, "%-10s %-10s%s %s\n", u, p, m, t.Test)
+	}
+	return b.String()
+}
@@ -0,0 +1,146 @@
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package quality
+
+// Import standard library packages
+import (
+	"math" // math
+)
+
+// nistFrequency returns the p-value of the frequency (monobit) test of NIST SP 800-22 for the bits e.
+func nistFrequency(e []byte) []float64 {
+	// Sum of the bits mapped to -1 and +1
+	s := 0
+	for _, b := range e {
+		s += 2*int(b) - 1
+	}
+	return []float64{math.Erfc(math.Abs(float64(s)) / math.Sqrt(float64(len(e))) / math.Sqrt2)}
+}
+
+// nistBlockFrequency returns the p-value of the frequency test within blocks of NIST SP 800-22 for the bits e.
+func nistBlockFrequency(e []byte) []float64 {
+	m := nistc.blockFrequency
+	n := len(e) / m
+	// Sum of the squared deviations of the proportions of ones in each block from 1/2
+	x := 0.0
+	for i := 0; i < n; i++ {
+		ones := 0
+		for _, b := range e[i*m : (i+1)*m] {
+			ones += int(b)
+		}
+		d := float64(ones)/float64(m) - 0.5
+		x += d * d
+	}
+	x *= 4 * float64(m)
+	return []float64{gammaQ(float64(n)/2, x/2)}
+}
+
+// nistCumulativeSums returns the p-values of the cumulative sums test of NIST SP 800-22 in forward and backward mode for the bits e.
+func nistCumulativeSums(e []byte) []float64 {
+	n := len(e)
+	// Maximum absolute partial sums in forward and backward mode
+	s, fwd := 0, 0
+	for _, b := range e {
+		s += 2*int(b) - 1
+		fwd = max(fwd, abs(s))
+	}
+	s, bwd := 0, 0
+	for i := n - 1; i >= 0; i-- {
+		s += 2*int(e[i]) - 1
+		bwd = max(bwd, abs(s))
+	}
+	return []float64{cumulativeSumsP(fwd, n), cumulativeSumsP(bwd, n)}
+}
+
+// cumulativeSumsP returns the p-value of the maximum absolute partial sum z of n bits.
+func cumulativeSumsP(z, n int) float64 {
+	zf, nf := float64(z), float64(n)
+	sn := math.Sqrt(nf)
+	// Standard normal cumulative distribution function
+	phi := func(x float64) float64 { return 0.5 * math.Erfc(-x/math.Sqrt2) }
+	s1 := 0.0
+	for k := math.Floor((-nf/zf + 1) / 4); k <= math.Floor((nf/zf-1)/4); k++ {
+		s1 += phi((4*k+1)*zf/sn) - phi((4*k-1)*zf/sn)
+	}
+	s2 := 0.0
+	for k := math.Floor((-nf/zf - 3) / 4); k <= math.Floor((nf/zf-1)/4); k++ {
+		s2 += phi((4*k+3)*zf/sn) - phi((4*k+1)*zf/sn)
+	}
+	return 1 - s1 + s2
+}
+
+// nistRuns returns the p-value of the runs test of NIST SP 800-22 for the bits e.
+func nistRuns(e []byte) []float64 {
+	n := float64(len(e))
+	// Proportion of ones
+	ones := 0
+	for _, b := range e {
+		ones += int(b)
+	}
+	pi := float64(ones) / n
+	// The frequency test is a prerequisite, the p-value is 0, if the proportion deviates too much from 1/2
+	if math.Abs(pi-0.5) >= 2/math.Sqrt(n) {
+		return []float64{0}
+	}
+	// Number of runs
+	v := 1
+	for i := 1; i < len(e); i++ {
+		if e[i] != e[i-1] {
+			v++
+		}
+	}
+	return []float64{math.Erfc(math.Abs(float64(v)-2*n*pi*(1-pi)) / (2 * math.Sqrt(2*n) * pi * (1 - pi)))}
+}
+
+// nistLongestRun returns the p-value of the test for the longest run of ones in a block of NIST SP 800-22 for the bits e.
+// The block length and the categories depend on the number of bits.
+func nistLongestRun(e []byte) []float64 {
+	// Block length m, lowest category lo and probabilities of the categories lo, ..., lo+len(pi)-1
+	var (
+		m, lo int
+		pi    []float64
+	)
+	switch n := len(e); {
+	case n < 6272:
+		m, lo, pi = 8, 1, []float64{0.2148, 0.3672, 0.2305, 0.1875}
+	case n < 750000:
+		m, lo, pi = 128, 4, []float64{0.1174, 0.2430, 0.2493, 0.1752, 0.1027, 0.1124}
+	default:
+		m, lo, pi = 10000, 10, []float64{0.0882, 0.2092, 0.2483, 0.1933, 0.1208, 0.0675, 0.0727}
+	}
+	// Count the longest runs of ones in each block by category
+	n, v := len(e)/m, make([]int, len(pi))
+	for i := 0; i < n; i++ {
+		run, longest := 0, 0
+		for _, b := range e[i*m : (i+1)*m] {
+			if b == 1 {
+				run++
+				longest = max(longest, run)
+			} else {
+				run = 0
+			}
+		}
+		v[min(max(longest, lo), lo+len(pi)-1)-lo]++
+	}
+	return []float64{gammaQ(float64(len(pi)-1)/2, nistChiSquare(v, pi, n)/2)}
+}
+
+// nistChiSquare returns the chi-square statistic of the counts v with the probabilities pi for n observations.
+func nistChiSquare(v []int, pi []float64, n int) float64 {
+	x := 0.0
+	for i := range v {
+		e := float64(n) * pi[i]
+		d := float64(v[i]) - e
+		x += d * d / e
+	}
+	return x
+}
+
+// abs returns the absolute value of x.
+func abs(x int) int {
+	if x < 0 {
+		return -x
+	}
+	return x
+}
@@ -0,0 +1,123 @@
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package quality
+
+// Import standard library packages
+import (
+	"math" // math
+)
+
+// nistLinearComplexity returns the p-value of the linear complexity test of NIST SP 800-22 for the bits e.
+func nistLinearComplexity(e []byte) []float64 {
+	m := nistc.linearBlock
+	n := len(e) / m
+	mf := float64(m)
+	// Mean of the linear complexity of a block of m random bits
+	sign := 1.0
+	if m%2 == 1 {
+		sign = -1
+	}
+	mu := mf/2 + (9-sign)/36 - (mf/3+2.0/9)/math.Ldexp(1, m)
+	// Count the blocks by the deviation of the linear complexity from the mean
+	v := make([]int, 7)
+	for i := 0; i < n; i++ {
+		t := sign*(float64(berlekampMassey(e[i*m:(i+1)*m]))-mu) + 2.0/9
+		c := 0
+		for _, b := range []float64{-2.5, -1.5, -0.5, 0.5, 1.5, 2.5} {
+			if t > b {
+				c++
+			}
+		}
+		v[c]++
+	}
+	// Probabilities of the categories as in the NIST statistical test suite
+	pi := []float64{0.01047, 0.03125, 0.125, 0.5, 0.25, 0.0625, 0.020833}
+	return []float64{gammaQ(3, nistChiSquare(v, pi, n)/2)}
+}
+
+// berlekampMassey returns the linear complexity of the bits s, which is the length of the shortest linear feedback shift register generating s.
+func berlekampMassey(s []byte) int {
+	n := len(s)
+	c, b, t := make([]byte, n+1), make([]byte, n+1), make([]byte, n+1)
+	c[0], b[0] = 1, 1
+	l, m := 0, -1
+	for i := 0; i < n; i++ {
+		// Discrepancy of the next bit
+		d := s[i]
+		for j := 1; j <= l; j++ {
+			d ^= c[j] & s[i-j]
+		}
+		if d == 0 {
+			continue
+		}
+		copy(t, c)
+		for j := 0; j+i-m <= n; j++ {
+			c[j+i-m] ^= b[j]
+		}
+		if 2*l <= i {
+			l, m = i+1-l, i
+			copy(b, t)
+		}
+	}
+	return l
+}
+
+// nistSerial returns the two p-values of the serial test of NIST SP 800-22 for the bits e.
+func nistSerial(e []byte) []float64 {
+	m := nistc.serial
+	p0, p1, p2 := psiSquare(e, m), psiSquare(e, m-1), psiSquare(e, m-2)
+	d1, d2 := p0-p1, p0-2*p1+p2
+	return []float64{gammaQ(math.Ldexp(1, m-2), d1/2), gammaQ(math.Ldexp(1, m-3), d2/2)}
+}
+
+// patterns returns the counts of all overlapping patterns of m bits of e, which is extended by its first m-1 bits.
+func patterns(e []byte, m int) []int {
+	c := make([]int, 1<<m)
+	if m <= 0 {
+		return c
+	}
+	mask, v := 1<<m-1, 0
+	// Initialize the window with the first m-1 bits
+	for _, b := range e[:m-1] {
+		v = v<<1 | int(b)
+	}
+	for i := range e {
+		v = (v<<1 | int(e[(i+m-1)%len(e)])) & mask
+		c[v]++
+	}
+	return c
+}
+
+// psiSquare returns the statistic psi^2 of the overlapping patterns of m bits of e. It returns 0 for m <= 0.
+func psiSquare(e []byte, m int) float64 {
+	if m <= 0 {
+		return 0
+	}
+	n, sum := float64(len(e)), 0.0
+	for _, c := range patterns(e, m) {
+		sum += float64(c) * float64(c)
+	}
+	return sum*math.Ldexp(1, m)/n - n
+}
+
+// nistApproximateEntropy returns the p-value of the approximate entropy test of NIST SP 800-22 for the bits e.
+func nistApproximateEntropy(e []byte) []float64 {
+	m := nistc.entropy
+	n := float64(len(e))
+	apen := phi(e, m) - phi(e, m+1)
+	x := 2 * n * (math.Ln2 - apen)
+	return []float64{gammaQ(math.Ldexp(1, m-1), x/2)}
+}
+
+// phi returns the sum of pi*ln(pi) of the relative frequencies pi of the overlapping patterns of m bits of e.
+func phi(e []byte, m int) float64 {
+	n, sum := float64(len(e)), 0.0
+	for _, c := range patterns(e, m) {
+		if c > 0 {
+			p := float64(c) / n
+			sum += p * math.Log(p)
+		}
+	}
+	return sum
+}
@@ -0,0 +1,110 @@
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package quality
+
+// Import standard library packages
+import (
+	"math" // math
+)
+
+// excursionsc holds the states of the random excursions test and its variant
+var (
+	excursionsc = struct {
+		states  []int // states of the random excursions test
+		variant []int // states of the random excursions variant test
+	}{
+		states:  []int{-4, -3, -2, -1, 1, 2, 3, 4},
+		variant: []int{-9, -8, -7, -6, -5, -4, -3, -2, -1, 1, 2, 3, 4, 5, 6, 7, 8, 9},
+	}
+)
+
+// walk returns the partial sums of the bits e mapped to -1 and +1 and the number of cycles of the random walk, which starts and ends at 0.
+// It returns nil, if the number of cycles is too low for the random excursions tests.
+func walk(e []byte) ([]int, int) {
+	s, sum, j := make([]int, len(e)), 0, 0
+	for i, b := range e {
+		sum += 2*int(b) - 1
+		s[i] = sum
+		if sum == 0 {
+			j++
+		}
+	}
+	// The walk returns to 0 at its end
+	if sum != 0 {
+		j++
+	}
+	if float64(j) < max(0.005*math.Sqrt(float64(len(e))), 500) {
+		return nil, j
+	}
+	return s, j
+}
+
+// nistRandomExcursions returns the p-values of the random excursions test of NIST SP 800-22 for the bits e and each state.
+// It returns nil, if the test is not applicable due to a low number of cycles.
+func nistRandomExcursions(e []byte) []float64 {
+	s, j := walk(e)
+	if s == nil {
+		return nil
+	}
+	// Count the visits of each state in each cycle, the visits are counted up to 5
+	const k = 5
+	v := make([][k + 1]int, len(excursionsc.states))
+	visits := make([]int, len(excursionsc.states))
+	count := func() {
+		for i, c := range visits {
+			v[i][min(c, k)]++
+			visits[i] = 0
+		}
+	}
+	for _, x := range s {
+		if x == 0 {
+			count()
+		} else if -4 <= x && x <= 4 {
+			visits[state(x)]++
+		}
+	}
+	if s[len(s)-1] != 0 {
+		count()
+	}
+	// Chi-square test of the visits of each state
+	p := make([]float64, len(excursionsc.states))
+	for i, x := range excursionsc.states {
+		a := 1 / (2 * math.Abs(float64(x)))
+		pi := make([]float64, k+1)
+		pi[0] = 1 - a
+		for c := 1; c < k; c++ {
+			pi[c] = a * a * math.Pow(1-a, float64(c-1))
+		}
+		pi[k] = a * math.Pow(1-a, k-1)
+		p[i] = gammaQ(float64(k)/2, nistChiSquare(v[i][:], pi, j)/2)
+	}
+	return p
+}
+
+// state returns the index of the state x in the states of the random excursions test.
+func state(x int) int {
+	if x < 0 {
+		return x + 4
+	}
+	return x + 3
+}
+
+// nistRandomExcursionsVariant returns the p-values of the random excursions variant test of NIST SP 800-22 for the bits e and each state.
+// It returns nil, if the test is not applicable due to a low number of cycles.
+func nistRandomExcursionsVariant(e []byte) []float64 {
+	s, j := walk(e)
+	if s == nil {
+		return nil
+	}
+	// Count the total visits of each state
+	visits := make(map[int]int)
+	for _, x := range s {
+		visits[x]++
+	}
+	p := make([]float64, len(excursionsc.variant))
+	for i, x := range excursionsc.variant {
+		p[i] = math.Erfc(math.Abs(float64(visits[x]-j)) / math.Sqrt(2*float64(j)*(4*math.Abs(float64(x))-2)))
+	}
+	return p
+}
@@ -0,0 +1,157 @@
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package quality
+
+// Import standard library packages
+import (
+	"math"       // math
+	"math/bits"  // math/bits
+	"math/cmplx" // math/cmplx
+)
+
+// nistRank returns the p-value of the binary matrix rank test of NIST SP 800-22 for the bits e. The bits are
+// filled row by row into 32x32 matrices.
+func nistRank(e []byte) []float64 {
+	const q = 32
+	n := len(e) / (q * q)
+	// Count the matrices with full rank, rank 31 and lower rank
+	v := make([]int, 3)
+	for k := 0; k < n; k++ {
+		var rows [q]uint32
+		for i := range rows {
+			for _, b := range e[(k*q+i)*q : (k*q+i+1)*q] {
+				rows[i] = rows[i]<<1 | uint32(b)
+			}
+		}
+		v[min(q-rank(rows[:]), 2)]++
+	}
+	// Probabilities of full rank, rank 31 and lower rank
+	p32, p31 := rankP(q, q, q), rankP(q-1, q, q)
+	return []float64{math.Exp(-nistChiSquare(v, []float64{p32, p31, 1 - p32 - p31}, n) / 2)}
+}
+
+// rank returns the rank of the binary matrix with the rows over GF(2). The rows are modified.
+func rank[T uint32 | uint64](rows []T) int {
+	r := 0
+	for bit := ^T(0) ^ (^T(0) >> 1); bit != 0 && r < len(rows); bit >>= 1 {
+		// Find a pivot row with the bit set
+		p := -1
+		for i := r; i < len(rows); i++ {
+			if rows[i]&bit != 0 {
+				p = i
+				break
+			}
+		}
+		if p < 0 {
+			continue
+		}
+		// Eliminate the bit in all other rows
+		rows[r], rows[p] = rows[p], rows[r]
+		for i := range rows {
+			if i != r && rows[i]&bit != 0 {
+				rows[i] ^= rows[r]
+			}
+		}
+		r++
+	}
+	return r
+}
+
+// rankP returns the probability that a random l x k binary matrix has rank r.
+func rankP(r, l, k int) float64 {
+	p := math.Ldexp(1, r*(l+k-r)-l*k)
+	for i := 0; i < r; i++ {
+		p *= (1 - math.Ldexp(1, i-l)) * (1 - math.Ldexp(1, i-k)) / (1 - math.Ldexp(1, i-r))
+	}
+	return p
+}
+
+// nistDFT returns the p-value of the discrete Fourier transform (spectral) test of NIST SP 800-22 for the bits e.
+func nistDFT(e []byte) []float64 {
+	n := len(e)
+	// Transform the bits mapped to -1 and +1
+	x := make([]complex128, n)
+	for i, b := range e {
+		x[i] = complex(float64(2*int(b)-1), 0)
+	}
+	f := dft(x)
+	// Count the peaks of the first half below the 95 % threshold
+	t := math.Sqrt(math.Log(1/0.05) * float64(n))
+	n1 := 0
+	for _, c := range f[:n/2] {
+		if cmplx.Abs(c) < t {
+			n1++
+		}
+	}
+	n0 := 0.95 * float64(n) / 2
+	d := (float64(n1) - n0) / math.Sqrt(float64(n)*0.95*0.05/4)
+	return []float64{math.Erfc(math.Abs(d) / math.Sqrt2)}
+}
+
+// dft returns the discrete Fourier transform of x of any length based on the chirp z-transform of Bluestein.
+func dft(x []complex128) []complex128 {
+	n := len(x)
+	// Length of the convolution as power of two
+	m := 1 << bits.Len(uint(2*n-1))
+	// Chirp w[k] = exp(-i*pi*k^2/n), k^2 is reduced modulo 2n to retain precision
+	w := make([]complex128, n)
+	for k := range w {
+		s := float64(k * k % (2 * n))
+		w[k] = cmplx.Exp(complex(0, -math.Pi*s/float64(n)))
+	}
+	a, b := make([]complex128, m), make([]complex128, m)
+	for k := range x {
+		a[k] = x[k] * w[k]
+	}
+	b[0] = cmplx.Conj(w[0])
+	for k := 1; k < n; k++ {
+		b[k] = cmplx.Conj(w[k])
+		b[m-k] = b[k]
+	}
+	// Convolution of a and b
+	fft(a, false)
+	fft(b, false)
+	for i := range a {
+		a[i] *= b[i]
+	}
+	fft(a, true)
+	y := make([]complex128, n)
+	for k := range y {
+		y[k] = a[k] * w[k] / complex(float64(m), 0)
+	}
+	return y
+}
+
+// fft computes the unnormalized fast Fourier transform of x in place with the iterative radix-2 algorithm. The length of x must be a power of two.
+// If inv is true, it computes the inverse transform without the normalization by 1/len(x).
+func fft(x []complex128, inv bool) {
+	n := len(x)
+	// Bit-reversal permutation
+	for i, j := 1, 0; i < n; i++ {
+		bit := n >> 1
+		for ; j&bit != 0; bit >>= 1 {
+			j ^= bit
+		}
+		j ^= bit
+		if i < j {
+			x[i], x[j] = x[j], x[i]
+		}
+	}
+	// Butterflies
+	sign := -1.0
+	if inv {
+		sign = 1
+	}
+	for l := 2; l <= n; l <<= 1 {
+		wl := cmplx.Exp(complex(0, sign*2*math.Pi/float64(l)))
+		for i := 0; i < n; i += l {
+			w := complex(1, 0)
+			for j := 0; j < l/2; j++ {
+				u, v := x[i+j], x[i+j+l/2]*w
+				x[i+j], x[i+j+l/2] = u+v, u-v
+				w *= wl
+			}
+		}
+	}
+}
@@ -0,0 +1,175 @@
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package quality
+
+// Import standard library packages
+import (
+	"fmt"  // fmt
+	"math" // math
+)
+
+// templates returns all aperiodic templates of m bits in ascending order. A template is aperiodic, if no proper prefix equals the suffix of the same length,
+// so that occurrences of the template cannot overlap.
+func templates(m int) []uint32 {
+	var t []uint32
+	for b := uint32(0); b < 1<<m; b++ {
+		aperiodic := true
+		for k := 1; k < m && aperiodic; k++ {
+			// Prefix of m-k bits equals the suffix of m-k bits
+			if b>>k == b&(1<<(m-k)-1) {
+				aperiodic = false
+			}
+		}
+		if aperiodic {
+			t = append(t, b)
+		}
+	}
+	return t
+}
+
+// templateNames returns the names of the non-overlapping template tests, which are the bits of the templates.
+func templateNames() []string {
+	t := templates(nistc.template)
+	names := make([]string, len(t))
+	for i, b := range t {
+		names[i] = fmt.Sprintf("NonOverlappingTemplate %0*b", nistc.template, b)
+	}
+	return names
+}
+
+// nistNonOverlappingTemplate returns the p-values of the non-overlapping template matching test of NIST SP 800-22 for the bits e and each
+// aperiodic template.
+func nistNonOverlappingTemplate(e []byte) []float64 {
+	m, n := nistc.template, nistc.templateBlocks
+	bm := len(e) / n
+	// Expected value and variance of the number of occurrences in a block
+	mu := float64(bm-m+1) / math.Ldexp(1, m)
+	v := float64(bm) * (1/math.Ldexp(1, m) - float64(2*m-1)/math.Ldexp(1, 2*m))
+	// Bits of the window ending at each position
+	w := windows(e, m)
+	t := templates(m)
+	p := make([]float64, len(t))
+	for j, b := range t {
+		x := 0.0
+		for i := 0; i < n; i++ {
+			// Count the occurrences in the block, the search continues after an occurrence
+			c := 0
+			for k := i*bm + m - 1; k < (i+1)*bm; k++ {
+				if w[k] == b {
+					c++
+					k += m - 1
+				}
+			}
+			d := float64(c) - mu
+			x += d * d / v
+		}
+		p[j] = gammaQ(float64(n)/2, x/2)
+	}
+	return p
+}
+
+// windows returns the bits e[i-m+1], ..., e[i] as integer w[i] for each position i >= m-1.
+func windows(e []byte, m int) []uint32 {
+	w, mask := make([]uint32, len(e)), uint32(1)<<m-1
+	v := uint32(0)
+	for i, b := range e {
+		v = (v<<1 | uint32(b)) & mask
+		w[i] = v
+	}
+	return w
+}
+
+// nistOverlappingTemplate returns the p-value of the overlapping template matching test of NIST SP 800-22 for the bits e with the template of ones.
+func nistOverlappingTemplate(e []byte) []float64 {
+	m, bm, k := nistc.template, nistc.overlappingBlock, nistc.overlappingK
+	n := len(e) / bm
+	// Probabilities of 0, ..., k-1 and k or more occurrences in a block
+	eta := float64(bm-m+1) / math.Ldexp(1, m) / 2
+	pi, sum := make([]float64, k+1), 0.0
+	for u := 0; u < k; u++ {
+		pi[u] = overlappingP(u, eta)
+		sum += pi[u]
+	}
+	pi[k] = 1 - sum
+	// Count the blocks by the number of overlapping occurrences
+	ones := uint32(1)<<m - 1
+	w := windows(e, m)
+	v := make([]int, k+1)
+	for i := 0; i < n; i++ {
+		c := 0
+		for j := i*bm + m - 1; j < (i+1)*bm; j++ {
+			if w[j] == ones {
+				c++
+			}
+		}
+		v[min(c, k)]++
+	}
+	return []float64{gammaQ(float64(k)/2, nistChiSquare(v, pi, n)/2)}
+}
+
+// overlappingP returns the probability of u overlapping occurrences of the template of ones in a block with the parameter eta.
+func overlappingP(u int, eta float64) float64 {
+	if u == 0 {
+		return math.Exp(-eta)
+	}
+	lg := func(x int) float64 { l, _ := math.Lgamma(float64(x)); return l }
+	p := 0.0
+	for l := 1; l <= u; l++ {
+		p += math.Exp(-eta - float64(u)*math.Ln2 + float64(l)*math.Log(eta) - lg(l+1) + lg(u) - lg(l) - lg(u-l+1))
+	}
+	return p
+}
+
+// universalc holds the expected values and variances of Maurer's universal statistical test for the block lengths 6, ..., 16
+// and the minimum number of bits for each block length
+var (
+	universalc = struct {
+		minBits  []int     // minimum number of bits for the block lengths 6, ..., 16
+		expected []float64 // expected values for the block lengths 6, ..., 16
+		variance []float64 // variances for the block lengths 6, ..., 16
+	}{
+		minBits:  []int{387840, 904960, 2068480, 4654080, 10342400, 22753280, 49643520, 107560960, 231669760, 496435200, 1059061760},
+		expected: []float64{5.2177052, 6.1962507, 7.1836656, 8.1764248, 9.1723243, 10.170032, 11.168765, 12.168070, 13.167693, 14.167488, 15.167379},
+		variance: []float64{2.954, 3.125, 3.238, 3.311, 3.356, 3.384, 3.401, 3.410, 3.416, 3.419, 3.421},
+	}
+)
+
+// nistUniversal returns the p-value of Maurer's universal statistical test of NIST SP 800-22 for the bits e. The block length
+// depends on the number of bits.
+func nistUniversal(e []byte) []float64 {
+	n := len(e)
+	// Block length l
+	j := 0
+	for j+1 < len(universalc.minBits) && n >= universalc.minBits[j+1] {
+		j++
+	}
+	l := j + 6
+	q := 10 << l
+	k := n/l - q
+	// Blocks as integers
+	block := func(i int) int {
+		v := 0
+		for _, b := range e[i*l : (i+1)*l] {
+			v = v<<1 | int(b)
+		}
+		return v
+	}
+	// Initialize the table with the last occurrence of each block in the first q blocks
+	t := make([]int, 1<<l)
+	for i := 1; i <= q; i++ {
+		t[block(i-1)] = i
+	}
+	// Sum of the logarithms of the distances to the last occurrence in the following k blocks
+	sum := 0.0
+	for i := q + 1; i <= q+k; i++ {
+		b := block(i - 1)
+		sum += math.Log2(float64(i - t[b]))
+		t[b] = i
+	}
+	f := sum / float64(k)
+	lf := float64(l)
+	c := 0.7 - 0.8/lf + (4+32/lf)*math.Pow(float64(k), -3/lf)/15
+	sigma := c * math.Sqrt(universalc.variance[j]/float64(k))
+	return []float64{math.Erfc(math.Abs(f-universalc.expected[j]) / (math.Sqrt2 * sigma))}
+}
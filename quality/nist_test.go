@@ -0,0 +1,216 @@
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package quality
+
+// Import standard library packages, tserr and tsrand
+import (
+	"fmt"      // fmt
+	"math"     // math
+	"math/big" // math/big
+	"strings"  // strings
+	"testing"  // testing
+
+	"github.com/thorstenrie/tserr"  // tserr
+	"github.com/thorstenrie/tsrand" // tsrand
+)
+
+const (
+	testNISTBits int     = 1000000 // number of bits of the reference sequence
+	maxDiffNIST  float64 = 1e-6    // maximum difference of a p-value to its reference value
+)
+
+// testBits returns the bits of the string s.
+func testBits(s string) []byte {
+	b := make([]byte, len(s))
+	for i := range s {
+		b[i] = s[i] - '0'
+	}
+	return b
+}
+
+// testSplit returns p and q with p/q = 1/(a+1) + 1/((a+1)(a+2)) + ... + 1/((a+1)...b) based on binary splitting.
+func testSplit(a, b int64) (*big.Int, *big.Int) {
+	if b-a == 1 {
+		return big.NewInt(1), big.NewInt(b)
+	}
+	m := (a + b) / 2
+	p1, q1 := testSplit(a, m)
+	p2, q2 := testSplit(m, b)
+	p := new(big.Int).Mul(p1, q2)
+	return p.Add(p, p2), new(big.Int).Mul(q1, q2)
+}
+
+// testE returns the first n bits of the binary expansion of e = 10.1011011111..., which is the reference sequence data.e of NIST SP 800-22.
+func testE(n int) []byte {
+	// e - 1 = p/q with the sum of 1/k! for k = 1, ..., 80000, which is precise to more than 10^6 bits
+	p, q := testSplit(0, 80000)
+	p.Add(p, q)
+	// Bits of e, the integer part has two bits
+	x := new(big.Int).Lsh(p, uint(n-2))
+	return testBits(x.Quo(x, q).Text(2))[:n]
+}
+
+// TestNISTReference tests the p-values of each test for the first 10^6 bits of e against the reference values of NIST SP 800-22, Appendix B.
+func TestNISTReference(t *testing.T) {
+	e := testE(testNISTBits)
+	// Reference p-values of the first p-value of each test, the second p-value of the serial and cumulative sums test and
+	// the random excursions tests for the states +1 and -1
+	want := map[string]float64{
+		"Frequency":                        0.953749,
+		"BlockFrequency":                   0.211072,
+		"Runs":                             0.561917,
+		"LongestRun":                       0.718945,
+		"Rank":                             0.306156,
+		"DFT":                              0.847187,
+		"NonOverlappingTemplate 000000001": 0.078790,
+		"OverlappingTemplate":              0.110434,
+		"Universal":                        0.282568,
+		"LinearComplexity":                 0.826335,
+		"Serial 1":                         0.766182,
+		"Serial 2":                         0.462921,
+		"ApproximateEntropy":               0.700073,
+		"CumulativeSums forward":           0.669887,
+		"CumulativeSums backward":          0.724266,
+		"RandomExcursions x=+1":            0.786868,
+		"RandomExcursionsVariant x=-1":     0.826009,
+	}
+	for _, test := range nistTests() {
+		// The test fails, if the test is not applicable
+		p := test.f(e)
+		if len(p) != len(test.names) {
+			t.Fatal(tserr.Equal(&tserr.EqualArgs{Var: "p-values of " + test.names[0], Actual: int64(len(p)), Want: int64(len(test.names))}))
+		}
+		// The test fails, if the p-value differs from the reference value
+		for i, name := range test.names {
+			if w, ok := want[name]; ok && math.Abs(p[i]-w) > maxDiffNIST {
+				t.Error(tserr.Equalf(&tserr.EqualfArgs{Var: name, Actual: p[i], Want: w}))
+			}
+			delete(want, name)
+		}
+	}
+	// The test fails, if a reference value is not tested
+	for name := range want {
+		t.Error(tserr.NotExistent(name))
+	}
+}
+
+// TestNISTExamples tests the p-values of the tests against the examples of NIST SP 800-22, Section 2.
+func TestNISTExamples(t *testing.T) {
+	// Example of 100 bits of the tests
+	e100 := testBits("1100100100001111110110101010001000100001011010001100001000110100110001001100011001100010100010111000")
+	// Example of 128 bits of the longest run test
+	e128 := testBits("11001100000101010110110001001100111000000000001001001101010100010001001111010110100000001101011111001100111001101101100010110010")
+	for _, c := range []struct {
+		name string
+		f    func([]byte) []float64
+		e    []byte
+		want []float64
+	}{
+		{"Frequency 10 bits", nistFrequency, testBits("1011010101"), []float64{0.527089}},
+		{"Frequency 100 bits", nistFrequency, e100, []float64{0.109599}},
+		{"Runs 10 bits", nistRuns, testBits("1001101011"), []float64{0.147232}},
+		{"Runs 100 bits", nistRuns, e100, []float64{0.500798}},
+		{"CumulativeSums 100 bits", nistCumulativeSums, e100, []float64{0.219194, 0.114866}},
+		{"LongestRun 128 bits", nistLongestRun, e128, []float64{0.180609}},
+	} {
+		// The test fails, if a p-value differs from the example, the probabilities in the specification are rounded
+		for i, p := range c.f(c.e) {
+			if math.Abs(p-c.want[i]) > 1e-4 {
+				t.Error(tserr.Equalf(&tserr.EqualfArgs{Var: fmt.Sprintf("%s p-value %d", c.name, i), Actual: p, Want: c.want[i]}))
+			}
+		}
+	}
+	// The test fails, if the number of aperiodic templates of 9 bits is not 148
+	if l := len(templates(nistc.template)); l != 148 {
+		t.Error(tserr.Equal(&tserr.EqualArgs{Var: "number of templates", Actual: int64(l), Want: 148}))
+	}
+	// The test fails, if the transform does not match the discrete Fourier transform of a short sequence of odd length
+	x := []complex128{1, -1, -1, 1, 1, 1, -1}
+	for k, c := range dft(x) {
+		w := complex(0, 0)
+		for j, v := range x {
+			s, co := math.Sincos(-2 * math.Pi * float64(j*k) / float64(len(x)))
+			w += v * complex(co, s)
+		}
+		if d := c - w; math.Hypot(real(d), imag(d)) > 1e-9 {
+			t.Error(tserr.Equalf(&tserr.EqualfArgs{Var: fmt.Sprintf("dft %d", k), Actual: real(c), Want: real(w)}))
+		}
+	}
+}
+
+// TestNISTReport tests that the report of a good source passes, the report of a poor counter source fails and tests requiring more bits are omitted.
+func TestNISTReport(t *testing.T) {
+	for name, c := range map[string]struct {
+		src  tsrand.Source
+		pass bool
+	}{"PCG64Source": {testSource(), true}, "testCounterSource": {&testCounterSource{}, false}} {
+		// The test fails, if NIST returns an error
+		r, e := NIST(c.src, 10, 100000)
+		if e != nil {
+			t.Fatal(tserr.Op(&tserr.OpArgs{Op: "NIST", Fn: name, Err: e}))
+		}
+		// The test fails, if the report does not pass or fail as expected
+		if r.Pass() != c.pass {
+			t.Error(tserr.Check(&tserr.CheckArgs{F: "report of " + name, Err: fmt.Errorf("pass is %v\n%v", r.Pass(), r)}))
+		}
+		// The test fails, if tests requiring 10^6 bits are not omitted
+		for _, res := range r.Results {
+			if strings.HasPrefix(res.Test, "LinearComplexity") || strings.HasPrefix(res.Test, "RandomExcursions") {
+				t.Error(tserr.Forbidden(res.Test + " of 100000 bits"))
+			}
+			// The test fails, if not all sequences are evaluated
+			if len(res.P) != 10 {
+				t.Error(tserr.Equal(&tserr.EqualArgs{Var: "p-values of " + res.Test, Actual: int64(len(res.P)), Want: 10}))
+			}
+		}
+		// The test fails, if the table does not contain the tests or marks failed tests not as expected
+		if s := r.String(); !strings.Contains(s, "NonOverlappingTemplate 000000001") || strings.Contains(s, "*") == c.pass {
+			t.Error(tserr.Check(&tserr.CheckArgs{F: "table of " + name, Err: fmt.Errorf("%v", s)}))
+		}
+	}
+}
+
+// TestNISTAnalyze tests the proportion and uniformity of the p-values of a result.
+func TestNISTAnalyze(t *testing.T) {
+	// Uniform p-values pass, p-values in one bin fail the uniformity test and low p-values fail the proportion
+	uniform, bin, low := make([]float64, 100), make([]float64, 100), make([]float64, 100)
+	for i := range uniform {
+		uniform[i] = (float64(i) + 0.5) / 100
+		bin[i] = 0.5
+		low[i] = 0.001 + 0.5*float64(i%2)
+	}
+	for name, c := range map[string]struct {
+		p    []float64
+		pass bool
+	}{"uniform": {uniform, true}, "bin": {bin, false}, "low": {low, false}, "not applicable": {nil, true}} {
+		r := NISTResult{Test: name, P: c.p}
+		// The test fails, if the result does not pass or fail as expected
+		if r.analyze(); r.Pass != c.pass {
+			t.Error(tserr.Check(&tserr.CheckArgs{F: name, Err: fmt.Errorf("pass is %v with proportion %v and uniformity %v", r.Pass, r.Proportion, r.Uniformity)}))
+		}
+	}
+}
+
+// TestNISTInvalid tests that NIST returns an error for a nil source, an unavailable source and invalid parameters.
+func TestNISTInvalid(t *testing.T) {
+	for name, f := range map[string]func() (*NISTReport, error){
+		"nil":                  func() (*NISTReport, error) { return NIST(nil, 1, 1000) },
+		"BufferedCryptoSource": func() (*NISTReport, error) { return NIST(tsrand.NewBufferedCryptoSource(1), 1, 1000) },
+		"sequences":            func() (*NISTReport, error) { return NIST(testSource(), 0, 1000) },
+		"n":                    func() (*NISTReport, error) { return NIST(testSource(), 1, 99) },
+	} {
+		// The test fails, if NIST does not return an error
+		if r, e := f(); e == nil || r != nil {
+			t.Error(tserr.NilFailed(name))
+		}
+	}
+}
+
+// BenchmarkNIST performs a benchmark on all tests of NIST SP 800-22 for a sequence of 10^6 bits.
+func BenchmarkNIST(b *testing.B) {
+	src := testSource()
+	for i := 0; i < b.N; i++ {
+		NIST(src, 1, testNISTBits)
+	}
+}
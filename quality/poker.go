@@ -0,0 +1,88 @@
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package quality
+
+// Import standard library packages and tsrand
+import (
+	"fmt" // fmt
+
+	"github.com/thorstenrie/tsrand" // tsrand
+)
+
+// Poker draws n hands of k random integers in [0,d) from src and tests the number of distinct values in each hand with a chi-square test.
+// Numbers of distinct values with an expected count lower than 5 are lumped together. It returns an error, if src is not available, d or k is lower
+// than 2 or less than two numbers of distinct values remain after lumping.
+func Poker(src tsrand.Source, n, k, d int) (Result, error) {
+	// Check source and parameters
+	if e := checkSource(src); e != nil {
+		return Result{}, e
+	}
+	if e := checkMin("k", k, 2); e != nil {
+		return Result{}, e
+	}
+	if e := checkMin("d", d, 2); e != nil {
+		return Result{}, e
+	}
+	// Probabilities of 1, ..., min(k,d) distinct values in a hand
+	probs := occupancy(k, d)[1:]
+	// Count the number of distinct values of each hand
+	counts, seen := make([]int, len(probs)), make([]int, d)
+	for i := 1; i <= n; i++ {
+		r := 0
+		for j := 0; j < k; j++ {
+			// Values are marked with the hand number i to avoid clearing seen for each hand
+			if v := tsrand.Uint64n(src, uint64(d)); seen[v] != i {
+				seen[v] = i
+				r++
+			}
+		}
+		counts[r-1]++
+	}
+	// Chi-square test
+	x, p, e := chiSquare(counts, probs, n)
+	return Result{Test: fmt.Sprintf("Poker(n=%d, k=%d, d=%d)", n, k, d), Statistic: x, P: p}, e
+}
+
+// CouponCollector draws random integers in [0,d) from src until n segments are observed and tests the lengths of the segments with a chi-square test.
+// A segment is the sequence of random integers until each of the d values occurred at least once. Segment lengths of t or longer are lumped
+// together. It returns an error, if src is not available, d is lower than 2, t is not higher than d or the expected count of a segment length
+// is lower than 5.
+func CouponCollector(src tsrand.Source, n, d, t int) (Result, error) {
+	// Check source and parameters
+	if e := checkSource(src); e != nil {
+		return Result{}, e
+	}
+	if e := checkMin("d", d, 2); e != nil {
+		return Result{}, e
+	}
+	if e := checkMin("t", t, d+1); e != nil {
+		return Result{}, e
+	}
+	// Probabilities of the segment lengths d, ..., t-1 and t or longer. A segment has length r, if the first r-1 integers contain exactly d-1
+	// distinct values and the r-th integer is the missing value.
+	probs, sum := make([]float64, t-d+1), 0.0
+	for r := d; r < t; r++ {
+		probs[r-d] = occupancy(r-1, d)[d-1] / float64(d)
+		sum += probs[r-d]
+	}
+	probs[t-d] = max(0, 1-sum)
+	// Count the segment lengths. A segment is counted as length t or longer as soon as it reaches t and the next segment starts, so that
+	// the number of random integers is bounded by nt even for a poor source.
+	counts, seen := make([]int, len(probs)), make([]int, d)
+	for s := 1; s <= n; s++ {
+		r, c := 0, 0
+		for c < d && r < t {
+			// Values are marked with the segment number s to avoid clearing seen for each segment
+			if v := tsrand.Uint64n(src, uint64(d)); seen[v] != s {
+				seen[v] = s
+				c++
+			}
+			r++
+		}
+		counts[min(r, t)-d]++
+	}
+	// Chi-square test
+	x, p, e := chiSquare(counts, probs, n)
+	return Result{Test: fmt.Sprintf("CouponCollector(n=%d, d=%d, t=%d)", n, d, t), Statistic: x, P: p}, e
+}
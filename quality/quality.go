@@ -0,0 +1,153 @@
+// Package quality provides statistical tests to assess the quality of random number generator sources.
+//
+// Each test draws random numbers from a tsrand Source, computes a test statistic and returns a Result with the p-value
+// of the statistic under the hypothesis that the source produces independent uniformly distributed random numbers.
+// For a good source, the p-values are uniformly distributed in [0,1]. A p-value very close to 0 or, for most tests,
+// very close to 1 indicates a defect of the source. A single test can only reveal some kinds of defects. Therefore, a source
+// should be vetted with several tests and parameters before it is used with tsrand.New.
+//
+// - Frequency tests the uniformity of random integers with a chi-square test
+// - Serial tests the uniformity of non-overlapping pairs, triples or longer tuples of random integers
+// - Gap tests the lengths of gaps between random numbers in an interval
+// - Poker tests the number of distinct values in hands of random integers
+// - Runs tests the number of runs up and down
+// - CouponCollector tests the number of random integers needed to collect all values
+// - BirthdaySpacings tests the number of equal spacings between sorted random birthdays of Marsaglia
+// - KolmogorovSmirnov tests the distribution of random floats with the Kolmogorov-Smirnov test
+// - Collision tests the number of collisions of random tuples in a large number of cells
+// - MaxOfT tests the maxima of groups of random floats
+// - WeightDistribution tests the number of random floats in an interval within groups
+// - MatrixRank tests the ranks of random binary matrices
+// - HammingIndependence tests the independence of the Hamming weights of consecutive blocks of bits
+// - RandomWalk tests five statistics of random walks
+//
+// SmallCrush runs a battery of these tests with the parameters of SmallCrush of TestU01 and returns a BatteryReport with a summary of suspect p-values.
+// RunBattery runs a custom battery of tests.
+//
+// NIST runs the 15 statistical tests of NIST SP 800-22 on a number of bit sequences of a source and returns a NISTReport with the p-values
+// of each test and sequence, the proportion of passed sequences and the uniformity of the p-values.
+//
+// Entropy and EntropyBytes estimate the min-entropy per bit of a source or of sampled output with the most common value, collision, Markov and compression
+// estimates of NIST SP 800-90B and return an EntropyReport.
+//
+// The tests return an error, if the source is nil or not available or a parameter is invalid, e.g., the sample size is too
+// small for a valid approximation of the distribution of the test statistic.
+//
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package quality
+
+// Import standard library packages, tserr and tsrand
+import (
+	"fmt" // fmt
+
+	"github.com/thorstenrie/tserr"  // tserr
+	"github.com/thorstenrie/tsrand" // tsrand
+)
+
+// qualityc holds the constants of the statistical tests
+var (
+	qualityc = struct {
+		minExpected float64 // minimum expected count of a category of a chi-square test
+		scale       float64 // scale of the upper 53 bits of a 64-bit random value to [0,1)
+	}{
+		minExpected: 5,       // minimum expected count of a category of a chi-square test
+		scale:       0x1p-53, // scale of the upper 53 bits of a 64-bit random value to [0,1)
+	}
+)
+
+// Result holds the name of a statistical test, the value of the test statistic and the p-value of the statistic.
+type Result struct {
+	Test      string  // name of the test and its parameters
+	Statistic float64 // value of the test statistic
+	P         float64 // p-value of the test statistic
+}
+
+// String returns the name of the test, the statistic and the p-value.
+func (r Result) String() string {
+	return fmt.Sprintf("%s: statistic %.4f, p-value %.4f", r.Test, r.Statistic, r.P)
+}
+
+// checkSource returns an error, if src is nil or not available on the platform.
+func checkSource(src tsrand.Source) error {
+	// Return an error, if src is nil
+	if src == nil {
+		return tserr.NilPtr()
+	}
+	// Call Assert and check if Err returns an error
+	if src.Assert(); src.Err() != nil {
+		return tserr.NotAvailable(&tserr.NotAvailableArgs{S: "Source", Err: src.Err()})
+	}
+	// Return nil
+	return nil
+}
+
+// checkMin returns an error, if the parameter name with value v is lower than min.
+func checkMin(name string, v, min int) error {
+	if v < min {
+		return tserr.Higher(&tserr.HigherArgs{Var: name, Actual: int64(v), LowerBound: int64(min)})
+	}
+	return nil
+}
+
+// checkRange returns an error, if the parameter name with value v is not in [lo,hi].
+func checkRange(name string, v, lo, hi int) error {
+	if v < lo || v > hi {
+		return tserr.Check(&tserr.CheckArgs{F: name, Err: fmt.Errorf("value is %d, but it must be in [%d,%d]", v, lo, hi)})
+	}
+	return nil
+}
+
+// checkExpected returns an error, if the lowest expected count e of a category of a chi-square test with sample size n is lower than minExpected.
+func checkExpected(e float64, n int) error {
+	if e < qualityc.minExpected {
+		return tserr.Check(&tserr.CheckArgs{F: "sample size", Err: fmt.Errorf("value is %d, but the expected count %.2f of a category is lower than %v", n, e, qualityc.minExpected)})
+	}
+	return nil
+}
+
+// uniform returns a random number of src in the half-open interval [0,1) with 53 bits of precision.
+func uniform(src tsrand.Source) float64 {
+	return float64(src.Uint64()>>11) * qualityc.scale
+}
+
+// chiSquare returns the chi-square statistic of counts with the expected probabilities probs for n observations and its p-value.
+// Categories with an expected count lower than minExpected are lumped with their neighbors from both ends. It returns an error, if
+// the lumped categories are fewer than two.
+func chiSquare(counts []int, probs []float64, n int) (float64, float64, error) {
+	// Lump categories with low expected counts from both ends
+	c, p := lump(counts, probs, float64(n))
+	// Return an error, if less than two categories remain
+	if len(c) < 2 {
+		return 0, 0, tserr.Check(&tserr.CheckArgs{F: "categories", Err: fmt.Errorf("%d categories remain after lumping categories with an expected count lower than %v, but at least two are required", len(c), qualityc.minExpected)})
+	}
+	// Chi-square statistic
+	x := 0.0
+	for i := range c {
+		e := p[i] * float64(n)
+		d := float64(c[i]) - e
+		x += d * d / e
+	}
+	// Return statistic and p-value
+	return x, chiSquareP(x, float64(len(c)-1)), nil
+}
+
+// lump returns counts and probabilities, in which categories with an expected count lower than minExpected for n observations are lumped
+// with their neighbors. The categories are lumped from the lower end upwards and from the upper end downwards.
+func lump(counts []int, probs []float64, n float64) ([]int, []float64) {
+	c, p := append([]int{}, counts...), append([]float64{}, probs...)
+	// Lump from the lower end
+	for len(c) > 1 && p[0]*n < qualityc.minExpected {
+		c[1] += c[0]
+		p[1] += p[0]
+		c, p = c[1:], p[1:]
+	}
+	// Lump from the upper end
+	for l := len(c); l > 1 && p[l-1]*n < qualityc.minExpected; l = len(c) {
+		c[l-2] += c[l-1]
+		p[l-2] += p[l-1]
+		c, p = c[:l-1], p[:l-1]
+	}
+	return c, p
+}
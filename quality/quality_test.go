@@ -0,0 +1,159 @@
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package quality
+
+// Import standard library packages, tserr and tsrand
+import (
+	"fmt"     // fmt
+	"testing" // testing
+
+	"github.com/thorstenrie/tserr"  // tserr
+	"github.com/thorstenrie/tsrand" // tsrand
+)
+
+const (
+	minP float64 = 1e-4 // minimum p-value of a good source and maximum p-value of a bad source
+)
+
+// testCounterSource is a poor source returning a Weyl sequence with step 2^52 + 1.
+type testCounterSource struct {
+	v uint64 // last returned value
+}
+
+// Seed sets the last returned value to s.
+func (c *testCounterSource) Seed(s int64) { c.v = uint64(s) }
+
+// Uint64 returns the next value of the Weyl sequence.
+func (c *testCounterSource) Uint64() uint64 { c.v += 1<<52 + 1; return c.v }
+
+// Int63 returns the upper 63 bits of Uint64.
+func (c *testCounterSource) Int63() int64 { return int64(c.Uint64() >> 1) }
+
+// Assert is empty, the source is always available.
+func (c *testCounterSource) Assert() {}
+
+// Err returns nil.
+func (c *testCounterSource) Err() error { return nil }
+
+// testSource returns a seeded PCG64Source for the tests.
+func testSource() tsrand.Source {
+	src := tsrand.NewPCG64Source()
+	src.Seed(1)
+	return src
+}
+
+// testTests returns all tests with valid parameters with their names.
+func testTests() map[string]func(tsrand.Source) (Result, error) {
+	return map[string]func(tsrand.Source) (Result, error){
+		"Frequency":         func(s tsrand.Source) (Result, error) { return Frequency(s, 100000, 64) },
+		"Serial pairs":      func(s tsrand.Source) (Result, error) { return Serial(s, 100000, 16, 2) },
+		"Serial triples":    func(s tsrand.Source) (Result, error) { return Serial(s, 100000, 8, 3) },
+		"Gap":               func(s tsrand.Source) (Result, error) { return Gap(s, 50000, 0, 0.25, 16) },
+		"Poker":             func(s tsrand.Source) (Result, error) { return Poker(s, 50000, 5, 10) },
+		"Runs":              func(s tsrand.Source) (Result, error) { return Runs(s, 100000) },
+		"CouponCollector":   func(s tsrand.Source) (Result, error) { return CouponCollector(s, 20000, 8, 40) },
+		"BirthdaySpacings":  func(s tsrand.Source) (Result, error) { return BirthdaySpacings(s, 100, 512, 24) },
+		"KolmogorovSmirnov": func(s tsrand.Source) (Result, error) { return KolmogorovSmirnov(s, 100000) },
+	}
+}
+
+// TestGoodSource tests that the p-values of each test for good sources are not suspect.
+func TestGoodSource(t *testing.T) {
+	for name, src := range map[string]tsrand.Source{"PCG64Source": testSource(), "MT64Source": tsrand.NewMT64Source()} {
+		for test, f := range testTests() {
+			// The test fails, if the test returns an error
+			r, e := f(src)
+			if e != nil {
+				t.Fatal(tserr.Op(&tserr.OpArgs{Op: test, Fn: name, Err: e}))
+			}
+			// The test fails, if the p-value is suspect
+			if r.P < minP || r.P > 1-minP {
+				t.Error(tserr.Check(&tserr.CheckArgs{F: fmt.Sprintf("%s of %s", test, name), Err: fmt.Errorf("suspect %v", r)}))
+			}
+		}
+	}
+}
+
+// TestBadSource tests that the p-values of the tests for a poor counter source are suspect. The Weyl sequence of the counter source is
+// equidistributed, therefore the Frequency test is not expected to detect it.
+func TestBadSource(t *testing.T) {
+	for test, f := range testTests() {
+		if test == "Frequency" {
+			continue
+		}
+		// The test fails, if the test returns an error
+		r, e := f(&testCounterSource{})
+		if e != nil {
+			t.Fatal(tserr.Op(&tserr.OpArgs{Op: test, Fn: "testCounterSource", Err: e}))
+		}
+		// The test fails, if the p-value is not suspect
+		if r.P >= minP && r.P <= 1-minP {
+			t.Error(tserr.Check(&tserr.CheckArgs{F: test + " of testCounterSource", Err: fmt.Errorf("not suspect %v", r)}))
+		}
+	}
+}
+
+// TestInvalid tests that the tests return an error for a nil source, an unavailable source and invalid parameters.
+func TestInvalid(t *testing.T) {
+	// Nil and unavailable sources
+	for name, src := range map[string]tsrand.Source{"nil": nil, "BufferedCryptoSource": tsrand.NewBufferedCryptoSource(1)} {
+		for test, f := range testTests() {
+			// The test fails, if the test does not return an error
+			if _, e := f(src); e == nil {
+				t.Error(tserr.NilFailed(fmt.Sprintf("%s of %s", test, name)))
+			}
+		}
+	}
+	// Invalid parameters
+	src := testSource()
+	for test, f := range map[string]func() (Result, error){
+		"Frequency d":           func() (Result, error) { return Frequency(src, 1000, 1) },
+		"Frequency n":           func() (Result, error) { return Frequency(src, 100, 64) },
+		"Serial t":              func() (Result, error) { return Serial(src, 1000, 4, 1) },
+		"Serial n":              func() (Result, error) { return Serial(src, 1000, 16, 3) },
+		"Gap interval":          func() (Result, error) { return Gap(src, 1000, 0.5, 0.5, 4) },
+		"Gap beta":              func() (Result, error) { return Gap(src, 1000, 0.5, 1.5, 4) },
+		"Gap n":                 func() (Result, error) { return Gap(src, 10, 0, 0.5, 4) },
+		"Poker k":               func() (Result, error) { return Poker(src, 1000, 1, 10) },
+		"Poker n":               func() (Result, error) { return Poker(src, 1, 5, 10) },
+		"Runs n":                func() (Result, error) { return Runs(src, 10) },
+		"CouponCollector t":     func() (Result, error) { return CouponCollector(src, 1000, 8, 8) },
+		"CouponCollector n":     func() (Result, error) { return CouponCollector(src, 1, 8, 40) },
+		"BirthdaySpacings m":    func() (Result, error) { return BirthdaySpacings(src, 10, 1, 24) },
+		"BirthdaySpacings bits": func() (Result, error) { return BirthdaySpacings(src, 10, 512, 65) },
+		"BirthdaySpacings n":    func() (Result, error) { return BirthdaySpacings(src, 1, 16, 24) },
+		"KolmogorovSmirnov n":   func() (Result, error) { return KolmogorovSmirnov(src, 10) },
+	} {
+		// The test fails, if the test does not return an error
+		if _, e := f(); e == nil {
+			t.Error(tserr.NilFailed(test))
+		}
+	}
+}
+
+// TestChiSquare tests that chiSquare returns an error, if fewer than two categories remain after lumping, and the statistic otherwise.
+func TestChiSquare(t *testing.T) {
+	// The test fails, if chiSquare does not return an error for categories, which are all lumped into one
+	if x, p, e := chiSquare([]int{1, 2, 1}, []float64{0.25, 0.5, 0.25}, 4); e == nil || x != 0 || p != 0 {
+		t.Error(tserr.NilFailed("chiSquare with one lumped category"))
+	}
+	// The test fails, if chiSquare returns an error or an unexpected statistic for two categories
+	x, _, e := chiSquare([]int{40, 60}, []float64{0.5, 0.5}, 100)
+	if e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "chiSquare", Fn: "two categories", Err: e}))
+	}
+	testSpecial(t, "chiSquare statistic", x, 4)
+}
+
+// BenchmarkTests performs a benchmark on each test with a PCG64Source.
+func BenchmarkTests(b *testing.B) {
+	for test, f := range testTests() {
+		b.Run(test, func(b *testing.B) {
+			src := testSource()
+			for i := 0; i < b.N; i++ {
+				f(src)
+			}
+		})
+	}
+}
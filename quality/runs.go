@@ -0,0 +1,41 @@
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package quality
+
+// Import standard library packages and tsrand
+import (
+	"fmt"  // fmt
+	"math" // math
+
+	"github.com/thorstenrie/tsrand" // tsrand
+)
+
+// Runs draws n random numbers in [0,1) from src and tests the number of runs up and down. A run up is a maximal increasing sequence and a run down
+// a maximal decreasing sequence of consecutive random numbers. For independent random numbers, the number of runs R has the mean (2n-1)/3 and
+// the variance (16n-29)/90 and is approximately normally distributed. The statistic is the standardized z-score of R and the p-value is two-sided.
+// It returns an error, if src is not available or n is lower than 20.
+func Runs(src tsrand.Source, n int) (Result, error) {
+	// Check source and parameters
+	if e := checkSource(src); e != nil {
+		return Result{}, e
+	}
+	if e := checkMin("n", n, 20); e != nil {
+		return Result{}, e
+	}
+	// Count the runs, each change of direction starts a new run
+	runs, up := 0, false
+	prev := uniform(src)
+	for i := 1; i < n; i++ {
+		u := uniform(src)
+		if d := u > prev; i == 1 || d != up {
+			runs++
+			up = d
+		}
+		prev = u
+	}
+	// Standardize the number of runs
+	m, v := float64(2*n-1)/3, float64(16*n-29)/90
+	z := (float64(runs) - m) / math.Sqrt(v)
+	return Result{Test: fmt.Sprintf("Runs(n=%d)", n), Statistic: z, P: normalP(z)}, nil
+}
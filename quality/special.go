@@ -0,0 +1,146 @@
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package quality
+
+// Import standard library packages
+import (
+	"math" // math
+)
+
+// specialc holds the constants of the special functions
+var (
+	specialc = struct {
+		eps   float64 // relative precision of the series and continued fraction
+		tiny  float64 // smallest value to avoid a division by zero in the continued fraction
+		itr   int     // maximum number of iterations
+		terms int     // number of terms of the Kolmogorov distribution
+	}{
+		eps:   1e-15,  // relative precision of the series and continued fraction
+		tiny:  1e-300, // smallest value to avoid a division by zero in the continued fraction
+		itr:   10000,  // maximum number of iterations
+		terms: 100,    // number of terms of the Kolmogorov distribution
+	}
+)
+
+// gammaP returns the regularized lower incomplete gamma function P(a,x) for a > 0 and x >= 0.
+func gammaP(a, x float64) float64 {
+	if x <= 0 {
+		return 0
+	}
+	// Series for x < a+1, otherwise continued fraction
+	if x < a+1 {
+		return gammaSeries(a, x)
+	}
+	return 1 - gammaFraction(a, x)
+}
+
+// gammaQ returns the regularized upper incomplete gamma function Q(a,x) = 1 - P(a,x) for a > 0 and x >= 0.
+func gammaQ(a, x float64) float64 {
+	if x <= 0 {
+		return 1
+	}
+	// Series for x < a+1, otherwise continued fraction
+	if x < a+1 {
+		return 1 - gammaSeries(a, x)
+	}
+	return gammaFraction(a, x)
+}
+
+// gammaSeries returns P(a,x) computed with its series representation.
+func gammaSeries(a, x float64) float64 {
+	lg, _ := math.Lgamma(a)
+	sum, term := 1/a, 1/a
+	for n := 1; n < specialc.itr; n++ {
+		term *= x / (a + float64(n))
+		sum += term
+		if math.Abs(term) < math.Abs(sum)*specialc.eps {
+			break
+		}
+	}
+	return sum * math.Exp(-x+a*math.Log(x)-lg)
+}
+
+// gammaFraction returns Q(a,x) computed with its continued fraction representation based on the modified Lentz method.
+func gammaFraction(a, x float64) float64 {
+	lg, _ := math.Lgamma(a)
+	b := x + 1 - a
+	c, d := 1/specialc.tiny, 1/b
+	h := d
+	for i := 1; i < specialc.itr; i++ {
+		an := -float64(i) * (float64(i) - a)
+		b += 2
+		d = an*d + b
+		if math.Abs(d) < specialc.tiny {
+			d = specialc.tiny
+		}
+		c = b + an/c
+		if math.Abs(c) < specialc.tiny {
+			c = specialc.tiny
+		}
+		d = 1 / d
+		del := d * c
+		h *= del
+		if math.Abs(del-1) < specialc.eps {
+			break
+		}
+	}
+	return math.Exp(-x+a*math.Log(x)-lg) * h
+}
+
+// chiSquareP returns the upper tail probability of the chi-square distribution with df degrees of freedom at x.
+func chiSquareP(x, df float64) float64 {
+	return gammaQ(df/2, x/2)
+}
+
+// normalP returns the two-sided tail probability of the standard normal distribution at z.
+func normalP(z float64) float64 {
+	return math.Erfc(math.Abs(z) / math.Sqrt2)
+}
+
+// poissonP returns the upper tail probability P(X >= k) of the Poisson distribution with mean lambda.
+func poissonP(k int, lambda float64) float64 {
+	if k <= 0 {
+		return 1
+	}
+	// P(X >= k) = P(k, lambda) of the regularized lower incomplete gamma function
+	return gammaP(float64(k), lambda)
+}
+
+// kolmogorovP returns the upper tail probability of the Kolmogorov-Smirnov statistic d for sample size n based on the asymptotic Kolmogorov
+// distribution with the correction of Stephens for finite sample sizes.
+func kolmogorovP(d float64, n int) float64 {
+	sn := math.Sqrt(float64(n))
+	l := (sn + 0.12 + 0.11/sn) * d
+	// The series does not converge for small l, where the probability is 1
+	if l < 0.2 {
+		return 1
+	}
+	p := 0.0
+	for k := 1; k <= specialc.terms; k++ {
+		t := math.Exp(-2 * float64(k*k) * l * l)
+		if k%2 == 1 {
+			p += t
+		} else {
+			p -= t
+		}
+		if t < specialc.eps*p {
+			break
+		}
+	}
+	return max(0, min(1, 2*p))
+}
+
+// occupancy returns the probabilities q[r] that k random integers of [0,d) contain exactly r distinct values for r = 0, ..., min(k,d),
+// based on the recurrence q(k,r) = q(k-1,r)*r/d + q(k-1,r-1)*(d-r+1)/d.
+func occupancy(k, d int) []float64 {
+	q := make([]float64, min(k, d)+1)
+	q[0] = 1
+	for i := 1; i <= k; i++ {
+		for r := min(i, d); r >= 1; r-- {
+			q[r] = q[r]*float64(r)/float64(d) + q[r-1]*float64(d-r+1)/float64(d)
+		}
+		q[0] = 0
+	}
+	return q
+}
@@ -0,0 +1,103 @@
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package quality
+
+// Import standard library packages and tserr
+import (
+	"fmt"     // fmt
+	"math"    // math
+	"testing" // testing
+
+	"github.com/thorstenrie/tserr" // tserr
+)
+
+const (
+	maxDiff float64 = 1e-6 // maximum difference of a special function to its reference value
+)
+
+// testSpecial fails, if the value act of the function name differs more than maxDiff from want.
+func testSpecial(t *testing.T, name string, act, want float64) {
+	// Panic if t is nil
+	if t == nil {
+		panic("nil pointer")
+	}
+	if math.Abs(act-want) > maxDiff {
+		t.Error(tserr.Equalf(&tserr.EqualfArgs{Var: name, Actual: act, Want: want}))
+	}
+}
+
+// TestGamma tests the regularized incomplete gamma functions against closed forms and reference values.
+func TestGamma(t *testing.T) {
+	for _, x := range []float64{0.1, 0.5, 1, 2, 5, 10, 30} {
+		// Q(1,x) = exp(-x)
+		testSpecial(t, fmt.Sprintf("gammaQ(1, %v)", x), gammaQ(1, x), math.Exp(-x))
+		// P(1/2,x) = erf(sqrt(x))
+		testSpecial(t, fmt.Sprintf("gammaP(0.5, %v)", x), gammaP(0.5, x), math.Erf(math.Sqrt(x)))
+		// P(a,x) + Q(a,x) = 1
+		testSpecial(t, fmt.Sprintf("gammaP+gammaQ(3.5, %v)", x), gammaP(3.5, x)+gammaQ(3.5, x), 1)
+	}
+	// Critical values of the chi-square distribution
+	testSpecial(t, "chiSquareP(3.841459, 1)", chiSquareP(3.841459, 1), 0.05)
+	testSpecial(t, "chiSquareP(18.307038, 10)", chiSquareP(18.307038, 10), 0.05)
+	testSpecial(t, "chiSquareP(135.806723, 100)", chiSquareP(135.806723, 100), 0.01)
+}
+
+// TestDistributions tests the tail probabilities of the normal, Poisson and Kolmogorov distribution and the occupancy probabilities.
+func TestDistributions(t *testing.T) {
+	// Two-sided critical value of the normal distribution
+	testSpecial(t, "normalP(1.959964)", normalP(1.959964), 0.05)
+	testSpecial(t, "normalP(-2.575829)", normalP(-2.575829), 0.01)
+	// Upper tail of the Poisson distribution compared with the sum of the probability mass function
+	for _, lambda := range []float64{0.5, 4, 20} {
+		pmf, cdf := math.Exp(-lambda), 0.0
+		for k := 0; k < 30; k++ {
+			testSpecial(t, fmt.Sprintf("poissonP(%d, %v)", k, lambda), poissonP(k, lambda), 1-cdf)
+			cdf += pmf
+			pmf *= lambda / float64(k+1)
+		}
+	}
+	// Critical values of the asymptotic Kolmogorov distribution with the correction of Stephens
+	n := 100
+	sn := math.Sqrt(float64(n)) + 0.12 + 0.11/math.Sqrt(float64(n))
+	testSpecial(t, "kolmogorovP(1.358099)", kolmogorovP(1.358099/sn, n), 0.05)
+	testSpecial(t, "kolmogorovP(1.627624)", kolmogorovP(1.627624/sn, n), 0.01)
+	testSpecial(t, "kolmogorovP(0)", kolmogorovP(0, n), 1)
+	// Occupancy probabilities sum to 1 and match the closed form for k = 2
+	for _, d := range []int{2, 10, 64} {
+		for _, k := range []int{2, 5, 40} {
+			q, sum := occupancy(k, d), 0.0
+			for _, p := range q {
+				sum += p
+			}
+			testSpecial(t, fmt.Sprintf("sum of occupancy(%d, %d)", k, d), sum, 1)
+		}
+		testSpecial(t, fmt.Sprintf("occupancy(2, %d)[1]", d), occupancy(2, d)[1], 1/float64(d))
+	}
+}
+
+// TestBatteryDistributions tests the Anderson-Darling distribution against critical values, the distribution of the ranks of binary matrices
+// and the binomial distribution.
+func TestBatteryDistributions(t *testing.T) {
+	// Critical values of the asymptotic Anderson-Darling distribution with the precision of the approximation
+	for z, want := range map[float64]float64{1.933: 0.9, 2.492: 0.95, 3.857: 0.99} {
+		if p := adCDF(z); math.Abs(p-want) > 1e-3 {
+			t.Error(tserr.Equalf(&tserr.EqualfArgs{Var: fmt.Sprintf("adCDF(%v)", z), Actual: p, Want: want}))
+		}
+	}
+	// Probabilities of the ranks and the binomial probabilities sum to 1
+	for _, lk := range [][2]int{{32, 32}, {60, 60}, {6, 8}} {
+		sum := 0.0
+		for r := 0; r <= min(lk[0], lk[1]); r++ {
+			sum += rankP(r, lk[0], lk[1])
+		}
+		testSpecial(t, fmt.Sprintf("sum of rankP of %v", lk), sum, 1)
+	}
+	sum := 0.0
+	for _, p := range binomial(300, 0.5) {
+		sum += p
+	}
+	testSpecial(t, "sum of binomial(300, 0.5)", sum, 1)
+	// Full rank probability of 32 x 32 matrices
+	testSpecial(t, "rankP(32, 32, 32)", rankP(32, 32, 32), 0.288788)
+}
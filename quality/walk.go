@@ -0,0 +1,127 @@
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package quality
+
+// Import standard library packages, tserr and tsrand
+import (
+	"fmt"  // fmt
+	"math" // math
+
+	"github.com/thorstenrie/tserr"  // tserr
+	"github.com/thorstenrie/tsrand" // tsrand
+)
+
+// RandomWalk performs n random walks of l steps with the s most significant bits of the random 64-bit values of src. A bit 1 is a step up and
+// a bit 0 a step down. It tests five statistics of the walks with a chi-square test:
+//
+// - H: the final position of the walk
+// - M: the maximum position of the walk
+// - J: the number of steps on the positive side
+// - R: the number of returns to the origin
+// - C: the number of sign changes
+//
+// The exact distributions of the statistics are based on Feller, An Introduction to Probability Theory and Its Applications, Vol. 1, Chapter III.
+// Values with an expected count lower than 5 are lumped together. It returns an error, if src is not available, s is not in [1,64], l is odd or lower
+// than 2 or less than two values of a statistic remain after lumping.
+func RandomWalk(src tsrand.Source, n, s, l int) ([]Result, error) {
+	// Check source and parameters
+	if e := checkSource(src); e != nil {
+		return nil, e
+	}
+	if e := checkRange("s", s, 1, 64); e != nil {
+		return nil, e
+	}
+	if e := checkMin("l", l, 2); e != nil {
+		return nil, e
+	}
+	if l%2 != 0 {
+		return nil, tserr.Check(&tserr.CheckArgs{F: "l", Err: fmt.Errorf("value is %d, but it must be even", l)})
+	}
+	// Count the statistics of the walks
+	probs := walkProbs(l)
+	counts := make([][]int, len(probs))
+	for i := range counts {
+		counts[i] = make([]int, len(probs[i]))
+	}
+	b := newBitStream(src, s)
+	for i := 0; i < n; i++ {
+		for j, v := range walkStats(b, l) {
+			counts[j][v]++
+		}
+	}
+	// Chi-square test of each statistic
+	res := make([]Result, len(probs))
+	for i, name := range []string{"H", "M", "J", "R", "C"} {
+		x, p, e := chiSquare(counts[i], probs[i], n)
+		if e != nil {
+			return nil, e
+		}
+		res[i] = Result{Test: fmt.Sprintf("RandomWalk(n=%d, s=%d, l=%d) %s", n, s, l, name), Statistic: x, P: p}
+	}
+	return res, nil
+}
+
+// walkStats performs a random walk of l steps with the bits of b and returns the indices of the statistics H, M, J, R and C: the number of steps up,
+// the maximum position, half the number of steps on the positive side, the number of returns to the origin and the number of sign changes.
+func walkStats(b *bitStream, l int) [5]int {
+	pos, up, maxPos, positive, returns, changes, last := 0, 0, 0, 0, 0, 0, 0
+	for i := 0; i < l; i++ {
+		prev := pos
+		if b.read(1) == 1 {
+			pos++
+			up++
+		} else {
+			pos--
+		}
+		maxPos = max(maxPos, pos)
+		// A step is on the positive side, if it starts or ends above the origin
+		if prev > 0 || pos > 0 {
+			positive++
+		}
+		if pos == 0 {
+			returns++
+		} else {
+			// The sign changes, if the walk crosses the origin since the last non-zero position
+			if last != 0 && (last > 0) != (pos > 0) {
+				changes++
+			}
+			last = pos
+		}
+	}
+	return [5]int{up, maxPos, positive / 2, returns, changes}
+}
+
+// walkProbs returns the probabilities of the indices of the statistics H, M, J, R and C of a random walk of l steps.
+func walkProbs(l int) [][]float64 {
+	h, m, j, r, c := binomial(l, 0.5), make([]float64, l+1), make([]float64, l/2+1), make([]float64, l/2+1), make([]float64, l/2)
+	// The maximum is m with the probability P(S_l = m) + P(S_l = m+1)
+	for i := range m {
+		m[i] = walkP(l, i) + walkP(l, i+1)
+	}
+	// Discrete arc sine law of the time on the positive side
+	for i := range j {
+		j[i] = walkP(2*i, 0) * walkP(l-2*i, 0)
+	}
+	// Probability of i returns to the origin is P(S_{l-i} = i)
+	for i := range r {
+		r[i] = walkP(l-i, i)
+	}
+	// Probability of i sign changes is 2 P(S_{l-1} = 2i+1)
+	for i := range c {
+		c[i] = 2 * walkP(l-1, 2*i+1)
+	}
+	return [][]float64{h, m, j, r, c}
+}
+
+// walkP returns the probability P(S_k = x) that a random walk is at position x after k steps.
+func walkP(k, x int) float64 {
+	if x < -k || x > k || (k+x)%2 != 0 {
+		return 0
+	}
+	u := (k + x) / 2
+	lk, _ := math.Lgamma(float64(k + 1))
+	lu, _ := math.Lgamma(float64(u + 1))
+	lv, _ := math.Lgamma(float64(k - u + 1))
+	return math.Exp(lk - lu - lv - float64(k)*math.Ln2)
+}
@@ -0,0 +1,56 @@
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package quality
+
+// Import standard library packages, tserr and tsrand
+import (
+	"fmt"  // fmt
+	"math" // math
+
+	"github.com/thorstenrie/tserr"  // tserr
+	"github.com/thorstenrie/tsrand" // tsrand
+)
+
+// WeightDistribution draws n groups of k random numbers in [0,1) from src and counts the random numbers of each group in the interval [alpha,beta).
+// The counts are binomially distributed with k trials and the probability beta-alpha and are tested with a chi-square test. Counts with an expected
+// number lower than 5 are lumped together. It returns an error, if src is not available, the interval is empty, not in [0,1] or [0,1] itself, k is lower than 1 or
+// less than two classes remain after lumping.
+func WeightDistribution(src tsrand.Source, n, k int, alpha, beta float64) (Result, error) {
+	// Check source and parameters
+	if e := checkSource(src); e != nil {
+		return Result{}, e
+	}
+	if !(alpha >= 0 && alpha < beta && beta <= 1 && beta-alpha < 1) {
+		return Result{}, tserr.Check(&tserr.CheckArgs{F: "interval", Err: fmt.Errorf("[%v,%v) is not a non-empty proper subinterval of [0,1]", alpha, beta)})
+	}
+	if e := checkMin("k", k, 1); e != nil {
+		return Result{}, e
+	}
+	// Count the random numbers in the interval for each group
+	counts := make([]int, k+1)
+	for i := 0; i < n; i++ {
+		w := 0
+		for j := 0; j < k; j++ {
+			if u := uniform(src); u >= alpha && u < beta {
+				w++
+			}
+		}
+		counts[w]++
+	}
+	// Chi-square test with binomial probabilities
+	x, p, e := chiSquare(counts, binomial(k, beta-alpha), n)
+	return Result{Test: fmt.Sprintf("WeightDistribution(n=%d, k=%d, alpha=%v, beta=%v)", n, k, alpha, beta), Statistic: x, P: p}, e
+}
+
+// binomial returns the probabilities of 0, ..., k successes of the binomial distribution with k trials and the success probability p.
+func binomial(k int, p float64) []float64 {
+	probs := make([]float64, k+1)
+	lk, _ := math.Lgamma(float64(k + 1))
+	for i := range probs {
+		li, _ := math.Lgamma(float64(i + 1))
+		lj, _ := math.Lgamma(float64(k - i + 1))
+		probs[i] = math.Exp(lk - li - lj + float64(i)*math.Log(p) + float64(k-i)*math.Log1p(-p))
+	}
+	return probs
+}
@@ -0,0 +1,171 @@
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package quasirandom
+
+// Import standard library package math/bits and tsrand
+import (
+	"math/bits" // math/bits
+
+	"github.com/thorstenrie/tsrand" // tsrand
+)
+
+// haltonc holds the constants of the Halton sequence
+var (
+	haltonc = struct {
+		eps float64 // precision of the scrambled digits
+		max float64 // largest float64 lower than 1
+		phi uint64  // 64-bit fraction of the golden ratio to separate the digit levels
+	}{
+		eps: 0x1p-53,            // precision of the scrambled digits
+		max: 1 - 0x1p-53,        // largest float64 lower than 1
+		phi: 0x9e3779b97f4a7c15, // 64-bit fraction of the golden ratio to separate the digit levels
+	}
+)
+
+// Halton provides the Halton sequence. Coordinate j of the point with index n is the radical inverse of n in the j-th prime base:
+// the digits of n in the base are mirrored at the radix point. The first b^m points of a dimension with base b are stratified: each
+// interval [k/b^m,(k+1)/b^m) contains exactly one coordinate. Halton holds the prime bases, the index of the next point and the seeds
+// of the scrambling, if scrambled.
+type Halton struct {
+	base []uint64 // prime base of each dimension
+	n    uint64   // index of the next point
+	seed []uint64 // seeds of the scrambling of each dimension, nil if not scrambled
+}
+
+// NewHalton returns a new instance of Halton with dimension dim. It returns an error, if dim is lower than 1.
+func NewHalton(dim int) (*Halton, error) {
+	// Return an error, if dim is invalid
+	if e := checkDim(dim); e != nil {
+		return nil, e
+	}
+	// Return Halton with the first dim primes as bases
+	return &Halton{base: primes(dim)}, nil
+}
+
+// primes returns the first n prime numbers.
+func primes(n int) []uint64 {
+	p := make([]uint64, 0, n)
+	for c := uint64(2); len(p) < n; c++ {
+		prime := true
+		for _, q := range p {
+			if q*q > c {
+				break
+			}
+			if c%q == 0 {
+				prime = false
+				break
+			}
+		}
+		if prime {
+			p = append(p, c)
+		}
+	}
+	return p
+}
+
+// Dim returns the dimension of the points.
+func (h *Halton) Dim() int {
+	return len(h.base)
+}
+
+// Index returns the index of the next point.
+func (h *Halton) Index() uint64 {
+	return h.n
+}
+
+// Next writes the next point into p. It returns an error, if the length of p does not equal Dim or the sequence is exhausted after 2^64-1 points.
+func (h *Halton) Next(p []float64) error {
+	// Return an error, if p is invalid
+	if e := checkPoint(p, len(h.base)); e != nil {
+		return e
+	}
+	// Return an error, if the sequence is exhausted
+	if e := checkIndex("Halton", h.n, 1); e != nil {
+		return e
+	}
+	// Write the radical inverse of each base, scrambled, if seeds are set
+	for j, b := range h.base {
+		if h.seed != nil {
+			p[j] = scrambledInverse(h.n, b, h.seed[j])
+		} else {
+			p[j] = radicalInverse(h.n, b)
+		}
+	}
+	h.n++
+	return nil
+}
+
+// Skip skips the next n points. It returns an error, if the sequence would be exhausted after 2^64-1 points. In this case, no point is skipped.
+func (h *Halton) Skip(n uint64) error {
+	// Return an error, if the sequence would be exhausted
+	if e := checkIndex("Halton", h.n, n); e != nil {
+		return e
+	}
+	h.n += n
+	return nil
+}
+
+// Scramble scrambles the sequence with nested uniform scrambling of Owen: the digit at each level is permuted by a uniformly random permutation
+// of the digits of the base, which depends on the seed of the dimension drawn from src, the level and all higher digits. The permutations are
+// generated by a Fisher-Yates shuffle driven by a hash of the seed, the level and the higher digits. Therefore, the cost of a scrambled
+// coordinate grows linearly with its base. The scrambled points keep the stratification of the sequence. It returns an error, if src is nil or not available.
+func (h *Halton) Scramble(src tsrand.Source) error {
+	// Return an error, if src is invalid
+	if e := checkSource(src); e != nil {
+		return e
+	}
+	// Draw the seed of each dimension
+	h.seed = make([]uint64, len(h.base))
+	for j := range h.seed {
+		h.seed[j] = src.Uint64()
+	}
+	return nil
+}
+
+// radicalInverse returns the radical inverse of n in base b.
+func radicalInverse(n, b uint64) float64 {
+	r, f := 0.0, 1/float64(b)
+	for ; n > 0; n /= b {
+		r += float64(n%b) * f
+		f /= float64(b)
+	}
+	// Rounding of the sum may result in 1 for indices with many digits
+	return min(r, haltonc.max)
+}
+
+// scrambledInverse returns the radical inverse of n in base b with digits scrambled by seed. The digits are scrambled up to the precision
+// of float64, since the leading zero digits of n are scrambled as well.
+func scrambledInverse(n, b, seed uint64) float64 {
+	r, f := 0.0, 1/float64(b)
+	// prefix holds the higher digits of the radical inverse, which are the lower digits of n
+	prefix, pow := uint64(0), uint64(1)
+	for l := uint64(0); f > haltonc.eps; l++ {
+		d := n % b
+		n /= b
+		// Permute the digit by the permutation of the node given by the seed, the level and the prefix
+		r += float64(permute(d, b, mix(mix(seed+l*haltonc.phi)^prefix))) * f
+		prefix += d * pow
+		pow *= b
+		f /= float64(b)
+	}
+	return min(r, haltonc.max)
+}
+
+// permute returns the image of digit d under a random permutation of the digits 0 to b-1 defined by the hash h. The permutation is generated
+// by a Fisher-Yates shuffle of the digits with random numbers derived from h. The image of d is its position after the shuffle, which is
+// tracked through the swaps without storing the permutation.
+func permute(d, b, h uint64) uint64 {
+	for i := b - 1; i > 0; i-- {
+		// Random index j in [0,i] by multiplication of a random 64-bit value with i+1
+		j, _ := bits.Mul64(mix(h+i*haltonc.phi), i+1)
+		// Swap positions i and j
+		switch d {
+		case i:
+			d = j
+		case j:
+			d = i
+		}
+	}
+	return d
+}
@@ -0,0 +1,100 @@
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package quasirandom
+
+// Import standard library packages and tserr
+import (
+	"fmt"     // fmt
+	"math"    // math
+	"testing" // testing
+
+	"github.com/thorstenrie/tserr" // tserr
+)
+
+// TestHaltonPoints tests the first five points of the Halton sequence with dimension 2.
+func TestHaltonPoints(t *testing.T) {
+	want := [][]float64{{0, 0}, {1.0 / 2, 1.0 / 3}, {1.0 / 4, 2.0 / 3}, {3.0 / 4, 1.0 / 9}, {1.0 / 8, 4.0 / 9}}
+	// The test fails, if NewHalton returns an error
+	h, e := NewHalton(2)
+	if e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "NewHalton", Fn: "dim", Err: e}))
+	}
+	p := make([]float64, 2)
+	for i, w := range want {
+		h.Next(p)
+		// The test fails, if a coordinate does not equal the expected value
+		for j := range w {
+			if math.Abs(p[j]-w[j]) > 1e-15 {
+				t.Error(tserr.Equalf(&tserr.EqualfArgs{Var: fmt.Sprintf("coordinate %d of point %d", j, i), Actual: p[j], Want: w[j]}))
+			}
+		}
+	}
+	// The test fails, if the radical inverse of the largest index is not lower than 1
+	if v := radicalInverse(math.MaxUint64, 2); v >= 1 {
+		t.Error(tserr.Equalf(&tserr.EqualfArgs{Var: "radical inverse of the largest index", Actual: v, Want: haltonc.max}))
+	}
+}
+
+// TestHaltonStratified tests that each dimension of the unscrambled and scrambled Halton sequence is stratified in its base.
+func TestHaltonStratified(t *testing.T) {
+	for _, scrambled := range []bool{false, true} {
+		for j, m := range []int{11, 7, 5, 4, 3} {
+			// The test fails, if NewHalton returns an error
+			h, e := NewHalton(testDim)
+			if e != nil {
+				t.Fatal(tserr.Op(&tserr.OpArgs{Op: "NewHalton", Fn: "dim", Err: e}))
+			}
+			if scrambled {
+				h.Scramble(testSource())
+			}
+			testStratified(t, fmt.Sprintf("Halton scrambled %v", scrambled), h, j, int(h.base[j]), m)
+		}
+	}
+}
+
+// TestHaltonPermutations tests that the scrambling of each node is a permutation of the digits and that all permutations in base 3 occur
+// with equal frequency. A random digit shift would only result in the three cyclic permutations.
+func TestHaltonPermutations(t *testing.T) {
+	// Number of nodes and frequency of each permutation of the digits in base 3
+	n, freq := 60000, make(map[string]int)
+	for i := 0; i < n; i++ {
+		for _, b := range []uint64{3, 7} {
+			// Images of all digits of the node
+			p, seen := make([]uint64, b), make([]bool, b)
+			for d := range p {
+				p[d] = permute(uint64(d), b, mix(uint64(i)))
+				// The test fails, if the scrambling of the node is not a permutation
+				if p[d] >= b || seen[p[d]] {
+					t.Fatal(tserr.Check(&tserr.CheckArgs{F: fmt.Sprintf("scrambling of node %d in base %d", i, b), Err: fmt.Errorf("%v is not a permutation", p)}))
+				}
+				seen[p[d]] = true
+			}
+			if b == 3 {
+				freq[fmt.Sprint(p)]++
+			}
+		}
+	}
+	// The test fails, if not all six permutations occur with a frequency of about 1/6, the tolerance is about five standard deviations
+	if len(freq) != 6 {
+		t.Error(tserr.Equal(&tserr.EqualArgs{Var: "number of permutations in base 3", Actual: int64(len(freq)), Want: 6}))
+	}
+	for p, f := range freq {
+		if math.Abs(float64(f)-float64(n)/6) > 5*math.Sqrt(float64(n)*5/36) {
+			t.Error(tserr.Equal(&tserr.EqualArgs{Var: "frequency of permutation " + p, Actual: int64(f), Want: int64(n / 6)}))
+		}
+	}
+}
+
+// TestPrimes tests the first ten prime numbers and the 1000th prime number.
+func TestPrimes(t *testing.T) {
+	p := primes(1000)
+	// The test fails, if the first ten prime numbers do not match
+	if w := []uint64{2, 3, 5, 7, 11, 13, 17, 19, 23, 29}; fmt.Sprint(p[:10]) != fmt.Sprint(w) {
+		t.Error(tserr.NotEqualStr(&tserr.NotEqualStrArgs{X: fmt.Sprint(p[:10]), Y: fmt.Sprint(w)}))
+	}
+	// The test fails, if the 1000th prime number is not 7919
+	if p[999] != 7919 {
+		t.Error(tserr.Equal(&tserr.EqualArgs{Var: "1000th prime", Actual: int64(p[999]), Want: 7919}))
+	}
+}
@@ -0,0 +1,92 @@
+// Package quasirandom provides quasi-random (low-discrepancy) sequences for quasi-Monte Carlo integration.
+//
+// In contrast to pseudo-random numbers, the points of a low-discrepancy sequence cover the unit hypercube [0,1)^d
+// evenly. Therefore, the error of quasi-Monte Carlo integration decreases almost with 1/n instead of 1/sqrt(n) for n points.
+//
+// - Sobol provides the Sobol sequence in base 2 with the direction numbers of Joe and Kuo
+// - Halton provides the Halton sequence with the radical inverses in the first d prime bases
+// - RSequence provides the additive recurrence sequence of Roberts based on the generalized golden ratio
+//
+// Each sequence implements Sequence. Next writes the next point into a slice of length Dim and Skip skips points without
+// generating them. Each sequence starts with index 0. Scramble randomizes a sequence driven by a tsrand Source: Sobol and Halton
+// are scrambled with nested scrambling of Owen, which preserves their stratification, and RSequence is randomized with a random shift.
+// A randomized sequence enables error estimates with independent replications.
+//
+// The sequences are not safe for concurrent use by multiple goroutines.
+//
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package quasirandom
+
+// Import standard library packages, tserr and tsrand
+import (
+	"fmt"  // fmt
+	"math" // math
+
+	"github.com/thorstenrie/tserr"  // tserr
+	"github.com/thorstenrie/tsrand" // tsrand
+)
+
+// Sequence is implemented by each quasi-random sequence. Dim returns the dimension of the points. Next writes the next point into p,
+// which must have length Dim, and returns an error, if the length of p does not match or the sequence is exhausted. Skip skips the
+// next n points and returns an error, if the sequence is exhausted. Index returns the index of the next point. Scramble randomizes
+// the sequence with random numbers of src and returns an error, if src is nil or not available.
+type Sequence interface {
+	Dim() int
+	Next(p []float64) error
+	Skip(n uint64) error
+	Index() uint64
+	Scramble(src tsrand.Source) error
+}
+
+// checkSource returns an error, if src is nil or not available on the platform.
+func checkSource(src tsrand.Source) error {
+	// Return an error, if src is nil
+	if src == nil {
+		return tserr.NilPtr()
+	}
+	// Call Assert and check if Err returns an error
+	if src.Assert(); src.Err() != nil {
+		return tserr.NotAvailable(&tserr.NotAvailableArgs{S: "Source", Err: src.Err()})
+	}
+	// Return nil
+	return nil
+}
+
+// checkDim returns an error, if dim is lower than 1.
+func checkDim(dim int) error {
+	if dim < 1 {
+		return tserr.Higher(&tserr.HigherArgs{Var: "dimension", Actual: int64(dim), LowerBound: 1})
+	}
+	return nil
+}
+
+// checkPoint returns an error, if the length of p does not equal dim.
+func checkPoint(p []float64, dim int) error {
+	if len(p) != dim {
+		return tserr.Equal(&tserr.EqualArgs{Var: "length of p", Actual: int64(len(p)), Want: int64(dim)})
+	}
+	return nil
+}
+
+// checkIndex returns an error, if n more points of the sequence name would exceed the index range of uint64, where i is the index
+// of the next point. The sequence provides at most 2^64-1 points, so that the index of the next point does not overflow.
+func checkIndex(name string, i, n uint64) error {
+	if n > math.MaxUint64-i {
+		return tserr.Forbidden(fmt.Sprintf("more than %d points of %s", uint64(math.MaxUint64), name))
+	}
+	return nil
+}
+
+// errParam returns an error for the parameter name with the invalid value v, which is expected to be want.
+func errParam(name string, v any, want string) error {
+	return tserr.Check(&tserr.CheckArgs{F: "parameter " + name, Err: fmt.Errorf("value is %v, but expected to be %v", v, want)})
+}
+
+// mix returns x mixed by the output function of splitmix64.
+func mix(x uint64) uint64 {
+	x = (x ^ (x >> 30)) * 0xbf58476d1ce4e5b9
+	x = (x ^ (x >> 27)) * 0x94d049bb133111eb
+	return x ^ (x >> 31)
+}
@@ -0,0 +1,210 @@
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package quasirandom
+
+// Import standard library packages, tserr and tsrand
+import (
+	"fmt"     // fmt
+	"math"    // math
+	"testing" // testing
+
+	"github.com/thorstenrie/tserr"  // tserr
+	"github.com/thorstenrie/tsrand" // tsrand
+)
+
+const (
+	testDim    int     = 5     // dimension of the sequences in the tests
+	testPoints int     = 4096  // number of points of the integration tests
+	maxErr     float64 = 0.005 // maximum error of the quasi-Monte Carlo integration
+)
+
+// Sequence is implemented by each quasi-random sequence
+var (
+	_ Sequence = (*Sobol)(nil)
+	_ Sequence = (*Halton)(nil)
+	_ Sequence = (*RSequence)(nil)
+)
+
+// testSource returns a seeded PCG64Source for the tests.
+func testSource() tsrand.Source {
+	src := tsrand.NewPCG64Source()
+	src.Seed(1)
+	return src
+}
+
+// testSequences returns new instances of all sequences with dimension dim, unscrambled and scrambled, with their names.
+func testSequences(t testing.TB, dim int) map[string]Sequence {
+	// Panic if t is nil
+	if t == nil {
+		panic("nil pointer")
+	}
+	seqs := make(map[string]Sequence)
+	for _, scrambled := range []bool{false, true} {
+		for name, f := range map[string]func(int) (Sequence, error){
+			"Sobol":     func(d int) (Sequence, error) { return NewSobol(d) },
+			"Halton":    func(d int) (Sequence, error) { return NewHalton(d) },
+			"RSequence": func(d int) (Sequence, error) { return NewRSequence(d) },
+		} {
+			// The test fails, if the constructor returns an error
+			s, e := f(dim)
+			if e != nil {
+				t.Fatal(tserr.Op(&tserr.OpArgs{Op: "New" + name, Fn: "dim", Err: e}))
+			}
+			// Scramble the sequence
+			if scrambled {
+				if e := s.Scramble(testSource()); e != nil {
+					t.Fatal(tserr.Op(&tserr.OpArgs{Op: "Scramble", Fn: name, Err: e}))
+				}
+				name = "scrambled " + name
+			}
+			seqs[name] = s
+		}
+	}
+	return seqs
+}
+
+// testStratified tests that the coordinates of dimension j of the next b^m points of s are stratified: each interval [k/b^m,(k+1)/b^m)
+// contains exactly one coordinate.
+func testStratified(t *testing.T, name string, s Sequence, j int, b, m int) {
+	// Panic if t is nil
+	if t == nil {
+		panic("nil pointer")
+	}
+	n := int(math.Pow(float64(b), float64(m)))
+	seen := make([]bool, n)
+	p := make([]float64, s.Dim())
+	for i := 0; i < n; i++ {
+		// The test fails, if Next returns an error
+		if e := s.Next(p); e != nil {
+			t.Fatal(tserr.Op(&tserr.OpArgs{Op: "Next", Fn: name, Err: e}))
+		}
+		// Interval of the coordinate, a small tolerance compensates rounding of the radical inverse at the lower bound of an interval
+		k := min(int(p[j]*float64(n)+1e-9), n-1)
+		// The test fails, if an interval contains two coordinates
+		if seen[k] {
+			t.Fatal(tserr.Forbidden(fmt.Sprintf("%s: second coordinate of dimension %d in interval %d of %d", name, j, k, n)))
+		}
+		seen[k] = true
+	}
+}
+
+// TestSequence tests for each sequence that the points are in [0,1), Next returns an error for a slice of invalid length, Skip results in the same
+// points as Next, Index returns the index of the next point and Scramble returns an error for a nil source.
+func TestSequence(t *testing.T) {
+	for name, s := range testSequences(t, testDim) {
+		p := make([]float64, testDim)
+		// The test fails, if Next does not return an error for an invalid length of p
+		if e := s.Next(make([]float64, testDim+1)); e == nil {
+			t.Error(tserr.NilFailed(name + " Next with invalid length"))
+		}
+		// Points of Next
+		pts := make([][]float64, 100)
+		for i := range pts {
+			s.Next(p)
+			pts[i] = append([]float64{}, p...)
+			// The test fails, if a coordinate is not in [0,1)
+			for _, x := range p {
+				if x < 0 || x >= 1 {
+					t.Fatal(tserr.Return(&tserr.ReturnArgs{Op: name + " Next", Actual: fmt.Sprint(x), Want: "value in [0,1)"}))
+				}
+			}
+		}
+		// The test fails, if Index does not return the number of points
+		if s.Index() != uint64(len(pts)) {
+			t.Error(tserr.Equal(&tserr.EqualArgs{Var: name + " Index", Actual: int64(s.Index()), Want: int64(len(pts))}))
+		}
+		// Skip to the points with index 37 and 38, the test fails, if they do not equal the points of Next
+		seqs := testSequences(t, testDim)
+		for _, k := range []uint64{37, 0} {
+			if e := seqs[name].Skip(k); e != nil {
+				t.Fatal(tserr.Op(&tserr.OpArgs{Op: "Skip", Fn: name, Err: e}))
+			}
+			seqs[name].Next(p)
+			for j := range p {
+				if i := seqs[name].Index() - 1; p[j] != pts[i][j] {
+					t.Error(tserr.Equalf(&tserr.EqualfArgs{Var: fmt.Sprintf("%s coordinate %d of point %d", name, j, i), Actual: p[j], Want: pts[i][j]}))
+				}
+			}
+		}
+		// The test fails, if Scramble does not return an error for nil
+		if e := s.Scramble(nil); e == nil {
+			t.Error(tserr.NilFailed(name + " Scramble with nil"))
+		}
+	}
+}
+
+// TestSequenceLimits tests that Halton and RSequence are exhausted after 2^64-1 points and Skip does not overflow the index.
+func TestSequenceLimits(t *testing.T) {
+	h, _ := NewHalton(2)
+	r, _ := NewRSequence(2)
+	p := make([]float64, 2)
+	for name, s := range map[string]Sequence{"Halton": h, "RSequence": r} {
+		// The test fails, if Skip does not return an error for an overflow of the index or skips points
+		s.Skip(1)
+		if e := s.Skip(math.MaxUint64); e == nil || s.Index() != 1 {
+			t.Error(tserr.NilFailed(name + " Skip with overflow"))
+		}
+		// The test fails, if Skip or Next of the last point return an error
+		if e := s.Skip(math.MaxUint64 - 2); e != nil {
+			t.Fatal(tserr.Op(&tserr.OpArgs{Op: "Skip", Fn: name, Err: e}))
+		}
+		if e := s.Next(p); e != nil {
+			t.Fatal(tserr.Op(&tserr.OpArgs{Op: "Next", Fn: name, Err: e}))
+		}
+		// The test fails, if Next and Skip do not return an error after the last point
+		if e := s.Next(p); e == nil || s.Index() != math.MaxUint64 {
+			t.Error(tserr.NilFailed("Next of exhausted " + name))
+		}
+		if e := s.Skip(1); e == nil {
+			t.Error(tserr.NilFailed("Skip of exhausted " + name))
+		}
+	}
+}
+
+// TestDim tests that the constructors return an error for an invalid dimension.
+func TestDim(t *testing.T) {
+	for name, f := range map[string]func(int) error{
+		"NewSobol":     func(d int) error { _, e := NewSobol(d); return e },
+		"NewHalton":    func(d int) error { _, e := NewHalton(d); return e },
+		"NewRSequence": func(d int) error { _, e := NewRSequence(d); return e },
+	} {
+		// The test fails, if the constructor does not return an error
+		if e := f(0); e == nil {
+			t.Error(tserr.NilFailed(name + " with dimension 0"))
+		}
+	}
+}
+
+// TestIntegrate tests that the quasi-Monte Carlo integration of the product of 1+(x_j-1/2) over [0,1)^testDim with each sequence is more accurate
+// than maxErr, which is about half of the standard error of Monte Carlo integration with the same number of pseudo-random points.
+func TestIntegrate(t *testing.T) {
+	for name, s := range testSequences(t, testDim) {
+		p := make([]float64, testDim)
+		sum := 0.0
+		for i := 0; i < testPoints; i++ {
+			s.Next(p)
+			f := 1.0
+			for _, x := range p {
+				f *= 1 + (x - 0.5)
+			}
+			sum += f
+		}
+		// The test fails, if the integral does not equal 1 with a maximum error of maxErr
+		if v := sum / float64(testPoints); math.Abs(v-1) > maxErr {
+			t.Error(tserr.Equalf(&tserr.EqualfArgs{Var: name + " integral", Actual: v, Want: 1}))
+		}
+	}
+}
+
+// BenchmarkNext performs a benchmark on Next of each sequence with dimension 10.
+func BenchmarkNext(b *testing.B) {
+	for name, s := range testSequences(b, 10) {
+		p := make([]float64, 10)
+		b.Run(name, func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				s.Next(p)
+			}
+		})
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package quasirandom
+
+// Import standard library packages and tsrand
+import (
+	"math" // math
+
+	"github.com/thorstenrie/tsrand" // tsrand
+)
+
+// rsequencec holds the constants of the R-sequence
+var (
+	rsequencec = struct {
+		shift uint64  // default shift 1/2 as 64-bit fraction
+		scale float64 // scale of the upper 53 bits of a 64-bit fraction to [0,1)
+		itr   int     // number of iterations of the Newton method
+	}{
+		shift: 1 << 63, // default shift 1/2 as 64-bit fraction
+		scale: 0x1p-53, // scale of the upper 53 bits of a 64-bit fraction to [0,1)
+		itr:   64,      // number of iterations of the Newton method
+	}
+)
+
+// RSequence provides the additive recurrence sequence of Roberts, also known as R2 sequence for two dimensions. Coordinate j of the point
+// with index n is the fractional part of s_j + n * alpha_j with alpha_j = phi^-(j+1), where phi is the generalized golden ratio, the unique
+// positive root of x^(d+1) = x + 1 for dimension d. The fractional parts are computed exactly in 64-bit fixed-point arithmetic.
+// The shift s_j is 1/2 as recommended by Roberts or random, if scrambled. RSequence holds the increments, the shifts and the index of the next point.
+type RSequence struct {
+	alpha []uint64 // increment of each dimension as 64-bit fraction
+	shift []uint64 // shift of each dimension as 64-bit fraction
+	n     uint64   // index of the next point
+}
+
+// NewRSequence returns a new instance of RSequence with dimension dim. It returns an error, if dim is lower than 1.
+func NewRSequence(dim int) (*RSequence, error) {
+	// Return an error, if dim is invalid
+	if e := checkDim(dim); e != nil {
+		return nil, e
+	}
+	// Increments phi^-(j+1) and default shifts
+	r := &RSequence{alpha: make([]uint64, dim), shift: make([]uint64, dim)}
+	g := goldenRatio(dim)
+	for j := range r.alpha {
+		r.alpha[j] = uint64(math.Ldexp(math.Pow(g, -float64(j+1)), 64))
+		r.shift[j] = rsequencec.shift
+	}
+	// Return RSequence
+	return r, nil
+}
+
+// goldenRatio returns the generalized golden ratio of dimension d, which is the unique positive root of x^(d+1) = x + 1, computed with the Newton method.
+func goldenRatio(d int) float64 {
+	x, e := 2.0, float64(d+1)
+	for i := 0; i < rsequencec.itr; i++ {
+		x -= (math.Pow(x, e) - x - 1) / (e*math.Pow(x, e-1) - 1)
+	}
+	return x
+}
+
+// Dim returns the dimension of the points.
+func (r *RSequence) Dim() int {
+	return len(r.alpha)
+}
+
+// Index returns the index of the next point.
+func (r *RSequence) Index() uint64 {
+	return r.n
+}
+
+// Next writes the next point into p. It returns an error, if the length of p does not equal Dim or the sequence is exhausted after 2^64-1 points.
+func (r *RSequence) Next(p []float64) error {
+	// Return an error, if p is invalid
+	if e := checkPoint(p, len(r.alpha)); e != nil {
+		return e
+	}
+	// Return an error, if the sequence is exhausted
+	if e := checkIndex("RSequence", r.n, 1); e != nil {
+		return e
+	}
+	// Fractional part of s_j + n * alpha_j with wrapping 64-bit arithmetic
+	for j, a := range r.alpha {
+		p[j] = float64((r.shift[j]+r.n*a)>>11) * rsequencec.scale
+	}
+	r.n++
+	return nil
+}
+
+// Skip skips the next n points. It returns an error, if the sequence would be exhausted after 2^64-1 points. In this case, no point is skipped.
+func (r *RSequence) Skip(n uint64) error {
+	// Return an error, if the sequence would be exhausted
+	if e := checkIndex("RSequence", r.n, n); e != nil {
+		return e
+	}
+	r.n += n
+	return nil
+}
+
+// Scramble randomizes the sequence with a random shift of each dimension drawn from src, also known as Cranley-Patterson rotation. Owen
+// scrambling is not applicable to the R-sequence, since it is not based on digits. The randomized points keep the low discrepancy of the
+// sequence. It returns an error, if src is nil or not available.
+func (r *RSequence) Scramble(src tsrand.Source) error {
+	// Return an error, if src is invalid
+	if e := checkSource(src); e != nil {
+		return e
+	}
+	// Draw the shift of each dimension
+	for j := range r.shift {
+		r.shift[j] = src.Uint64()
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package quasirandom
+
+// Import standard library packages and tserr
+import (
+	"fmt"     // fmt
+	"math"    // math
+	"testing" // testing
+
+	"github.com/thorstenrie/tserr" // tserr
+)
+
+// TestGoldenRatio tests the generalized golden ratio of dimension 1, the golden ratio, and of dimension 2, the plastic number.
+func TestGoldenRatio(t *testing.T) {
+	for d, w := range map[int]float64{1: (1 + math.Sqrt(5)) / 2, 2: 1.324717957244746} {
+		// The test fails, if the generalized golden ratio does not equal the expected value
+		if g := goldenRatio(d); math.Abs(g-w) > 1e-15 {
+			t.Error(tserr.Equalf(&tserr.EqualfArgs{Var: fmt.Sprintf("generalized golden ratio of dimension %d", d), Actual: g, Want: w}))
+		}
+	}
+}
+
+// TestRSequencePoints tests the first three points of the R2 sequence.
+func TestRSequencePoints(t *testing.T) {
+	// Increments of the R2 sequence of Roberts
+	a1, a2 := 0.7548776662466927, 0.5698402909980532
+	// The test fails, if NewRSequence returns an error
+	r, e := NewRSequence(2)
+	if e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "NewRSequence", Fn: "dim", Err: e}))
+	}
+	p := make([]float64, 2)
+	for i := 0; i < 3; i++ {
+		r.Next(p)
+		w := []float64{math.Mod(0.5+float64(i)*a1, 1), math.Mod(0.5+float64(i)*a2, 1)}
+		// The test fails, if a coordinate does not equal the expected value
+		for j := range w {
+			if math.Abs(p[j]-w[j]) > 1e-15 {
+				t.Error(tserr.Equalf(&tserr.EqualfArgs{Var: fmt.Sprintf("coordinate %d of point %d", j, i), Actual: p[j], Want: w[j]}))
+			}
+		}
+	}
+}
@@ -0,0 +1,290 @@
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package quasirandom
+
+// Import standard library packages, tserr and tsrand
+import (
+	"bufio"     // bufio
+	_ "embed"   // embed
+	"fmt"       // fmt
+	"io"        // io
+	"math/bits" // math/bits
+	"strconv"   // strconv
+	"strings"   // strings
+
+	"github.com/thorstenrie/tserr"  // tserr
+	"github.com/thorstenrie/tsrand" // tsrand
+)
+
+// sobolc holds the constants of the Sobol sequence
+var (
+	sobolc = struct {
+		bits   int     // number of bits of the points
+		max    uint64  // maximum number of points
+		scale  float64 // scale of a point to [0,1)
+		degree int     // maximum degree of a primitive polynomial
+	}{
+		bits:   32,      // number of bits of the points
+		max:    1 << 32, // maximum number of points
+		scale:  0x1p-32, // scale of a point to [0,1)
+		degree: 31,      // maximum degree of a primitive polynomial
+	}
+)
+
+// Direction holds the primitive polynomial and the initial direction numbers of a dimension of the Sobol sequence in the format
+// of Joe and Kuo. S is the degree of the primitive polynomial x^S + a_1 x^(S-1) + ... + a_(S-1) x + 1 and A holds its inner
+// coefficients a_1, ..., a_(S-1) as bits with a_1 as most significant bit. M holds the S initial direction numbers m_1, ..., m_S.
+// Each m_k must be odd and lower than 2^k.
+type Direction struct {
+	S int      // degree of the primitive polynomial
+	A uint32   // inner coefficients of the primitive polynomial
+	M []uint32 // initial direction numbers
+}
+
+// sobolFile holds the built-in primitive polynomials and initial direction numbers of the Sobol sequence in the format of the file
+// new-joe-kuo-6.21201 of Joe and Kuo, starting with dimension 2.
+//
+//go:embed sobol_directions.txt
+var sobolFile string
+
+// sobolDirections holds the built-in directions parsed from sobolFile. NewSobol supports one dimension more than the number of built-in directions.
+// More dimensions are available with ReadDirections and NewSobolDirections from the complete file of Joe and Kuo, which provides direction numbers
+// for up to 21201 dimensions.
+var sobolDirections = func() []Direction {
+	dirs, e := ReadDirections(strings.NewReader(sobolFile))
+	// Panic, if the embedded directions are invalid
+	if e != nil {
+		panic(e)
+	}
+	return dirs
+}()
+
+// Sobol provides the Sobol sequence in base 2. The points are generated in Gray code order of Antonov and Saleev with 32 bits per
+// coordinate. Therefore, the sequence is exhausted after 2^32 points. The first 2^m points of the first two dimensions form a (0,m,2)-net:
+// each elementary interval of volume 2^-m contains exactly one point. Sobol holds the direction numbers, the current point, the index of the
+// next point and the seeds of the scrambling, if scrambled.
+type Sobol struct {
+	v    [][32]uint32 // direction numbers of each dimension
+	x    []uint32     // current point
+	n    uint64       // index of the current point
+	seed []uint32     // seeds of the scrambling of each dimension, nil if not scrambled
+}
+
+// NewSobol returns a new instance of Sobol with dimension dim using the built-in direction numbers of Joe and Kuo. The built-in direction numbers are
+// the first rows of the file new-joe-kuo-6.21201 embedded from sobol_directions.txt, currently for 21 dimensions. It returns an error, if dim is lower
+// than 1 or higher than the number of built-in directions plus one. For more dimensions, the complete file of Joe and Kuo can be read with ReadDirections
+// and used with NewSobolDirections.
+func NewSobol(dim int) (*Sobol, error) {
+	// Return an error, if dim is higher than the built-in dimensions
+	if dim > len(sobolDirections)+1 {
+		return nil, errParam("dimension", dim, fmt.Sprintf("lower than or equal to %d, use NewSobolDirections for more dimensions", len(sobolDirections)+1))
+	}
+	// Return Sobol with built-in direction numbers
+	return NewSobolDirections(dim, sobolDirections)
+}
+
+// NewSobolDirections returns a new instance of Sobol with dimension dim using the direction numbers dirs for the dimensions 2 to dim.
+// The first dimension is the van der Corput sequence in base 2. It returns an error, if dim is lower than 1, dirs holds less than dim-1
+// directions or a direction is invalid.
+func NewSobolDirections(dim int, dirs []Direction) (*Sobol, error) {
+	// Return an error, if dim is invalid
+	if e := checkDim(dim); e != nil {
+		return nil, e
+	}
+	// Return an error, if dirs does not provide enough directions
+	if len(dirs) < dim-1 {
+		return nil, errParam("number of directions", len(dirs), fmt.Sprintf("higher than or equal to %d", dim-1))
+	}
+	s := &Sobol{v: make([][32]uint32, dim), x: make([]uint32, dim)}
+	// Direction numbers of the first dimension
+	for k := range s.v[0] {
+		s.v[0][k] = 1 << (sobolc.bits - 1 - k)
+	}
+	// Direction numbers of the dimensions 2 to dim
+	for j := 1; j < dim; j++ {
+		if e := checkDirection(j+1, dirs[j-1]); e != nil {
+			return nil, e
+		}
+		s.v[j] = directionNumbers(dirs[j-1])
+	}
+	// Return Sobol
+	return s, nil
+}
+
+// ReadDirections reads primitive polynomials and initial direction numbers from r in the format of the files of Joe and Kuo, e.g.,
+// new-joe-kuo-6.21201. Each line holds the dimension d, the degree s, the inner coefficients a and the s initial direction numbers m
+// separated by white space. A header line and empty lines are skipped. The directions are returned in the order of the lines starting
+// with dimension 2. It returns an error, if a line cannot be parsed or a direction is invalid.
+func ReadDirections(r io.Reader) ([]Direction, error) {
+	// Return an error, if r is nil
+	if r == nil {
+		return nil, tserr.NilPtr()
+	}
+	var dirs []Direction
+	sc := bufio.NewScanner(r)
+	for l := 1; sc.Scan(); l++ {
+		f := strings.Fields(sc.Text())
+		// Skip empty lines and the header line
+		if len(f) == 0 || (l == 1 && f[0] == "d") {
+			continue
+		}
+		// Parse the fields of the line
+		v := make([]uint64, len(f))
+		for i := range f {
+			n, e := strconv.ParseUint(f[i], 10, 32)
+			if e != nil {
+				return nil, tserr.Op(&tserr.OpArgs{Op: fmt.Sprintf("parse line %d", l), Fn: f[i], Err: e})
+			}
+			v[i] = n
+		}
+		// Return an error, if the number of fields does not match the degree
+		if len(v) < 3 || uint64(len(v)) != v[1]+3 {
+			return nil, tserr.Check(&tserr.CheckArgs{F: fmt.Sprintf("line %d", l), Err: fmt.Errorf("expected dimension, degree, coefficients and initial direction numbers")})
+		}
+		d := Direction{S: int(v[1]), A: uint32(v[2]), M: make([]uint32, v[1])}
+		for i := range d.M {
+			d.M[i] = uint32(v[3+i])
+		}
+		// Return an error, if the direction is invalid
+		if e := checkDirection(int(v[0]), d); e != nil {
+			return nil, e
+		}
+		dirs = append(dirs, d)
+	}
+	// Return an error, if reading fails
+	if e := sc.Err(); e != nil {
+		return nil, e
+	}
+	// Return the directions
+	return dirs, nil
+}
+
+// checkDirection returns an error, if direction d of dimension dim is invalid.
+func checkDirection(dim int, d Direction) error {
+	name := fmt.Sprintf("direction of dimension %d", dim)
+	// Return an error, if the degree is invalid
+	if d.S < 1 || d.S > sobolc.degree {
+		return errParam("degree of "+name, d.S, fmt.Sprintf("in [1,%d]", sobolc.degree))
+	}
+	// Return an error, if the coefficients do not match the degree
+	if d.A >= 1<<(d.S-1) {
+		return errParam("coefficients of "+name, d.A, fmt.Sprintf("lower than %d", 1<<(d.S-1)))
+	}
+	// Return an error, if the number of initial direction numbers does not match the degree
+	if len(d.M) != d.S {
+		return errParam("number of initial direction numbers of "+name, len(d.M), fmt.Sprint(d.S))
+	}
+	// Return an error, if an initial direction number is even or too large
+	for k, m := range d.M {
+		if m%2 == 0 || m >= 1<<(k+1) {
+			return errParam(fmt.Sprintf("m_%d of %s", k+1, name), m, fmt.Sprintf("odd and lower than %d", 1<<(k+1)))
+		}
+	}
+	// Return nil
+	return nil
+}
+
+// directionNumbers returns the direction numbers of direction d based on the recurrence of the primitive polynomial.
+func directionNumbers(d Direction) [32]uint32 {
+	var v [32]uint32
+	// Initial direction numbers
+	for k := 0; k < d.S; k++ {
+		v[k] = d.M[k] << (sobolc.bits - 1 - k)
+	}
+	// Recurrence of the primitive polynomial
+	for k := d.S; k < sobolc.bits; k++ {
+		v[k] = v[k-d.S] ^ (v[k-d.S] >> d.S)
+		for i := 1; i < d.S; i++ {
+			v[k] ^= ((d.A >> (d.S - 1 - i)) & 1) * v[k-i]
+		}
+	}
+	return v
+}
+
+// Dim returns the dimension of the points.
+func (s *Sobol) Dim() int {
+	return len(s.x)
+}
+
+// Index returns the index of the next point.
+func (s *Sobol) Index() uint64 {
+	return s.n
+}
+
+// Next writes the next point into p. It returns an error, if the length of p does not equal Dim or the sequence is exhausted after 2^32 points.
+func (s *Sobol) Next(p []float64) error {
+	// Return an error, if p is invalid
+	if e := checkPoint(p, len(s.x)); e != nil {
+		return e
+	}
+	// Return an error, if the sequence is exhausted
+	if s.n >= sobolc.max {
+		return tserr.Forbidden(fmt.Sprintf("more than %d points of Sobol", sobolc.max))
+	}
+	// Write the current point, scrambled, if seeds are set
+	for j, x := range s.x {
+		if s.seed != nil {
+			x = owen(x, s.seed[j])
+		}
+		p[j] = float64(x) * sobolc.scale
+	}
+	// Advance to the next point in Gray code order by the direction number of the lowest changing bit
+	s.n++
+	if s.n < sobolc.max {
+		c := bits.TrailingZeros64(s.n)
+		for j := range s.x {
+			s.x[j] ^= s.v[j][c]
+		}
+	}
+	return nil
+}
+
+// Skip skips the next n points. The point is computed directly from the Gray code of its index. It returns an error, if the sequence
+// would be exhausted. In this case, no point is skipped.
+func (s *Sobol) Skip(n uint64) error {
+	// Return an error, if the sequence would be exhausted
+	if n > sobolc.max-s.n {
+		return tserr.Forbidden(fmt.Sprintf("more than %d points of Sobol", sobolc.max))
+	}
+	s.n += n
+	// Compute the point of index n from the direction numbers of the bits of its Gray code
+	g := s.n ^ (s.n >> 1)
+	for j := range s.x {
+		s.x[j] = 0
+		for c := 0; c < sobolc.bits; c++ {
+			if g>>c&1 == 1 {
+				s.x[j] ^= s.v[j][c]
+			}
+		}
+	}
+	return nil
+}
+
+// Scramble scrambles the sequence with nested uniform scrambling of Owen based on the hash-based permutation of Laine and Karras as
+// improved by Burley. The seed of each dimension is drawn from src. The scrambled points keep the net properties of the sequence. It
+// returns an error, if src is nil or not available.
+func (s *Sobol) Scramble(src tsrand.Source) error {
+	// Return an error, if src is invalid
+	if e := checkSource(src); e != nil {
+		return e
+	}
+	// Draw the seed of each dimension
+	s.seed = make([]uint32, len(s.x))
+	for j := range s.seed {
+		s.seed[j] = uint32(src.Uint64() >> 32)
+	}
+	return nil
+}
+
+// owen returns x scrambled with seed. The bits of x are reversed, so that each bit of the hash-based permutation depends only on the lower bits,
+// which are the higher bits of x. Hence, the permutation of each bit of x depends only on its higher bits as required by nested scrambling.
+func owen(x, seed uint32) uint32 {
+	x = bits.Reverse32(x)
+	x ^= x * 0x3d20adea
+	x += seed
+	x *= (seed >> 16) | 1
+	x ^= x * 0x05526c56
+	x ^= x * 0x53a22864
+	return bits.Reverse32(x)
+}
@@ -0,0 +1,270 @@
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package quasirandom
+
+// Import standard library packages and tserr
+import (
+	"fmt"           // fmt
+	"os"            // os
+	"path/filepath" // path/filepath
+	"strings"       // strings
+	"testing"       // testing
+
+	"github.com/thorstenrie/tserr" // tserr
+)
+
+// testNetM is the exponent of the number of points of the net tests
+const (
+	testNetM int = 10
+)
+
+// testHighDim is the dimension of the test of a high-dimensional Sobol sequence
+const (
+	testHighDim int = 1000
+)
+
+// TestSobolPoints tests the first eight points of the Sobol sequence with dimension 3.
+func TestSobolPoints(t *testing.T) {
+	want := [][]float64{{0, 0, 0}, {0.5, 0.5, 0.5}, {0.75, 0.25, 0.25}, {0.25, 0.75, 0.75}, {0.375, 0.375, 0.625}, {0.875, 0.875, 0.125}, {0.625, 0.125, 0.875}, {0.125, 0.625, 0.375}}
+	// The test fails, if NewSobol returns an error
+	s, e := NewSobol(3)
+	if e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "NewSobol", Fn: "dim", Err: e}))
+	}
+	p := make([]float64, 3)
+	for i, w := range want {
+		s.Next(p)
+		// The test fails, if a coordinate does not equal the expected value
+		for j := range w {
+			if p[j] != w[j] {
+				t.Error(tserr.Equalf(&tserr.EqualfArgs{Var: fmt.Sprintf("coordinate %d of point %d", j, i), Actual: p[j], Want: w[j]}))
+			}
+		}
+	}
+}
+
+// TestSobolNet tests that the first 2^testNetM points of the first two dimensions of the unscrambled and scrambled Sobol sequence form a
+// (0,testNetM,2)-net and that each dimension is stratified.
+func TestSobolNet(t *testing.T) {
+	n := 1 << testNetM
+	for _, scrambled := range []bool{false, true} {
+		// The test fails, if NewSobol returns an error
+		s, e := NewSobol(len(sobolDirections) + 1)
+		if e != nil {
+			t.Fatal(tserr.Op(&tserr.OpArgs{Op: "NewSobol", Fn: "dim", Err: e}))
+		}
+		if scrambled {
+			s.Scramble(testSource())
+		}
+		pts := make([][]float64, n)
+		for i := range pts {
+			pts[i] = make([]float64, s.Dim())
+			s.Next(pts[i])
+		}
+		// Each elementary interval of size 2^-m1 x 2^-(testNetM-m1) must contain exactly one point
+		for m1 := 0; m1 <= testNetM; m1++ {
+			seen := make(map[[2]int]bool)
+			for _, p := range pts {
+				c := [2]int{int(p[0] * float64(int(1)<<m1)), int(p[1] * float64(int(1)<<(testNetM-m1)))}
+				// The test fails, if an elementary interval contains two points
+				if seen[c] {
+					t.Fatal(tserr.Forbidden(fmt.Sprintf("second point in elementary interval %v of size 2^-%d x 2^-%d, scrambled %v", c, m1, testNetM-m1, scrambled)))
+				}
+				seen[c] = true
+			}
+		}
+		// The test fails, if a dimension is not stratified
+		for j := 0; j < s.Dim(); j++ {
+			s, _ := NewSobol(len(sobolDirections) + 1)
+			if scrambled {
+				s.Scramble(testSource())
+			}
+			testStratified(t, fmt.Sprintf("Sobol scrambled %v", scrambled), s, j, 2, testNetM)
+		}
+	}
+}
+
+// TestSobolDirections tests that the built-in directions are all primitive polynomials in the order of their degree and coefficients.
+func TestSobolDirections(t *testing.T) {
+	i := 0
+	for deg := 1; i < len(sobolDirections); deg++ {
+		for a := uint32(0); a < 1<<(deg-1) && i < len(sobolDirections); a++ {
+			if !primitive(deg, a) {
+				continue
+			}
+			// The test fails, if the direction does not hold the next primitive polynomial
+			if d := sobolDirections[i]; d.S != deg || d.A != a {
+				t.Fatal(tserr.Return(&tserr.ReturnArgs{Op: fmt.Sprintf("polynomial of direction %d", i), Actual: fmt.Sprintf("s=%d a=%d", d.S, d.A), Want: fmt.Sprintf("s=%d a=%d", deg, a)}))
+			}
+			// The test fails, if the direction is invalid
+			if e := checkDirection(i+2, sobolDirections[i]); e != nil {
+				t.Error(e)
+			}
+			i++
+		}
+	}
+}
+
+// primitive returns true, if the polynomial over GF(2) with degree s and inner coefficients a is primitive, i.e., the order of x modulo the
+// polynomial is 2^s-1.
+func primitive(s int, a uint32) bool {
+	p := uint64(1)<<s | uint64(a)<<1 | 1
+	ord := uint64(1)<<s - 1
+	// x^ord must be 1
+	if polyPow(2, ord, p, s) != 1 {
+		return false
+	}
+	// x^(ord/q) must not be 1 for each prime factor q of ord
+	for q, r := uint64(2), ord; r > 1; q++ {
+		if r%q != 0 {
+			continue
+		}
+		for r%q == 0 {
+			r /= q
+		}
+		if polyPow(2, ord/q, p, s) == 1 {
+			return false
+		}
+	}
+	return true
+}
+
+// polyPow returns x^e modulo polynomial p with degree s over GF(2).
+func polyPow(x, e, p uint64, s int) uint64 {
+	r := uint64(1)
+	for ; e > 0; e >>= 1 {
+		if e&1 == 1 {
+			r = polyMul(r, x, p, s)
+		}
+		x = polyMul(x, x, p, s)
+	}
+	return r
+}
+
+// polyMul returns x * y modulo polynomial p with degree s over GF(2).
+func polyMul(x, y, p uint64, s int) uint64 {
+	r := uint64(0)
+	for ; y > 0; y >>= 1 {
+		if y&1 == 1 {
+			r ^= x
+		}
+		x <<= 1
+		if x>>s&1 == 1 {
+			x ^= p
+		}
+	}
+	return r
+}
+
+// TestReadDirections tests that ReadDirections parses the built-in directions in the format of Joe and Kuo and returns an error for invalid lines.
+func TestReadDirections(t *testing.T) {
+	// Write the built-in directions in the format of Joe and Kuo
+	var sb strings.Builder
+	sb.WriteString("d       s       a       m_i\n")
+	for i, d := range sobolDirections {
+		fmt.Fprintf(&sb, "%d %d %d", i+2, d.S, d.A)
+		for _, m := range d.M {
+			fmt.Fprintf(&sb, " %d", m)
+		}
+		sb.WriteString("\n")
+	}
+	// The test fails, if ReadDirections returns an error
+	dirs, e := ReadDirections(strings.NewReader(sb.String()))
+	if e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "ReadDirections", Fn: "built-in directions", Err: e}))
+	}
+	// The test fails, if the directions do not equal the built-in directions
+	if fmt.Sprint(dirs) != fmt.Sprint(sobolDirections) {
+		t.Error(tserr.NotEqualStr(&tserr.NotEqualStrArgs{X: fmt.Sprint(dirs), Y: fmt.Sprint(sobolDirections)}))
+	}
+	// The test fails, if ReadDirections does not return an error for an invalid line
+	for _, l := range []string{"2 1 0", "2 1 0 1 1", "2 1 0 2", "3 2 1 1 5", "3 2 2 1 3", "2 x 0 1", "2 -1 0 1"} {
+		if _, e := ReadDirections(strings.NewReader(l)); e == nil {
+			t.Error(tserr.NilFailed("ReadDirections of " + l))
+		}
+	}
+	// The test fails, if ReadDirections does not return an error for nil
+	if _, e := ReadDirections(nil); e == nil {
+		t.Error(tserr.NilFailed("ReadDirections of nil"))
+	}
+}
+
+// TestSobolHighDim tests that a Sobol sequence with 1000 dimensions can be created and each dimension is balanced over the first 2^testNetM
+// points: each interval [k/2^testNetM,(k+1)/2^testNetM) contains exactly one coordinate. It uses the built-in directions, if they provide
+// 1000 dimensions, and otherwise the file new-joe-kuo-6.21201 of Joe and Kuo in testdata, if present. Otherwise, the test is skipped.
+func TestSobolHighDim(t *testing.T) {
+	// Retrieve the sequence from the built-in directions or the file of Joe and Kuo
+	var s *Sobol
+	var e error
+	if len(sobolDirections)+1 >= testHighDim {
+		s, e = NewSobol(testHighDim)
+	} else {
+		f, err := os.Open(filepath.Join("testdata", "new-joe-kuo-6.21201"))
+		if err != nil {
+			t.Skipf("%d built-in dimensions and no file new-joe-kuo-6.21201 in testdata", len(sobolDirections)+1)
+		}
+		defer f.Close()
+		dirs, err := ReadDirections(f)
+		if err != nil {
+			t.Fatal(tserr.Op(&tserr.OpArgs{Op: "ReadDirections", Fn: "new-joe-kuo-6.21201", Err: err}))
+		}
+		s, e = NewSobolDirections(testHighDim, dirs)
+	}
+	// The test fails, if the sequence cannot be created
+	if e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "NewSobol", Fn: fmt.Sprint(testHighDim), Err: e}))
+	}
+	// Count the coordinates in each interval of each dimension
+	n := 1 << testNetM
+	seen := make([][]bool, testHighDim)
+	for j := range seen {
+		seen[j] = make([]bool, n)
+	}
+	p := make([]float64, testHighDim)
+	for i := 0; i < n; i++ {
+		s.Next(p)
+		for j, x := range p {
+			k := int(x * float64(n))
+			// The test fails, if an interval contains two coordinates
+			if seen[j][k] {
+				t.Fatal(tserr.Forbidden(fmt.Sprintf("second coordinate of dimension %d in interval %d of size 2^-%d", j, k, testNetM)))
+			}
+			seen[j][k] = true
+		}
+	}
+}
+
+// TestSobolLimits tests that NewSobol returns an error for more than the built-in dimensions, NewSobolDirections returns an error for too few
+// directions and the sequence is exhausted after 2^32 points.
+func TestSobolLimits(t *testing.T) {
+	// The test fails, if NewSobol does not return an error for more than the built-in dimensions
+	if _, e := NewSobol(len(sobolDirections) + 2); e == nil {
+		t.Error(tserr.NilFailed("NewSobol with too many dimensions"))
+	}
+	// The test fails, if NewSobolDirections does not return an error for too few directions
+	if _, e := NewSobolDirections(3, sobolDirections[:1]); e == nil {
+		t.Error(tserr.NilFailed("NewSobolDirections with too few directions"))
+	}
+	// The test fails, if NewSobolDirections does not return an error for an invalid direction
+	if _, e := NewSobolDirections(2, []Direction{{2, 1, []uint32{1, 2}}}); e == nil {
+		t.Error(tserr.NilFailed("NewSobolDirections with invalid direction"))
+	}
+	s, _ := NewSobol(2)
+	p := make([]float64, 2)
+	// The test fails, if Skip to the last point returns an error
+	if e := s.Skip(sobolc.max - 1); e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "Skip", Fn: "Sobol", Err: e}))
+	}
+	// The test fails, if the last point cannot be retrieved or is not 2^-32 in the first dimension, since the Gray code of its index is 2^31
+	if e := s.Next(p); e != nil || p[0] != 0x1p-32 {
+		t.Error(tserr.Equalf(&tserr.EqualfArgs{Var: "first coordinate of the last point", Actual: p[0], Want: 0x1p-32}))
+	}
+	// The test fails, if Next and Skip do not return an error after the last point
+	if e := s.Next(p); e == nil {
+		t.Error(tserr.NilFailed("Next of exhausted Sobol"))
+	}
+	if e := s.Skip(1); e == nil {
+		t.Error(tserr.NilFailed("Skip of exhausted Sobol"))
+	}
+}
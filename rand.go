@@ -4,7 +4,8 @@
 // secure random number generation or pseudo-random number generation. Additionally, the package
 // enables to retrieve a rand.Rand using a custom implementation of a source for random number generation.
 //
-// - The cryptographically secure random number generator is based on crypto/rand.
+// - The cryptographically secure random number generator is based on crypto/rand. Errors of CryptoSource are sticky until cleared with ClearErr and can be detected per call with TryUint64.
+// - The buffered cryptographically secure random number generator reads crypto/rand in large blocks and is significantly faster.
 // - The pseudo-random number generator is based on math/rand. It can be used to generate deterministic random numbers based on a seed.
 // - A custom source needs to implement tsrand.Source.
 //
@@ -13,6 +14,26 @@
 // - SimpleSource based on a very simple example from Wikipedia
 // - MT32Source based on the 32-bit Mersenne Twister
 // - MT64Source based on the 64-bit Mersenne Twister
+// - PCG32Source based on the PCG32 generator with XSH-RR output function
+// - PCG64Source based on the PCG64 DXSM generator
+// - Xoshiro256StarStarSource, Xoshiro256PlusSource and Xoroshiro128PlusPlusSource based on the xoshiro/xoroshiro generators, which provide non-overlapping subsequences with Jump and LongJump
+// - SplitMix64Source based on the splitmix64 generator
+// - ChaChaSource based on the ChaCha8 or ChaCha20 stream cipher, seeded from crypto/rand or reproducibly with a 256-bit key
+// - PhiloxSource and ThreefrySource based on the counter-based Philox4x32-10 and Threefry4x64-20 generators, which provide random access to the stream with At and SetCounter
+//
+// Except for SimpleSource, the seeded example sources fill their full state with the SeedExpander, which expands a seed of type int64 or []byte based on splitmix64.
+//
+// NewV2 and the V2 variants of the interface functions return an instance of math/rand/v2 Rand. NewSourceFromV2 wraps a math/rand/v2 Source as Source.
+//
+// Uint64n, Uint32n and IntRange return unbiased random integers in a range based on the method of Lemire.
+//
+// Float64Full and Float32Full return every representable float in [0,1) based on the method of Downey. Open, half-open and closed variants are provided.
+//
+// The generic sampling helpers Shuffle, Choice, Sample, WeightedSample, Reservoir and ReservoirL select elements at random using a Source or a rand.Rand.
+//
+// NewLockedSource wraps a source, which is not safe for concurrent use by multiple goroutines, and serializes all calls with a mutex.
+//
+// NewHealthSource wraps a source with the continuous health tests of NIST SP 800-90B, the repetition count test and the adaptive proportion test. A failed test is recorded as sticky error and the source does not output random values until ClearErr is called.
 //
 // The functions return a pointer to an instance of type rand.Rand. It returns nil and an error, if the random number generator source is not available.
 //
@@ -35,10 +56,10 @@ const (
 )
 
 // NewCryptoRand returns a new instance of rand.Rand which provides a cryptographically secure
-// random number generator based on crypto/rand. It is safe for concurrent use by multiple goroutines.
-// If it is not available on the platform, NewCryptoRand() returns an error and *rand.Rand is nil.
+// random number generator based on crypto/rand. Each call uses a new instance of CryptoSource. It is safe for concurrent
+// use by multiple goroutines. If it is not available on the platform, NewCryptoRand() returns an error and *rand.Rand is nil.
 func NewCryptoRand() (*rand.Rand, error) {
-	return New(cryptoSource())
+	return New(NewCryptoSource())
 }
 
 // NewPseudoRandomRand returns a new instance of rand.Rand which provides a pseudo-
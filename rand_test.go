@@ -43,6 +43,33 @@ func BenchmarkCryptoRand(b *testing.B) {
 	benchRandUint(b, rnd)
 }
 
+// TestBufferedCryptoRand retrieves random values from the buffered cryptographically secure random number generator and performs the defined tests
+// on arithmetic mean and variance. The test fails, if the cryptographically secure random number generator is not available on the platform or if tests
+// on the retrieved random numbers fail.
+func TestBufferedCryptoRand(t *testing.T) {
+	// Retrieve the buffered cryptographically secure random number generator with the default buffer size
+	rnd, err := NewBufferedCryptoRand(0)
+	// The test fails if an error occurs
+	if err != nil {
+		t.Fatal(tserr.NotAvailable(&tserr.NotAvailableArgs{S: "BufferedCryptoRand", Err: err}))
+	}
+	// Perform tests on the random number generator source
+	testRandInt(t, rnd)
+	testRandFloat(t, rnd)
+	testRandUint(t, rnd)
+}
+
+// BenchmarkBufferedCryptoRand performs a benchmark on the buffered cryptographically secure random number generator
+func BenchmarkBufferedCryptoRand(b *testing.B) {
+	// Retrieve the buffered cryptographically secure random number generator with the default buffer size
+	rnd, err := NewBufferedCryptoRand(0)
+	// The test fails if an error occurs
+	if err != nil {
+		b.Fatal(tserr.NotAvailable(&tserr.NotAvailableArgs{S: "BufferedCryptoRand", Err: err}))
+	}
+	benchRandUint(b, rnd)
+}
+
 // TestPseudoRand retrieves random values from the pseudo-random number generator and performs the defined tests on arithmetic mean and variance.
 // The test fails, if the pseudo-random number generator is not available on the platform or if tests on the retrieved random numbers fail.
 func TestPseudoRand(t *testing.T) {
@@ -165,3 +192,253 @@ func BenchmarkMT64Rand(b *testing.B) {
 	}
 	benchRandUint(b, rnd)
 }
+
+// TestPCG32Rand retrieves random values from an implementation based on the PCG32 generator
+// and performs the defined tests on arithmetic mean and variance. The test fails, if the pseudo-random number generator
+// is not available on the platform  or if tests on the retrieved random numbers fail.
+func TestPCG32Rand(t *testing.T) {
+	// Retrieve the pseudo-random number generator
+	rnd, err := New(NewPCG32Source())
+	// The test fails if an error occurs
+	if err != nil {
+		t.Fatal(tserr.NotAvailable(&tserr.NotAvailableArgs{S: "NewPCG32Source", Err: err}))
+	}
+	// Perform tests on the random number generator source
+	testRand(t, rnd)
+}
+
+// BenchmarkPCG32Rand performs a benchmark on the PCG32 based implemented pseudo-random number generator
+func BenchmarkPCG32Rand(b *testing.B) {
+	// Retrieve the pseudo-random number generator
+	rnd, err := New(NewPCG32Source())
+	// The test fails if an error occurs
+	if err != nil {
+		b.Fatal(tserr.NotAvailable(&tserr.NotAvailableArgs{S: "NewPCG32Source", Err: err}))
+	}
+	benchRandUint(b, rnd)
+}
+
+// TestPCG64Rand retrieves random values from an implementation based on the PCG64 DXSM generator
+// and performs the defined tests on arithmetic mean and variance. The test fails, if the pseudo-random number generator
+// is not available on the platform  or if tests on the retrieved random numbers fail.
+func TestPCG64Rand(t *testing.T) {
+	// Retrieve the pseudo-random number generator
+	rnd, err := New(NewPCG64Source())
+	// The test fails if an error occurs
+	if err != nil {
+		t.Fatal(tserr.NotAvailable(&tserr.NotAvailableArgs{S: "NewPCG64Source", Err: err}))
+	}
+	// Perform tests on the random number generator source
+	testRand(t, rnd)
+}
+
+// BenchmarkPCG64Rand performs a benchmark on the PCG64 DXSM based implemented pseudo-random number generator
+func BenchmarkPCG64Rand(b *testing.B) {
+	// Retrieve the pseudo-random number generator
+	rnd, err := New(NewPCG64Source())
+	// The test fails if an error occurs
+	if err != nil {
+		b.Fatal(tserr.NotAvailable(&tserr.NotAvailableArgs{S: "NewPCG64Source", Err: err}))
+	}
+	benchRandUint(b, rnd)
+}
+
+// TestXoshiro256StarStarRand retrieves random values from an implementation based on the xoshiro256** generator
+// and performs the defined tests on arithmetic mean and variance. The test fails, if the pseudo-random number generator
+// is not available on the platform  or if tests on the retrieved random numbers fail.
+func TestXoshiro256StarStarRand(t *testing.T) {
+	// Retrieve the pseudo-random number generator
+	rnd, err := New(NewXoshiro256StarStarSource())
+	// The test fails if an error occurs
+	if err != nil {
+		t.Fatal(tserr.NotAvailable(&tserr.NotAvailableArgs{S: "NewXoshiro256StarStarSource", Err: err}))
+	}
+	// Perform tests on the random number generator source
+	testRand(t, rnd)
+}
+
+// BenchmarkXoshiro256StarStarRand performs a benchmark on the xoshiro256** based implemented pseudo-random number generator
+func BenchmarkXoshiro256StarStarRand(b *testing.B) {
+	// Retrieve the pseudo-random number generator
+	rnd, err := New(NewXoshiro256StarStarSource())
+	// The test fails if an error occurs
+	if err != nil {
+		b.Fatal(tserr.NotAvailable(&tserr.NotAvailableArgs{S: "NewXoshiro256StarStarSource", Err: err}))
+	}
+	benchRandUint(b, rnd)
+}
+
+// TestXoshiro256PlusRand retrieves random values from an implementation based on the xoshiro256+ generator
+// and performs the defined tests on arithmetic mean and variance. The test fails, if the pseudo-random number generator
+// is not available on the platform  or if tests on the retrieved random numbers fail.
+func TestXoshiro256PlusRand(t *testing.T) {
+	// Retrieve the pseudo-random number generator
+	rnd, err := New(NewXoshiro256PlusSource())
+	// The test fails if an error occurs
+	if err != nil {
+		t.Fatal(tserr.NotAvailable(&tserr.NotAvailableArgs{S: "NewXoshiro256PlusSource", Err: err}))
+	}
+	// Perform tests on the random number generator source
+	testRand(t, rnd)
+}
+
+// BenchmarkXoshiro256PlusRand performs a benchmark on the xoshiro256+ based implemented pseudo-random number generator
+func BenchmarkXoshiro256PlusRand(b *testing.B) {
+	// Retrieve the pseudo-random number generator
+	rnd, err := New(NewXoshiro256PlusSource())
+	// The test fails if an error occurs
+	if err != nil {
+		b.Fatal(tserr.NotAvailable(&tserr.NotAvailableArgs{S: "NewXoshiro256PlusSource", Err: err}))
+	}
+	benchRandUint(b, rnd)
+}
+
+// TestXoroshiro128PlusPlusRand retrieves random values from an implementation based on the xoroshiro128++ generator
+// and performs the defined tests on arithmetic mean and variance. The test fails, if the pseudo-random number generator
+// is not available on the platform  or if tests on the retrieved random numbers fail.
+func TestXoroshiro128PlusPlusRand(t *testing.T) {
+	// Retrieve the pseudo-random number generator
+	rnd, err := New(NewXoroshiro128PlusPlusSource())
+	// The test fails if an error occurs
+	if err != nil {
+		t.Fatal(tserr.NotAvailable(&tserr.NotAvailableArgs{S: "NewXoroshiro128PlusPlusSource", Err: err}))
+	}
+	// Perform tests on the random number generator source
+	testRand(t, rnd)
+}
+
+// BenchmarkXoroshiro128PlusPlusRand performs a benchmark on the xoroshiro128++ based implemented pseudo-random number generator
+func BenchmarkXoroshiro128PlusPlusRand(b *testing.B) {
+	// Retrieve the pseudo-random number generator
+	rnd, err := New(NewXoroshiro128PlusPlusSource())
+	// The test fails if an error occurs
+	if err != nil {
+		b.Fatal(tserr.NotAvailable(&tserr.NotAvailableArgs{S: "NewXoroshiro128PlusPlusSource", Err: err}))
+	}
+	benchRandUint(b, rnd)
+}
+
+// TestSplitMix64Rand retrieves random values from an implementation based on the splitmix64 generator
+// and performs the defined tests on arithmetic mean and variance. The test fails, if the pseudo-random number generator
+// is not available on the platform  or if tests on the retrieved random numbers fail.
+func TestSplitMix64Rand(t *testing.T) {
+	// Retrieve the pseudo-random number generator
+	rnd, err := New(NewSplitMix64Source())
+	// The test fails if an error occurs
+	if err != nil {
+		t.Fatal(tserr.NotAvailable(&tserr.NotAvailableArgs{S: "NewSplitMix64Source", Err: err}))
+	}
+	// Perform tests on the random number generator source
+	testRand(t, rnd)
+}
+
+// BenchmarkSplitMix64Rand performs a benchmark on the splitmix64 based implemented pseudo-random number generator
+func BenchmarkSplitMix64Rand(b *testing.B) {
+	// Retrieve the pseudo-random number generator
+	rnd, err := New(NewSplitMix64Source())
+	// The test fails if an error occurs
+	if err != nil {
+		b.Fatal(tserr.NotAvailable(&tserr.NotAvailableArgs{S: "NewSplitMix64Source", Err: err}))
+	}
+	benchRandUint(b, rnd)
+}
+
+// TestChaCha20Rand retrieves random values from an implementation based on the ChaCha20 stream cipher
+// and performs the defined tests on arithmetic mean and variance. The test fails, if the random number generator
+// is not available on the platform  or if tests on the retrieved random numbers fail.
+func TestChaCha20Rand(t *testing.T) {
+	// Retrieve the random number generator
+	rnd, err := New(NewChaCha20Source())
+	// The test fails if an error occurs
+	if err != nil {
+		t.Fatal(tserr.NotAvailable(&tserr.NotAvailableArgs{S: "NewChaCha20Source", Err: err}))
+	}
+	// Perform tests on the random number generator source
+	testRand(t, rnd)
+}
+
+// BenchmarkChaCha20Rand performs a benchmark on the ChaCha20 based implemented random number generator
+func BenchmarkChaCha20Rand(b *testing.B) {
+	// Retrieve the random number generator
+	rnd, err := New(NewChaCha20Source())
+	// The test fails if an error occurs
+	if err != nil {
+		b.Fatal(tserr.NotAvailable(&tserr.NotAvailableArgs{S: "NewChaCha20Source", Err: err}))
+	}
+	benchRandUint(b, rnd)
+}
+
+// TestChaCha8Rand retrieves random values from an implementation based on the ChaCha8 stream cipher
+// and performs the defined tests on arithmetic mean and variance. The test fails, if the random number generator
+// is not available on the platform  or if tests on the retrieved random numbers fail.
+func TestChaCha8Rand(t *testing.T) {
+	// Retrieve the random number generator
+	rnd, err := New(NewChaCha8Source())
+	// The test fails if an error occurs
+	if err != nil {
+		t.Fatal(tserr.NotAvailable(&tserr.NotAvailableArgs{S: "NewChaCha8Source", Err: err}))
+	}
+	// Perform tests on the random number generator source
+	testRand(t, rnd)
+}
+
+// BenchmarkChaCha8Rand performs a benchmark on the ChaCha8 based implemented random number generator
+func BenchmarkChaCha8Rand(b *testing.B) {
+	// Retrieve the random number generator
+	rnd, err := New(NewChaCha8Source())
+	// The test fails if an error occurs
+	if err != nil {
+		b.Fatal(tserr.NotAvailable(&tserr.NotAvailableArgs{S: "NewChaCha8Source", Err: err}))
+	}
+	benchRandUint(b, rnd)
+}
+
+// TestPhiloxRand retrieves random values from an implementation based on the counter-based Philox4x32-10 generator
+// and performs the defined tests on arithmetic mean and variance. The test fails, if the pseudo-random number generator
+// is not available on the platform  or if tests on the retrieved random numbers fail.
+func TestPhiloxRand(t *testing.T) {
+	// Retrieve the pseudo-random number generator
+	rnd, err := New(NewPhiloxSource())
+	// The test fails if an error occurs
+	if err != nil {
+		t.Fatal(tserr.NotAvailable(&tserr.NotAvailableArgs{S: "NewPhiloxSource", Err: err}))
+	}
+	// Perform tests on the random number generator source
+	testRand(t, rnd)
+}
+
+// BenchmarkPhiloxRand performs a benchmark on the Philox4x32-10 based implemented pseudo-random number generator
+func BenchmarkPhiloxRand(b *testing.B) {
+	// Retrieve the pseudo-random number generator
+	rnd, err := New(NewPhiloxSource())
+	// The test fails if an error occurs
+	if err != nil {
+		b.Fatal(tserr.NotAvailable(&tserr.NotAvailableArgs{S: "NewPhiloxSource", Err: err}))
+	}
+	benchRandUint(b, rnd)
+}
+
+// TestThreefryRand retrieves random values from an implementation based on the counter-based Threefry4x64-20 generator
+// and performs the defined tests on arithmetic mean and variance. The test fails, if the pseudo-random number generator
+// is not available on the platform  or if tests on the retrieved random numbers fail.
+func TestThreefryRand(t *testing.T) {
+	// Retrieve the pseudo-random number generator
+	rnd, err := New(NewThreefrySource())
+	// The test fails if an error occurs
+	if err != nil {
+		t.Fatal(tserr.NotAvailable(&tserr.NotAvailableArgs{S: "NewThreefrySource", Err: err}))
+	}
+	// Perform tests on the random number generator source
+	testRand(t, rnd)
+}
+
+// BenchmarkThreefryRand performs a benchmark on the Threefry4x64-20 based implemented pseudo-random number generator
+func BenchmarkThreefryRand(b *testing.B) {
+	// Retrieve the pseudo-random number generator
+	rnd, err := New(NewThreefrySource())
+	// The test fails if an error occurs
+	if err != nil {
+		b.Fatal(tserr.NotAvailable(&tserr.NotAvailableArgs{S: "NewThreefrySource", Err: err}))
+	}
+	benchRandUint(b, rnd)
+}
@@ -0,0 +1,52 @@
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tsrand
+
+// Import standard library packages and tserr
+import (
+	randv2 "math/rand/v2" // math/rand/v2
+	"time"                // time
+
+	"github.com/thorstenrie/tserr" // tserr
+)
+
+// NewCryptoRandV2 returns a new instance of math/rand/v2 Rand which provides a cryptographically secure
+// random number generator based on crypto/rand. Each call uses a new instance of CryptoSource. It is safe for concurrent
+// use by multiple goroutines. If it is not available on the platform, NewCryptoRandV2() returns an error and *randv2.Rand is nil.
+func NewCryptoRandV2() (*randv2.Rand, error) {
+	return NewV2(NewCryptoSource())
+}
+
+// NewPseudoRandomRandV2 returns a new instance of math/rand/v2 Rand which provides a pseudo-
+// random number generator based on math/rand. It is not safe for concurrent use by multiple goroutines.
+// The random number generator is initialized with time.Now().UnixNano(). The output might be easily
+// predictable and is unsuitable for security-sensitive services.
+func NewPseudoRandomRandV2() (*randv2.Rand, error) {
+	return NewV2(newDeterministicSource(time.Now().UnixNano()))
+}
+
+// NewDeterministicRandV2 returns a new instance of math/rand/v2 Rand which provides a deterministic pseudo-
+// random number generator based on math/rand. It is not safe for concurrent use by multiple goroutines.
+// It is initialized with defaultSeed = 1 and returns the same sequence of Uint64 as the source of NewDeterministicRand. The output is
+// easily predictable and is unsuitable for security-sensitive services.
+func NewDeterministicRandV2() (*randv2.Rand, error) {
+	return NewV2(newDeterministicSource(defaultSeed))
+}
+
+// NewV2 returns a new instance of math/rand/v2 Rand which provides a random number generator using Source
+// src. If src is nil or the source is not available on the platform, NewV2 returns an error and *randv2.Rand is nil.
+// It is the responsibility of the source to be safe for concurrent use by multiple goroutines.
+func NewV2(src Source) (*randv2.Rand, error) {
+	// Return an error, if src is nil
+	if src == nil {
+		return nil, tserr.NilPtr()
+	}
+	// Call Assert and check if Err returns an error
+	if src.Assert(); src.Err() != nil {
+		// If it returns an error, then return nil and the error
+		return nil, tserr.NotAvailable(&tserr.NotAvailableArgs{S: "Source", Err: src.Err()})
+	}
+	// Return a new instance of randv2.Rand and nil
+	return randv2.New(src), nil
+}
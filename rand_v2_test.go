@@ -0,0 +1,242 @@
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tsrand
+
+// Import standard library packages, lpstats and tserr
+import (
+	randv2 "math/rand/v2" // math/rand/v2
+	"testing"             // testing
+
+	"github.com/thorstenrie/lpstats" // lpstats
+	"github.com/thorstenrie/tserr"   // tserr
+)
+
+// testRandV2 retrieves random integers, floats and unsigned integers normalized to [0,1] from the math/rand/v2 Rand rnd.
+// The number of retrieved random values for each type is defined by the constant testItr. The test fails, if the arithmetic mean
+// of the random values or variance does not equal the expected values with a maximum difference defined by the constant maxDiff.
+func testRandV2(t *testing.T, rnd *randv2.Rand) {
+	// Panic if t is nil
+	if t == nil {
+		panic("nil pointer")
+	}
+	// Test fails immediately, if rnd is nil
+	if rnd == nil {
+		t.Fatal(tserr.NilPtr())
+	}
+	// Allocate and initialize slices with size testItr
+	a, f, u := make([]int, testItr), make([]float64, testItr), make([]float64, testItr)
+	// Iterate random number generator testItr times
+	for i := 0; i < testItr; i++ {
+		// Retrieve a random integer in the interval [0,testIntn), a float64 in the interval [0,1) and a normalized unsigned integer
+		a[i], f[i], u[i] = rnd.IntN(testIntn), rnd.Float64(), float64(rnd.Uint64())/float64(^uint64(0))
+	}
+	// Test arithmetic mean and variance of the random integers
+	testMeanVariance(t, "a", a, lpstats.ExpectedValueU(0, testIntn-1), lpstats.VarianceN(uint(testIntn)))
+	// Test arithmetic mean and variance of the random floats
+	testMeanVariance(t, "f", f, lpstats.ExpectedValueU(0, 1), lpstats.VarianceU(0, 1))
+	// Test arithmetic mean and variance of the normalized random unsigned integers
+	testMeanVariance(t, "u", u, lpstats.ExpectedValueU(0, 1), lpstats.VarianceU(0, 1))
+}
+
+// testMeanVariance compares the arithmetic mean and variance of the values x named n with the expected values meane and varie.
+// The test fails, if they differ more than the constant maxDiff.
+func testMeanVariance[T lpstats.Number](t *testing.T, n string, x []T, meane, varie float64) {
+	// Calculate the arithmetic mean of x
+	mean, e := lpstats.ArithmeticMean(x)
+	// The test fails if arithmetic mean returns an error
+	if e != nil {
+		t.Error(tserr.Op(&tserr.OpArgs{Op: "ArithmeticMean", Fn: n, Err: e}))
+	}
+	// The test fails if the arithmetic mean does not equal the expected value with a maximum difference of maxDiff
+	if !lpstats.NearEqual(mean, meane, maxDiff) {
+		t.Error(tserr.Equalf(&tserr.EqualfArgs{Var: "Arithmetic mean of " + n, Actual: mean, Want: meane}))
+	}
+	// Calculate the variance of x
+	vari, e := lpstats.Variance(x)
+	// The test fails if variance returns an error
+	if e != nil {
+		t.Error(tserr.Op(&tserr.OpArgs{Op: "Variance", Fn: n, Err: e}))
+	}
+	// The test fails if the variance does not equal the expected variance with a maximum difference of maxDiff
+	if !lpstats.NearEqual(vari, varie, maxDiff) {
+		t.Error(tserr.Equalf(&tserr.EqualfArgs{Var: "Variance of " + n, Actual: vari, Want: varie}))
+	}
+}
+
+// benchRandV2Uint retrieves random unsigned integers from the math/rand/v2 Rand rnd for benchmarks.
+func benchRandV2Uint(b *testing.B, rnd *randv2.Rand) {
+	// Panic if b is nil
+	if b == nil {
+		panic("nil pointer")
+	}
+	// The test fails if rnd is nil
+	if rnd == nil {
+		b.Fatal(tserr.NilPtr())
+	}
+	for n := 0; n < b.N; n++ {
+		rnd.Uint64()
+	}
+}
+
+// TestCryptoRandV2 retrieves random values from the cryptographically secure random number generator as math/rand/v2 Rand
+// and performs the defined tests on arithmetic mean and variance. The test fails, if the cryptographically secure random number
+// generator is not available on the platform or if tests on the retrieved random numbers fail.
+func TestCryptoRandV2(t *testing.T) {
+	// Retrieve the cryptographically secure random number generator
+	rnd, err := NewCryptoRandV2()
+	// The test fails if an error occurs
+	if err != nil {
+		t.Fatal(tserr.NotAvailable(&tserr.NotAvailableArgs{S: "CryptoRandV2", Err: err}))
+	}
+	// Perform tests on the random number generator
+	testRandV2(t, rnd)
+}
+
+// BenchmarkCryptoRandV2 performs a benchmark on the cryptographically secure random number generator as math/rand/v2 Rand
+func BenchmarkCryptoRandV2(b *testing.B) {
+	// Retrieve the cryptographically secure random number generator
+	rnd, err := NewCryptoRandV2()
+	// The test fails if an error occurs
+	if err != nil {
+		b.Fatal(tserr.NotAvailable(&tserr.NotAvailableArgs{S: "CryptoRandV2", Err: err}))
+	}
+	benchRandV2Uint(b, rnd)
+}
+
+// TestPseudoRandV2 retrieves random values from the pseudo-random number generator as math/rand/v2 Rand and performs the defined
+// tests on arithmetic mean and variance. The test fails, if the pseudo-random number generator is not available on the platform
+// or if tests on the retrieved random numbers fail.
+func TestPseudoRandV2(t *testing.T) {
+	// Retrieve the pseudo-random number generator
+	rnd, err := NewPseudoRandomRandV2()
+	// The test fails if an error occurs
+	if err != nil {
+		t.Fatal(tserr.NotAvailable(&tserr.NotAvailableArgs{S: "NewPseudoRandomRandV2", Err: err}))
+	}
+	// Perform tests on the random number generator
+	testRandV2(t, rnd)
+}
+
+// BenchmarkPseudoRandV2 performs a benchmark on the pseudo-random number generator as math/rand/v2 Rand
+func BenchmarkPseudoRandV2(b *testing.B) {
+	// Retrieve the pseudo-random number generator
+	rnd, err := NewPseudoRandomRandV2()
+	// The test fails if an error occurs
+	if err != nil {
+		b.Fatal(tserr.NotAvailable(&tserr.NotAvailableArgs{S: "NewPseudoRandomRandV2", Err: err}))
+	}
+	benchRandV2Uint(b, rnd)
+}
+
+// TestDeterministicRandV2 retrieves random values from the deterministic pseudo-random number generator as math/rand/v2 Rand and
+// performs the defined tests on arithmetic mean and variance. The test fails, if the pseudo-random number generator is not available,
+// if tests on the retrieved random numbers fail or if the sequence differs from the sequence of NewDeterministicRand.
+func TestDeterministicRandV2(t *testing.T) {
+	// Retrieve the deterministic pseudo-random number generators
+	rnd, err := NewDeterministicRandV2()
+	// The test fails if an error occurs
+	if err != nil {
+		t.Fatal(tserr.NotAvailable(&tserr.NotAvailableArgs{S: "NewDeterministicRandV2", Err: err}))
+	}
+	rnd1, err := NewDeterministicRand()
+	// The test fails if an error occurs
+	if err != nil {
+		t.Fatal(tserr.NotAvailable(&tserr.NotAvailableArgs{S: "NewDeterministicRand", Err: err}))
+	}
+	// The test fails, if the sequences of Uint64 differ
+	for i := 0; i < testCalls; i++ {
+		if v, w := rnd.Uint64(), rnd1.Uint64(); v != w {
+			t.Fatal(tserr.Equal(&tserr.EqualArgs{Var: "Uint64", Actual: int64(v), Want: int64(w)}))
+		}
+	}
+	// Perform tests on the random number generator
+	testRandV2(t, rnd)
+}
+
+// BenchmarkDeterministicRandV2 performs a benchmark on the deterministic pseudo-random number generator as math/rand/v2 Rand
+func BenchmarkDeterministicRandV2(b *testing.B) {
+	// Retrieve the deterministic pseudo-random number generator
+	rnd, err := NewDeterministicRandV2()
+	// The test fails if an error occurs
+	if err != nil {
+		b.Fatal(tserr.NotAvailable(&tserr.NotAvailableArgs{S: "NewDeterministicRandV2", Err: err}))
+	}
+	benchRandV2Uint(b, rnd)
+}
+
+// TestNewV2 tests NewV2 with all built-in sources and a nil source.
+func TestNewV2(t *testing.T) {
+	// The test fails, if NewV2 returns an error for a built-in source
+	for name, fn := range testBuiltinSources() {
+		if _, err := NewV2(fn()); err != nil {
+			t.Error(tserr.NotAvailable(&tserr.NotAvailableArgs{S: name, Err: err}))
+		}
+	}
+	// The test fails, if NewV2 does not return an error for a nil source
+	if _, err := NewV2(nil); err == nil {
+		t.Error(tserr.NilFailed("NewV2"))
+	}
+}
+
+// TestSourceFromV2 wraps the math/rand/v2 sources PCG and ChaCha8 and tests the output and Seed of the wrapped sources.
+func TestSourceFromV2(t *testing.T) {
+	// Sources of math/rand/v2 supporting Seed
+	srcs := map[string]func() randv2.Source{
+		"PCG":     func() randv2.Source { return randv2.NewPCG(1, 2) },
+		"ChaCha8": func() randv2.Source { return randv2.NewChaCha8([32]byte{1}) },
+	}
+	for name, fn := range srcs {
+		// Wrap source and retrieve reference source with the same initial state
+		src, ref := NewSourceFromV2(fn()), fn()
+		// The test fails, if the wrapped source is not available
+		rnd, err := New(src)
+		if err != nil {
+			t.Fatal(tserr.NotAvailable(&tserr.NotAvailableArgs{S: name, Err: err}))
+		}
+		// The test fails, if the wrapped source does not return the output of the reference source
+		for i := 0; i < testCalls; i++ {
+			if v, w := src.Uint64(), ref.Uint64(); v != w {
+				t.Fatal(tserr.Equal(&tserr.EqualArgs{Var: name + " Uint64", Actual: int64(v), Want: int64(w)}))
+			}
+		}
+		// The test fails, if Seed does not result in a reproducible output
+		src.Seed(defaultSeed)
+		v := src.Uint64()
+		src.Seed(defaultSeed)
+		if w := src.Uint64(); v != w {
+			t.Error(tserr.Equal(&tserr.EqualArgs{Var: name + " Uint64 after Seed", Actual: int64(w), Want: int64(v)}))
+		}
+		// The test fails, if Err returns an error
+		if src.Err() != nil {
+			t.Error(tserr.NotAvailable(&tserr.NotAvailableArgs{S: name, Err: src.Err()}))
+		}
+		// Perform tests on the random number generator
+		testRand(t, rnd)
+	}
+}
+
+// TestSourceFromV2Err tests that an unsupported Seed and a nil source result in an error.
+func TestSourceFromV2Err(t *testing.T) {
+	// Wrap a math/rand/v2 source without Seed support
+	src := NewSourceFromV2(randv2.NewZipf(randv2.New(randv2.NewPCG(1, 2)), 1.5, 1, 10))
+	// The test fails, if Seed does not result in an error
+	if src.Seed(defaultSeed); src.Err() == nil {
+		t.Error(tserr.NilFailed("Err after Seed"))
+	}
+	// The test fails, if New does not return an error for a wrapped nil source
+	if _, err := New(NewSourceFromV2(nil)); err == nil {
+		t.Error(tserr.NilFailed("New"))
+	}
+}
+
+// BenchmarkSourceFromV2 performs a benchmark on the wrapped math/rand/v2 ChaCha8 source
+func BenchmarkSourceFromV2(b *testing.B) {
+	// Retrieve the random number generator
+	rnd, err := New(NewSourceFromV2(randv2.NewChaCha8([32]byte{})))
+	// The test fails if an error occurs
+	if err != nil {
+		b.Fatal(tserr.NotAvailable(&tserr.NotAvailableArgs{S: "NewSourceFromV2", Err: err}))
+	}
+	benchRandUint(b, rnd)
+}
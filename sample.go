@@ -0,0 +1,326 @@
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tsrand
+
+// Import standard library packages and tserr
+import (
+	"container/heap" // container/heap
+	"fmt"            // fmt
+	"math"           // math
+
+	"github.com/thorstenrie/tserr" // tserr
+)
+
+// Random is the interface of the random number generator used by the sampling helpers. It is implemented by each Source and by
+// *rand.Rand retrieved from the interface functions, e.g., NewCryptoRand or New. The sampling helpers are safe for concurrent use
+// by multiple goroutines only if the Random is.
+type Random interface {
+	Uint64() uint64
+}
+
+// Shuffle pseudo-randomizes the order of the elements of s in place using r. Each permutation is equally likely.
+// It is based on the Fisher-Yates shuffle. It returns an error, if r is nil. In this case, s is not modified.
+func Shuffle[T any](r Random, s []T) error {
+	// Return an error, if r is nil
+	if r == nil {
+		return tserr.NilPtr()
+	}
+	shuffle(r, s)
+	return nil
+}
+
+// shuffle randomizes the order of the elements of s in place using r with the Fisher-Yates shuffle.
+func shuffle[T any](r Random, s []T) {
+	for i := len(s) - 1; i > 0; i-- {
+		j := uint64n(r, uint64(i+1))
+		s[i], s[j] = s[j], s[i]
+	}
+}
+
+// Choice returns an element of s selected uniformly at random using r. It returns an error, if r is nil or s is empty.
+func Choice[T any](r Random, s []T) (T, error) {
+	var zero T
+	// Return an error, if r is nil
+	if r == nil {
+		return zero, tserr.NilPtr()
+	}
+	// Return an error, if s is empty
+	if len(s) == 0 {
+		return zero, tserr.Empty("s")
+	}
+	// Return random element
+	return s[uint64n(r, uint64(len(s)))], nil
+}
+
+// Sample returns k elements of s selected uniformly at random without replacement using r in random order. Each subset of size k
+// and each of its orders are equally likely. s is not modified. It returns an error, if r is nil, k is negative or higher than the length of s.
+// For k much smaller than the length of s, it uses the algorithm of Floyd, otherwise a partial Fisher-Yates shuffle of a copy of s.
+func Sample[T any](r Random, s []T, k int) ([]T, error) {
+	// Return an error, if r is nil
+	if r == nil {
+		return nil, tserr.NilPtr()
+	}
+	// Return an error, if k is invalid
+	if e := checkK(k, len(s)); e != nil {
+		return nil, e
+	}
+	n := len(s)
+	res := make([]T, k)
+	// Partial Fisher-Yates shuffle of a copy of the indices, if k is not small
+	if k > n/8 {
+		c := make([]T, n)
+		copy(c, s)
+		for i := 0; i < k; i++ {
+			j := i + int(uint64n(r, uint64(n-i)))
+			c[i], c[j] = c[j], c[i]
+		}
+		copy(res, c[:k])
+		return res, nil
+	}
+	// Algorithm of Floyd selecting k distinct indices with k random numbers
+	sel := make(map[int]struct{}, k)
+	i := 0
+	for j := n - k; j < n; j++ {
+		t := int(uint64n(r, uint64(j+1)))
+		if _, ok := sel[t]; ok {
+			t = j
+		}
+		sel[t] = struct{}{}
+		res[i] = s[t]
+		i++
+	}
+	// Shuffle the selected elements, since the order of Floyd's algorithm is not uniformly random
+	shuffle(r, res)
+	return res, nil
+}
+
+// WeightedSample returns k elements of s selected at random without replacement using r, where the probability of an element to
+// be selected next is proportional to its weight among the remaining elements. The elements are returned in the order of selection.
+// An element with weight 0 is never selected. s and weights are not modified. It returns an error, if r is nil, the lengths of s and weights
+// differ, a weight is negative, NaN or infinite, k is negative or k is higher than the number of elements with a weight higher than 0.
+// It is based on the algorithm A-Res of Efraimidis and Spirakis.
+func WeightedSample[T any](r Random, s []T, weights []float64, k int) ([]T, error) {
+	// Return an error, if r is nil
+	if r == nil {
+		return nil, tserr.NilPtr()
+	}
+	// Return an error, if the lengths of s and weights differ
+	if len(s) != len(weights) {
+		return nil, tserr.Equal(&tserr.EqualArgs{Var: "length of weights", Actual: int64(len(weights)), Want: int64(len(s))})
+	}
+	// Return an error, if a weight is invalid and count elements with a weight higher than 0
+	m := 0
+	for i, w := range weights {
+		if math.IsNaN(w) || math.IsInf(w, 0) || w < 0 {
+			return nil, tserr.Check(&tserr.CheckArgs{F: fmt.Sprintf("weights[%d]", i), Err: fmt.Errorf("value is %v, but expected to be finite and higher than or equal to 0", w)})
+		}
+		if w > 0 {
+			m++
+		}
+	}
+	// Return an error, if k is invalid
+	if e := checkK(k, m); e != nil {
+		return nil, e
+	}
+	// Select the k elements with the largest keys log(U)/w with a min-heap
+	h := make(keyHeap, 0, k)
+	for i, w := range weights {
+		if w == 0 {
+			continue
+		}
+		key := math.Log(float64Open(r)) / w
+		if len(h) < k {
+			heap.Push(&h, keyItem{key: key, i: i})
+		} else if k > 0 && key > h[0].key {
+			h[0] = keyItem{key: key, i: i}
+			heap.Fix(&h, 0)
+		}
+	}
+	// Return the selected elements in descending order of their keys
+	res := make([]T, len(h))
+	for j := len(h) - 1; j >= 0; j-- {
+		res[j] = s[heap.Pop(&h).(keyItem).i]
+	}
+	return res, nil
+}
+
+// Reservoir selects k elements uniformly at random without replacement from a stream of unknown length. Each element is added with Add.
+// After n added elements, each subset of size min(k, n) of the added elements is equally likely. Reservoir holds r, the reservoir and the
+// number of added elements. It is based on algorithm R of Vitter and uses one random number for each added element. Reservoir is not safe
+// for concurrent use by multiple goroutines.
+type Reservoir[T any] struct {
+	r Random // random number generator
+	s []T    // reservoir
+	k int    // size of the reservoir
+	n uint64 // number of added elements
+}
+
+// NewReservoir returns a new instance of Reservoir for k elements using r. It returns an error, if r is nil or k is lower than 1.
+func NewReservoir[T any](r Random, k int) (*Reservoir[T], error) {
+	// Return an error, if r or k is invalid
+	if e := checkReservoir(r, k); e != nil {
+		return nil, e
+	}
+	// Return Reservoir
+	return &Reservoir[T]{r: r, s: make([]T, 0, k), k: k}, nil
+}
+
+// Add adds element v of the stream.
+func (res *Reservoir[T]) Add(v T) {
+	res.n++
+	// Fill the reservoir with the first k elements
+	if len(res.s) < res.k {
+		res.s = append(res.s, v)
+		return
+	}
+	// Replace an element of the reservoir with probability k/n
+	if j := uint64n(res.r, res.n); j < uint64(res.k) {
+		res.s[j] = v
+	}
+}
+
+// Count returns the number of added elements.
+func (res *Reservoir[T]) Count() uint64 {
+	return res.n
+}
+
+// Sample returns a copy of the selected elements. The order of the elements is not random.
+func (res *Reservoir[T]) Sample() []T {
+	c := make([]T, len(res.s))
+	copy(c, res.s)
+	return c
+}
+
+// ReservoirL selects k elements uniformly at random without replacement from a stream of unknown length like Reservoir. It is based on
+// algorithm L of Li, which computes the number of elements to skip before the next element is selected. Therefore, it uses random numbers
+// only for selected elements, which are O(k*(1+log(n/k))) for n added elements. Elements, which will be skipped anyway, may be discarded without
+// reading them with Discard. Skip returns their number. ReservoirL is not safe for concurrent use by multiple goroutines.
+type ReservoirL[T any] struct {
+	r    Random  // random number generator
+	s    []T     // reservoir
+	k    int     // size of the reservoir
+	n    uint64  // number of added elements
+	next uint64  // number of added elements before the next selected element
+	w    float64 // parameter W of algorithm L
+}
+
+// NewReservoirL returns a new instance of ReservoirL for k elements using r. It returns an error, if r is nil or k is lower than 1.
+func NewReservoirL[T any](r Random, k int) (*ReservoirL[T], error) {
+	// Return an error, if r or k is invalid
+	if e := checkReservoir(r, k); e != nil {
+		return nil, e
+	}
+	// Return ReservoirL
+	return &ReservoirL[T]{r: r, s: make([]T, 0, k), k: k}, nil
+}
+
+// Add adds element v of the stream.
+func (res *ReservoirL[T]) Add(v T) {
+	res.n++
+	// Fill the reservoir with the first k elements and initialize W
+	if len(res.s) < res.k {
+		res.s = append(res.s, v)
+		if len(res.s) == res.k {
+			res.w = math.Exp(math.Log(float64Open(res.r)) / float64(res.k))
+			res.skip()
+		}
+		return
+	}
+	// Skip the element, if it is not selected
+	if res.n < res.next {
+		return
+	}
+	// Replace a random element of the reservoir and compute the next selected element
+	res.s[uint64n(res.r, uint64(res.k))] = v
+	res.w *= math.Exp(math.Log(float64Open(res.r)) / float64(res.k))
+	res.skip()
+}
+
+// skip computes the number of added elements before the next selected element.
+func (res *ReservoirL[T]) skip() {
+	s := math.Floor(math.Log(float64Open(res.r)) / math.Log1p(-res.w))
+	if s >= float64(math.MaxUint64-res.n) {
+		res.next = math.MaxUint64
+		return
+	}
+	res.next = res.n + uint64(s) + 1
+}
+
+// Skip returns the number of following elements of the stream, which will not be selected. They may be discarded with Discard instead of Add.
+func (res *ReservoirL[T]) Skip() uint64 {
+	if len(res.s) < res.k {
+		return 0
+	}
+	return res.next - res.n - 1
+}
+
+// Discard discards the following n elements of the stream, which are counted as added, but not selected. n must not be higher than Skip().
+// It returns an error, if n is higher than Skip(). In this case, no element is discarded.
+func (res *ReservoirL[T]) Discard(n uint64) error {
+	// Return an error, if n elements cannot be discarded
+	if s := res.Skip(); n > s {
+		return tserr.Forbidden(fmt.Sprintf("Discard of %d elements, only %d elements are skipped", n, s))
+	}
+	res.n += n
+	return nil
+}
+
+// Count returns the number of added and discarded elements.
+func (res *ReservoirL[T]) Count() uint64 {
+	return res.n
+}
+
+// Sample returns a copy of the selected elements. The order of the elements is not random.
+func (res *ReservoirL[T]) Sample() []T {
+	c := make([]T, len(res.s))
+	copy(c, res.s)
+	return c
+}
+
+// keyItem is an element of keyHeap with key and index i.
+type keyItem struct {
+	key float64 // key of the element
+	i   int     // index of the element
+}
+
+// keyHeap implements heap.Interface as min-heap of keyItem ordered by key.
+type keyHeap []keyItem
+
+func (h keyHeap) Len() int           { return len(h) }
+func (h keyHeap) Less(i, j int) bool { return h[i].key < h[j].key }
+func (h keyHeap) Swap(i, j int)      { h[i], h[j] = h[j], h[i] }
+func (h *keyHeap) Push(x any)        { *h = append(*h, x.(keyItem)) }
+func (h *keyHeap) Pop() any {
+	old := *h
+	x := old[len(old)-1]
+	*h = old[:len(old)-1]
+	return x
+}
+
+// checkK returns an error, if k is negative or higher than n.
+func checkK(k, n int) error {
+	if k < 0 {
+		return tserr.Higher(&tserr.HigherArgs{Var: "k", Actual: int64(k), LowerBound: 0})
+	}
+	if k > n {
+		return tserr.Higher(&tserr.HigherArgs{Var: "number of elements", Actual: int64(n), LowerBound: int64(k)})
+	}
+	return nil
+}
+
+// checkReservoir returns an error, if r is nil or k is lower than 1.
+func checkReservoir(r Random, k int) error {
+	if r == nil {
+		return tserr.NilPtr()
+	}
+	if k < 1 {
+		return tserr.Higher(&tserr.HigherArgs{Var: "k", Actual: int64(k), LowerBound: 1})
+	}
+	return nil
+}
+
+// float64Open returns a random number of r in the open interval (0,1) with 53 bits of precision.
+func float64Open(r Random) float64 {
+	return (float64(r.Uint64()>>11) + 0.5) * 0x1.0p-53
+}
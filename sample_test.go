@@ -0,0 +1,400 @@
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tsrand
+
+// Import standard library packages and tserr
+import (
+	"fmt"     // fmt
+	"math"    // math
+	"testing" // testing
+
+	"github.com/thorstenrie/tserr" // tserr
+)
+
+const (
+	testSampleItr int     = 240000 // number of iterations for sampling tests
+	minP          float64 = 0.001  // minimum p-value of chi-square tests
+)
+
+// testSampleSource returns a seeded PCG64Source for the sampling tests.
+func testSampleSource() *PCG64Source {
+	src := NewPCG64Source()
+	src.Seed(defaultSeed)
+	return src
+}
+
+// testChiSquare tests counts of observed categories against the expected counts with a chi-square test. The test fails, if
+// a category is missing or the p-value is lower than minP.
+func testChiSquare(t *testing.T, name string, counts map[int]int, want map[int]float64) {
+	// Panic if t is nil
+	if t == nil {
+		panic("nil pointer")
+	}
+	// The test fails, if a category is observed, which is not expected
+	for c := range counts {
+		if _, ok := want[c]; !ok {
+			t.Error(tserr.Forbidden(fmt.Sprintf("%s category %d", name, c)))
+		}
+	}
+	// Chi-square statistic
+	x := 0.0
+	for c, w := range want {
+		d := float64(counts[c]) - w
+		x += d * d / w
+	}
+	// The test fails, if the p-value is lower than minP
+	if p := chiSquareP(x, float64(len(want)-1)); p < minP {
+		t.Error(tserr.Higher(&tserr.HigherArgs{Var: name + " p-value in thousandths", Actual: int64(p * 1000), LowerBound: int64(minP * 1000)}))
+	}
+}
+
+// chiSquareP returns the upper tail probability of the chi-square distribution with df degrees of freedom at x based on the
+// approximation of Wilson and Hilferty.
+func chiSquareP(x, df float64) float64 {
+	v := 2 / (9 * df)
+	z := (math.Cbrt(x/df) - (1 - v)) / math.Sqrt(v)
+	return math.Erfc(z/math.Sqrt2) / 2
+}
+
+// TestShuffle tests that Shuffle returns each permutation of four elements with equal probability using a Source and a rand.Rand.
+func TestShuffle(t *testing.T) {
+	// The test fails, if New returns an error
+	rnd, e := New(testSampleSource())
+	if e != nil {
+		t.Fatal(tserr.NotAvailable(&tserr.NotAvailableArgs{S: "PCG64Source", Err: e}))
+	}
+	for name, r := range map[string]Random{"Source": testSampleSource(), "rand.Rand": rnd} {
+		counts := make(map[int]int)
+		for i := 0; i < testSampleItr; i++ {
+			s := []int{0, 1, 2, 3}
+			if e := Shuffle(r, s); e != nil {
+				t.Fatal(tserr.Op(&tserr.OpArgs{Op: "Shuffle", Fn: name, Err: e}))
+			}
+			counts[s[0]<<6|s[1]<<4|s[2]<<2|s[3]]++
+		}
+		// Expected counts of the 24 permutations
+		want := make(map[int]float64)
+		for _, p := range testPermutations([]int{0, 1, 2, 3}) {
+			want[p[0]<<6|p[1]<<4|p[2]<<2|p[3]] = float64(testSampleItr) / 24
+		}
+		testChiSquare(t, "Shuffle with "+name, counts, want)
+	}
+	// Shuffle of empty and single element slices must not panic or return an error
+	for _, s := range [][]int{{}, {1}} {
+		if e := Shuffle(testSampleSource(), s); e != nil {
+			t.Error(tserr.Op(&tserr.OpArgs{Op: "Shuffle", Fn: fmt.Sprint(s), Err: e}))
+		}
+	}
+}
+
+// testPermutations returns all permutations of s.
+func testPermutations(s []int) [][]int {
+	if len(s) <= 1 {
+		return [][]int{append([]int{}, s...)}
+	}
+	var res [][]int
+	for i := range s {
+		rest := append(append([]int{}, s[:i]...), s[i+1:]...)
+		for _, p := range testPermutations(rest) {
+			res = append(res, append([]int{s[i]}, p...))
+		}
+	}
+	return res
+}
+
+// TestChoice tests that Choice returns each element with equal probability and returns an error for an empty slice.
+func TestChoice(t *testing.T) {
+	r := testSampleSource()
+	s := []int{0, 1, 2, 3, 4, 5}
+	counts, want := make(map[int]int), make(map[int]float64)
+	for _, v := range s {
+		want[v] = float64(testSampleItr) / float64(len(s))
+	}
+	for i := 0; i < testSampleItr; i++ {
+		// The test fails, if Choice returns an error
+		v, e := Choice(r, s)
+		if e != nil {
+			t.Fatal(tserr.Op(&tserr.OpArgs{Op: "Choice", Fn: "s", Err: e}))
+		}
+		counts[v]++
+	}
+	testChiSquare(t, "Choice", counts, want)
+	// The test fails, if Choice does not return an error for an empty slice
+	if _, e := Choice(r, []int{}); e == nil {
+		t.Error(tserr.NilFailed("Choice of empty slice"))
+	}
+}
+
+// TestSample tests that Sample returns each ordered selection of k distinct elements with equal probability for the partial
+// Fisher-Yates shuffle and the algorithm of Floyd.
+func TestSample(t *testing.T) {
+	r := testSampleSource()
+	// n = 5 uses the partial Fisher-Yates shuffle, n = 16 uses the algorithm of Floyd
+	for _, n := range []int{5, 16} {
+		s := make([]int, n)
+		for i := range s {
+			s[i] = i
+		}
+		counts, want := make(map[int]int), make(map[int]float64)
+		for i := 0; i < n; i++ {
+			for j := 0; j < n; j++ {
+				if i != j {
+					want[i*n+j] = float64(testSampleItr) / float64(n*(n-1))
+				}
+			}
+		}
+		for i := 0; i < testSampleItr; i++ {
+			// The test fails, if Sample returns an error
+			v, e := Sample(r, s, 2)
+			if e != nil {
+				t.Fatal(tserr.Op(&tserr.OpArgs{Op: "Sample", Fn: "s", Err: e}))
+			}
+			counts[v[0]*n+v[1]]++
+		}
+		testChiSquare(t, fmt.Sprintf("Sample of %d elements", n), counts, want)
+		// The test fails, if s is modified
+		for i, v := range s {
+			if v != i {
+				t.Fatal(tserr.Equal(&tserr.EqualArgs{Var: fmt.Sprintf("s[%d]", i), Actual: int64(v), Want: int64(i)}))
+			}
+		}
+	}
+	// The test fails, if Sample does not return all elements for k equal to the length of s
+	if v, e := Sample(r, []int{0, 1, 2}, 3); e != nil || len(v) != 3 || v[0]+v[1]+v[2] != 3 {
+		t.Error(tserr.Return(&tserr.ReturnArgs{Op: "Sample of all elements", Actual: fmt.Sprint(v), Want: "permutation of [0 1 2]"}))
+	}
+	// The test fails, if Sample does not return an empty slice for k = 0
+	if v, e := Sample(r, []int{0, 1, 2}, 0); e != nil || len(v) != 0 {
+		t.Error(tserr.Equal(&tserr.EqualArgs{Var: "length of Sample with k = 0", Actual: int64(len(v)), Want: 0}))
+	}
+	// The test fails, if Sample does not return an error for an invalid k
+	for _, k := range []int{-1, 4} {
+		if _, e := Sample(r, []int{0, 1, 2}, k); e == nil {
+			t.Error(tserr.NilFailed(fmt.Sprintf("Sample with k = %d", k)))
+		}
+	}
+}
+
+// TestWeightedSample tests that the first element selected by WeightedSample is proportional to its weight, the second element
+// is proportional to its weight among the remaining elements, an element with weight 0 is never selected and invalid arguments return an error.
+func TestWeightedSample(t *testing.T) {
+	r := testSampleSource()
+	s, w := []int{0, 1, 2, 3, 4}, []float64{1, 2, 3, 0, 4}
+	first, second := make(map[int]int), make(map[int]int)
+	for i := 0; i < testSampleItr; i++ {
+		// The test fails, if WeightedSample returns an error
+		v, e := WeightedSample(r, s, w, 2)
+		if e != nil {
+			t.Fatal(tserr.Op(&tserr.OpArgs{Op: "WeightedSample", Fn: "s", Err: e}))
+		}
+		first[v[0]]++
+		// Count the second element given the first element is 4
+		if v[0] == 4 {
+			second[v[1]]++
+		}
+	}
+	n := float64(testSampleItr)
+	testChiSquare(t, "first element of WeightedSample", first, map[int]float64{0: n / 10, 1: n * 2 / 10, 2: n * 3 / 10, 4: n * 4 / 10})
+	m := float64(first[4])
+	testChiSquare(t, "second element of WeightedSample", second, map[int]float64{0: m / 6, 1: m * 2 / 6, 2: m * 3 / 6})
+	// The test fails, if WeightedSample does not return all elements with a weight higher than 0
+	if v, e := WeightedSample(r, s, w, 4); e != nil || len(v) != 4 || v[0]+v[1]+v[2]+v[3] != 7 {
+		t.Error(tserr.Return(&tserr.ReturnArgs{Op: "WeightedSample of all elements", Actual: fmt.Sprint(v), Want: "permutation of [0 1 2 4]"}))
+	}
+	// The test fails, if WeightedSample does not return an error for invalid arguments
+	for name, a := range map[string]struct {
+		w []float64
+		k int
+	}{
+		"length":     {[]float64{1, 2}, 1},
+		"negative":   {[]float64{1, 2, -1}, 1},
+		"NaN":        {[]float64{1, math.NaN(), 1}, 1},
+		"Inf":        {[]float64{1, math.Inf(1), 1}, 1},
+		"k":          {[]float64{1, 0, 1}, 3},
+		"negative k": {[]float64{1, 2, 3}, -1},
+	} {
+		if _, e := WeightedSample(r, []int{0, 1, 2}, a.w, a.k); e == nil {
+			t.Error(tserr.NilFailed("WeightedSample with invalid " + name))
+		}
+	}
+}
+
+// TestSampleNil tests that Shuffle, Choice, Sample and WeightedSample return an error for a nil Random and do not modify s.
+func TestSampleNil(t *testing.T) {
+	s := []int{0, 1, 2, 3}
+	// The test fails, if a helper does not return an error
+	if e := Shuffle(nil, s); e == nil {
+		t.Error(tserr.NilFailed("Shuffle with nil"))
+	}
+	if _, e := Choice(nil, s); e == nil {
+		t.Error(tserr.NilFailed("Choice with nil"))
+	}
+	if _, e := Sample(nil, s, 2); e == nil {
+		t.Error(tserr.NilFailed("Sample with nil"))
+	}
+	if _, e := WeightedSample(nil, s, []float64{1, 1, 1, 1}, 2); e == nil {
+		t.Error(tserr.NilFailed("WeightedSample with nil"))
+	}
+	// The test fails, if s is modified
+	if s[0] != 0 || s[1] != 1 || s[2] != 2 || s[3] != 3 {
+		t.Error(tserr.NotEqualStr(&tserr.NotEqualStrArgs{X: fmt.Sprint(s), Y: "[0 1 2 3]"}))
+	}
+}
+
+// testSubsets tests that the sample of a reservoir for k = 3 of a stream of 8 elements contains each of the 56 subsets with equal
+// probability. add adds the elements of the stream to a new reservoir and returns its sample.
+func testSubsets(t *testing.T, name string, add func(s []int) []int) {
+	// Panic if t is nil
+	if t == nil {
+		panic("nil pointer")
+	}
+	s := []int{0, 1, 2, 3, 4, 5, 6, 7}
+	counts, want := make(map[int]int), make(map[int]float64)
+	for i := 0; i < 1<<len(s); i++ {
+		if c := bitCount(i); c == 3 {
+			want[i] = float64(testSampleItr) / 56
+		}
+	}
+	for i := 0; i < testSampleItr; i++ {
+		m := 0
+		for _, v := range add(s) {
+			m |= 1 << v
+		}
+		counts[m]++
+	}
+	testChiSquare(t, name, counts, want)
+}
+
+// bitCount returns the number of set bits of i.
+func bitCount(i int) int {
+	c := 0
+	for ; i > 0; i >>= 1 {
+		c += i & 1
+	}
+	return c
+}
+
+// TestReservoir tests that Reservoir selects each subset with equal probability and returns an error for invalid arguments.
+func TestReservoir(t *testing.T) {
+	r := testSampleSource()
+	testSubsets(t, "Reservoir", func(s []int) []int {
+		// The test fails, if NewReservoir returns an error
+		res, e := NewReservoir[int](r, 3)
+		if e != nil {
+			t.Fatal(tserr.Op(&tserr.OpArgs{Op: "NewReservoir", Fn: "k", Err: e}))
+		}
+		for _, v := range s {
+			res.Add(v)
+		}
+		// The test fails, if Count does not return the number of added elements
+		if res.Count() != uint64(len(s)) {
+			t.Fatal(tserr.Equal(&tserr.EqualArgs{Var: "Count", Actual: int64(res.Count()), Want: int64(len(s))}))
+		}
+		return res.Sample()
+	})
+	// The test fails, if the sample of a short stream does not contain all elements
+	res, _ := NewReservoir[int](r, 3)
+	if res.Add(1); len(res.Sample()) != 1 {
+		t.Error(tserr.Equal(&tserr.EqualArgs{Var: "length of Sample", Actual: int64(len(res.Sample())), Want: 1}))
+	}
+	// The test fails, if NewReservoir does not return an error for invalid arguments
+	if _, e := NewReservoir[int](nil, 3); e == nil {
+		t.Error(tserr.NilFailed("NewReservoir with nil"))
+	}
+	if _, e := NewReservoir[int](r, 0); e == nil {
+		t.Error(tserr.NilFailed("NewReservoir with k = 0"))
+	}
+}
+
+// TestReservoirL tests that ReservoirL selects each subset with equal probability, if all elements are added and if skipped
+// elements are discarded, and returns an error for invalid arguments.
+func TestReservoirL(t *testing.T) {
+	r := testSampleSource()
+	for _, discard := range []bool{false, true} {
+		testSubsets(t, fmt.Sprintf("ReservoirL with discard %v", discard), func(s []int) []int {
+			// The test fails, if NewReservoirL returns an error
+			res, e := NewReservoirL[int](r, 3)
+			if e != nil {
+				t.Fatal(tserr.Op(&tserr.OpArgs{Op: "NewReservoirL", Fn: "k", Err: e}))
+			}
+			for i := 0; i < len(s); i++ {
+				// Discard skipped elements, if any
+				if n := min(res.Skip(), uint64(len(s)-i)); discard && n > 0 {
+					if e := res.Discard(n); e != nil {
+						t.Fatal(tserr.Op(&tserr.OpArgs{Op: "Discard", Fn: "n", Err: e}))
+					}
+					i += int(n) - 1
+					continue
+				}
+				res.Add(s[i])
+			}
+			// The test fails, if Count does not return the number of added and discarded elements
+			if res.Count() != uint64(len(s)) {
+				t.Fatal(tserr.Equal(&tserr.EqualArgs{Var: "Count", Actual: int64(res.Count()), Want: int64(len(s))}))
+			}
+			return res.Sample()
+		})
+	}
+	// The test fails, if Discard does not return an error for more elements than skipped
+	res, _ := NewReservoirL[int](r, 3)
+	if e := res.Discard(1); e == nil {
+		t.Error(tserr.NilFailed("Discard of a not filled reservoir"))
+	}
+	// The test fails, if NewReservoirL does not return an error for invalid arguments
+	if _, e := NewReservoirL[int](nil, 3); e == nil {
+		t.Error(tserr.NilFailed("NewReservoirL with nil"))
+	}
+	if _, e := NewReservoirL[int](r, 0); e == nil {
+		t.Error(tserr.NilFailed("NewReservoirL with k = 0"))
+	}
+}
+
+// BenchmarkShuffle performs a benchmark on Shuffle of 1000 elements.
+func BenchmarkShuffle(b *testing.B) {
+	r, s := testSampleSource(), make([]int, 1000)
+	for i := 0; i < b.N; i++ {
+		if e := Shuffle(r, s); e != nil {
+			b.Fatal(tserr.Op(&tserr.OpArgs{Op: "Shuffle", Fn: "PCG64Source", Err: e}))
+		}
+	}
+}
+
+// BenchmarkSample performs a benchmark on Sample of 10 and 500 elements of 1000 elements.
+func BenchmarkSample(b *testing.B) {
+	r, s := testSampleSource(), make([]int, 1000)
+	for _, k := range []int{10, 500} {
+		b.Run(fmt.Sprintf("k=%d", k), func(b *testing.B) {
+			for i := 0; i < b.N; i++ {
+				Sample(r, s, k)
+			}
+		})
+	}
+}
+
+// BenchmarkWeightedSample performs a benchmark on WeightedSample of 10 elements of 1000 elements.
+func BenchmarkWeightedSample(b *testing.B) {
+	r, s, w := testSampleSource(), make([]int, 1000), make([]float64, 1000)
+	for i := range w {
+		w[i] = float64(i + 1)
+	}
+	for i := 0; i < b.N; i++ {
+		WeightedSample(r, s, w, 10)
+	}
+}
+
+// BenchmarkReservoir performs a benchmark on Add of Reservoir and ReservoirL with k = 10.
+func BenchmarkReservoir(b *testing.B) {
+	b.Run("R", func(b *testing.B) {
+		res, _ := NewReservoir[int](testSampleSource(), 10)
+		for i := 0; i < b.N; i++ {
+			res.Add(i)
+		}
+	})
+	b.Run("L", func(b *testing.B) {
+		res, _ := NewReservoirL[int](testSampleSource(), 10)
+		for i := 0; i < b.N; i++ {
+			res.Add(i)
+		}
+	})
+}
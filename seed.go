@@ -0,0 +1,75 @@
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tsrand
+
+// Import standard library packages
+import (
+	"encoding/binary" // encoding/binary
+)
+
+// ByteSeeder is implemented by sources, which can be initialized with a seed b of arbitrary length.
+// The seed b is expanded to the full state of the source with a SeedExpander.
+type ByteSeeder interface {
+	SeedBytes(b []byte)
+}
+
+// SeedExpander expands a single seed into an arbitrary number of 64-bit words, which can be used to
+// initialize the full state of a pseudo-random number generator. It is based on splitmix64, which is
+// recommended by the authors of the xoshiro generators for this purpose. Each output of splitmix64 passes
+// through a strong mixing function. Therefore, adjacent seeds like 1 and 2 result in uncorrelated states.
+// A SeedExpander is not safe for concurrent use by multiple goroutines.
+type SeedExpander struct {
+	x uint64 // state of splitmix64
+}
+
+// NewSeedExpander returns a new instance of SeedExpander for seed s.
+func NewSeedExpander(s int64) *SeedExpander {
+	return &SeedExpander{x: uint64(s)}
+}
+
+// NewSeedExpanderBytes returns a new instance of SeedExpander for seed b. The seed b may have an arbitrary length.
+// It is read in little-endian 64-bit words, the last word is padded with zeros. Each word is absorbed into the state
+// of splitmix64 by one mixing step. The length of b is absorbed first, so that seeds differing only by trailing zeros
+// result in different states.
+func NewSeedExpanderBytes(b []byte) *SeedExpander {
+	// Absorb the length of b
+	h := uint64(len(b))
+	h = splitmix64(&h)
+	// Absorb b in 64-bit words
+	for len(b) > 0 {
+		var w [8]byte
+		n := copy(w[:], b)
+		b = b[n:]
+		h ^= binary.LittleEndian.Uint64(w[:])
+		h = splitmix64(&h)
+	}
+	// Return a new SeedExpander with the absorbed state
+	return &SeedExpander{x: h}
+}
+
+// Uint64 returns the next expanded 64-bit word.
+func (e *SeedExpander) Uint64() uint64 {
+	return splitmix64(&e.x)
+}
+
+// Fill fills state with expanded 64-bit words.
+func (e *SeedExpander) Fill(state []uint64) {
+	for i := range state {
+		state[i] = e.Uint64()
+	}
+}
+
+// Fill32 fills state with expanded 32-bit words. Each expanded 64-bit word provides two 32-bit words,
+// the lower half first.
+func (e *SeedExpander) Fill32(state []uint32) {
+	var w uint64
+	for i := range state {
+		if i%2 == 0 {
+			w = e.Uint64()
+		} else {
+			w >>= 32
+		}
+		state[i] = uint32(w)
+	}
+}
@@ -0,0 +1,243 @@
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tsrand
+
+// Import standard library packages
+import (
+	crand "crypto/rand" // crypto/rand
+	"encoding/binary"   // encoding/binary
+	"math/bits"         // math/bits
+)
+
+// ChaChaSource implements Source64 and can be used as source for a rand.Rand. It is based on the ChaCha stream cipher
+// as specified in RFC 8439 and returns its keystream. ChaChaSource holds the input block of the cipher consisting of the constants,
+// the 256-bit key, a 64-bit block counter and a 64-bit stream id, the current keystream block and the last occurring error, if any.
+// The number of rounds is 20 for NewChaCha20Source and 8 for NewChaCha8Source. If seeded with a key from crypto/rand, a ChaChaSource
+// provides a cryptographically secure random number generator source. If seeded with Seed or SeedKey, the output is reproducible
+// and only as secure as the provided seed. A ChaChaSource is not safe for concurrent use by multiple goroutines.
+type ChaChaSource struct {
+	input  [16]uint32 // input block: constants, key, block counter and stream id
+	block  [16]uint32 // current keystream block
+	idx    int        // index of the next unused word of the keystream block
+	rounds int        // number of rounds
+	e      error      // last error occurring, if any
+}
+
+// Parameters of the ChaCha stream cipher
+var (
+	chachac = struct {
+		constants         [4]uint32
+		rounds8, rounds20 int
+	}{
+		constants: [4]uint32{0x61707865, 0x3320646e, 0x79622d32, 0x6b206574}, // "expand 32-byte k"
+		rounds8:   8,                                                         // rounds of ChaCha8
+		rounds20:  20,                                                        // rounds of ChaCha20
+	}
+)
+
+// NewChaCha20Source returns a new instance of ChaChaSource using 20 rounds. It is seeded with a key read from crypto/rand.
+// If crypto/rand is not available on the platform, Err returns an error. It can be reseeded with Seed, SeedBytes or SeedKey
+// for a reproducible output. A ChaChaSource is not safe for concurrent use by multiple goroutines.
+func NewChaCha20Source() *ChaChaSource {
+	src := &ChaChaSource{rounds: chachac.rounds20}
+	src.SeedCrypto()
+	return src
+}
+
+// NewChaCha8Source returns a new instance of ChaChaSource using 8 rounds. ChaCha8 is faster than ChaCha20 and
+// still considered secure, but provides a lower security margin. It is seeded with a key read from crypto/rand.
+// If crypto/rand is not available on the platform, Err returns an error. It can be reseeded with Seed, SeedBytes or SeedKey
+// for a reproducible output. A ChaChaSource is not safe for concurrent use by multiple goroutines.
+func NewChaCha8Source() *ChaChaSource {
+	src := &ChaChaSource{rounds: chachac.rounds8}
+	src.SeedCrypto()
+	return src
+}
+
+// Seed initializes the key with the expanded seed s and selects stream 0. The output is reproducible,
+// but with only 64 bits of seed it is unsuitable for security-sensitive services.
+func (src *ChaChaSource) Seed(s int64) {
+	// Initialization of the key with the expanded seed s
+	src.expand(NewSeedExpander(s))
+}
+
+// SeedBytes initializes the key with the expanded seed b and selects stream 0. The seed b may have an arbitrary length.
+func (src *ChaChaSource) SeedBytes(b []byte) {
+	// Initialization of the key with the expanded seed b
+	src.expand(NewSeedExpanderBytes(b))
+}
+
+// expand initializes the key with four words of e and selects stream 0.
+func (src *ChaChaSource) expand(e *SeedExpander) {
+	var key [32]byte
+	for i := 0; i < len(key); i += 8 {
+		binary.LittleEndian.PutUint64(key[i:], e.Uint64())
+	}
+	src.SeedKey(key, 0)
+}
+
+// SeedKey initializes the source with the 256-bit key and the 64-bit stream id. The block counter is reset to zero.
+// The same key and stream id result in the same output. Different stream ids result in independent keystreams for the same key.
+// SeedKey resets the last error.
+func (src *ChaChaSource) SeedKey(key [32]byte, stream uint64) {
+	// Set constants
+	copy(src.input[0:4], chachac.constants[:])
+	// Set key in little-endian words
+	for i := 0; i < 8; i++ {
+		src.input[4+i] = binary.LittleEndian.Uint32(key[4*i:])
+	}
+	// Reset block counter
+	src.input[12], src.input[13] = 0, 0
+	// Set stream id
+	src.input[14], src.input[15] = uint32(stream), uint32(stream>>32)
+	// Discard the current keystream block
+	src.idx = len(src.block)
+	src.e = nil
+}
+
+// SeedCrypto initializes the source with a key read from crypto/rand and stream 0. If crypto/rand
+// is not available on the platform, a subsequent call of Err returns an error.
+func (src *ChaChaSource) SeedCrypto() {
+	var key [32]byte
+	// Read key from crypto/rand
+	_, e := crand.Read(key[:])
+	// Initialize source with key and stream 0
+	src.SeedKey(key, 0)
+	// Store error after SeedKey reset the error
+	src.e = e
+}
+
+// Uint64 returns a random 64-bit value from the keystream. The keystream bytes are read in little-endian order.
+func (src *ChaChaSource) Uint64() uint64 {
+	// Generate the next keystream block, if the current one is consumed
+	if src.idx >= len(src.block) {
+		src.nextBlock()
+	}
+	v := uint64(src.block[src.idx]) | uint64(src.block[src.idx+1])<<32
+	src.idx += 2
+	return v
+}
+
+// Int63 returns a random 63-bit integer.
+func (src *ChaChaSource) Int63() int64 {
+	return int64(src.Uint64() >> 1)
+}
+
+// nextBlock computes the next keystream block and increments the 64-bit block counter.
+func (src *ChaChaSource) nextBlock() {
+	chachaBlock(&src.block, &src.input, src.rounds)
+	src.input[12]++
+	if src.input[12] == 0 {
+		src.input[13]++
+	}
+	src.idx = 0
+}
+
+// Assert checks the availability of a random number generator source. For ChaChaSource, it is empty,
+// because an error reading the key from crypto/rand is already recorded when seeding.
+func (src *ChaChaSource) Assert() {}
+
+// Err provides the last occurring error of the random number generator source, if any.
+// It returns nil, if no error occurrred.
+func (src *ChaChaSource) Err() error {
+	return src.e
+}
+
+// chachaBlock computes the keystream block out with the ChaCha block function with the number of rounds
+// on the input block in. The implementation is based on section 2.3 of RFC 8439.
+func chachaBlock(out, in *[16]uint32, rounds int) {
+	x := *in
+	for i := 0; i < rounds; i += 2 {
+		// Column round
+		chachaQuarterRound(&x, 0, 4, 8, 12)
+		chachaQuarterRound(&x, 1, 5, 9, 13)
+		chachaQuarterRound(&x, 2, 6, 10, 14)
+		chachaQuarterRound(&x, 3, 7, 11, 15)
+		// Diagonal round
+		chachaQuarterRound(&x, 0, 5, 10, 15)
+		chachaQuarterRound(&x, 1, 6, 11, 12)
+		chachaQuarterRound(&x, 2, 7, 8, 13)
+		chachaQuarterRound(&x, 3, 4, 9, 14)
+	}
+	for i := range out {
+		out[i] = x[i] + in[i]
+	}
+}
+
+// chachaQuarterRound performs the ChaCha quarter round on the words a, b, c and d of x.
+func chachaQuarterRound(x *[16]uint32, a, b, c, d int) {
+	x[a] += x[b]
+	x[d] = bits.RotateLeft32(x[d]^x[a], 16)
+	x[c] += x[d]
+	x[b] = bits.RotateLeft32(x[b]^x[c], 12)
+	x[a] += x[b]
+	x[d] = bits.RotateLeft32(x[d]^x[a], 8)
+	x[c] += x[d]
+	x[b] = bits.RotateLeft32(x[b]^x[c], 7)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler and returns the state of the ChaChaSource in a versioned binary format. The state contains
+// the key. Therefore, the binary format must be protected like the key, if the source is used for security-sensitive services.
+func (src *ChaChaSource) MarshalBinary() ([]byte, error) {
+	// Create binary format with header
+	b := marshalHeader(marshalChaCha, 2+4*32)
+	// Append rounds, index, input block and current keystream block
+	b = append(b, byte(src.rounds), byte(src.idx))
+	for _, v := range src.input {
+		b = binary.BigEndian.AppendUint32(b, v)
+	}
+	for _, v := range src.block {
+		b = binary.BigEndian.AppendUint32(b, v)
+	}
+	// Append checksum and return binary format
+	return marshalChecksum(b), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler and restores the state of the ChaChaSource from data in the binary format
+// returned by MarshalBinary. It returns an error, if data is corrupted, has an unsupported version, belongs to another type
+// of source or contains an invalid state. In case of an error, the state is not changed.
+func (src *ChaChaSource) UnmarshalBinary(data []byte) error {
+	// Validate data and retrieve reader on state
+	r, e := unmarshalState(data, marshalChaCha, 2+4*32)
+	if e != nil {
+		return e
+	}
+	// Name of the source for error messages
+	name := marshalTypes[marshalChaCha]
+	// Read rounds and index and return an error, if they are invalid
+	rounds, idx := int(r.uint8()), int(r.uint8())
+	if rounds != chachac.rounds8 && rounds != chachac.rounds20 {
+		return unmarshalInvalid(name, "number of rounds")
+	}
+	if idx > len(src.block) || idx%2 != 0 {
+		return unmarshalInvalid(name, "index out of range")
+	}
+	// Read input block and current keystream block
+	var input, block [16]uint32
+	for i := range input {
+		input[i] = r.uint32()
+	}
+	for i := range block {
+		block[i] = r.uint32()
+	}
+	// Return an error, if the constants of the input block are invalid
+	if [4]uint32(input[0:4]) != chachac.constants {
+		return unmarshalInvalid(name, "constants")
+	}
+	// Restore state and reset the last error
+	src.rounds, src.idx, src.input, src.block, src.e = rounds, idx, input, block, nil
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler and returns the state of the ChaChaSource in the binary format
+// returned by MarshalBinary encoded with standard base64 encoding.
+func (src *ChaChaSource) MarshalText() ([]byte, error) {
+	return marshalText(src)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler and restores the state of the ChaChaSource from text
+// returned by MarshalText. It returns an error, if text is invalid. In case of an error, the state is not changed.
+func (src *ChaChaSource) UnmarshalText(text []byte) error {
+	return unmarshalText(src, marshalTypes[marshalChaCha], text)
+}
@@ -7,48 +7,69 @@ package tsrand
 import (
 	crand "crypto/rand" // crypto/rand
 	"encoding/binary"   // encoding/binary
+	"io"                // io
 	"sync"              // sync
 )
 
-// cSource implements Source64 and can be used as source for a rand.Rand.
-// cSource calls crypto/rand and therefore, it provides a cryptographically secure
+// CryptoSource implements Source64 and can be used as source for a rand.Rand.
+// CryptoSource calls crypto/rand and therefore, it provides a cryptographically secure
 // random number generator source. To check, if it is available on the platform
 // Assert() should be called. If it is available, Err() will return nil, otherwise
-// will return an error. It holds error e which contains the last occurring error, if any,
-// and a sync.Mutex to enable concurrent use. cSource is safe for concurrent use by multiple goroutines.
-// cSource cannot be seeded, therefore Seed(int64) is empty.
-type cSource struct {
+// will return an error. It holds the reader, the first occurring error, if any,
+// and a sync.Mutex to enable concurrent use. An error is sticky: subsequent successful reads
+// do not reset it. Err returns the error until it is explicitly cleared with ClearErr.
+// Each instance holds its own error and mutex. CryptoSource is safe for concurrent use by multiple goroutines.
+// CryptoSource cannot be seeded, therefore Seed(int64) is empty.
+type CryptoSource struct {
 	mu sync.Mutex // mutex to enable concurrency
-	e  error      // last error occurring, if any
+	r  io.Reader  // reader of random bytes, crypto/rand Reader
+	e  error      // first error occurring since the last ClearErr, if any
 }
 
-// cryptoSrc is the cSource instance used by the interface
-var (
-	cryptoSrc cSource
-)
+// NewCryptoSource returns a new instance of CryptoSource. CryptoSource implements Source64 and
+// provides a cryptographically secure random number generator source based on crypto/rand.
+// It is safe for concurrent use by multiple goroutines.
+func NewCryptoSource() *CryptoSource {
+	return newCryptoSource(crand.Reader)
+}
 
-// cryptoSource returns the used cSource as pointer
-func cryptoSource() *cSource {
-	return &cryptoSrc
+// newCryptoSource returns a new instance of CryptoSource reading from r.
+func newCryptoSource(r io.Reader) *CryptoSource {
+	return &CryptoSource{r: r}
 }
 
-// cSource cannot be seeded. Seed(int64) is empty for cSource.
-func (c *cSource) Seed(s int64) {}
+// CryptoSource cannot be seeded. Seed(int64) is empty for CryptoSource.
+func (c *CryptoSource) Seed(s int64) {}
 
-// Uint64 returns a random 64-bit value.
-func (c *cSource) Uint64() (v uint64) {
+// TryUint64 returns a random 64-bit value. If reading from crypto/rand fails, it returns 0 and the error.
+// The error is also recorded and returned by subsequent calls of Err until ClearErr is called.
+func (c *CryptoSource) TryUint64() (uint64, error) {
+	var b [8]byte
 	// Lock source
 	c.mu.Lock()
-	// Read from crypto/rand provided Reader in v
-	c.e = binary.Read(crand.Reader, binary.BigEndian, &v)
-	// Unlock source
-	c.mu.Unlock()
-	// Return v as uint64
+	// Unlock source on return
+	defer c.mu.Unlock()
+	// Read from crypto/rand provided Reader in b
+	if _, e := io.ReadFull(c.r, b[:]); e != nil {
+		// Record the error, if no error is recorded, and return 0 and the error
+		c.record(e)
+		return 0, e
+	}
+	// Return b as uint64
+	return binary.BigEndian.Uint64(b[:]), nil
+}
+
+// Uint64 returns a random 64-bit value. If reading from crypto/rand fails, it returns 0 and
+// subsequent calls of Err return the error until ClearErr is called.
+func (c *CryptoSource) Uint64() uint64 {
+	// Retrieve random value with TryUint64, the error is recorded for Err
+	v, _ := c.TryUint64()
+	// Return v
 	return v
 }
 
 // Int63 returns a random 63-bit integer
-func (c *cSource) Int63() int64 {
+func (c *CryptoSource) Int63() int64 {
 	// Retrieve a random 64-bit value with Uint64() in vu
 	vu := c.Uint64()
 	// Bitmask for the first 63 bits
@@ -59,21 +80,23 @@ func (c *cSource) Int63() int64 {
 
 // Assert checks the availability of a random number generator source.
 // A subsequent call of Err() returns an error, if the source is not
-// available on the platform.
-func (c *cSource) Assert() {
+// available on the platform. A successful check does not clear a recorded error.
+func (c *CryptoSource) Assert() {
 	// Create []byte of size 1
 	b := make([]byte, 1)
 	// Lock the source
 	c.mu.Lock()
-	// Read from crypto/rand in b.
-	_, c.e = crand.Read(b)
+	// Read from crypto/rand in b and record the error, if any
+	if _, e := io.ReadFull(c.r, b); e != nil {
+		c.record(e)
+	}
 	// Unlock the source
 	c.mu.Unlock()
 }
 
-// Err provides the last occurring error of the random number generator source, if any.
-// It returns nil, if no error occurrred.
-func (c *cSource) Err() error {
+// Err provides the first occurring error of the random number generator source since
+// the last call of ClearErr, if any. It returns nil, if no error occurrred.
+func (c *CryptoSource) Err() error {
 	// Lock the source
 	c.mu.Lock()
 	// Set return value e to struct e
@@ -83,3 +106,20 @@ func (c *cSource) Err() error {
 	// Return e
 	return e
 }
+
+// ClearErr clears the recorded error. Subsequent calls of Err return nil until a new error occurs.
+func (c *CryptoSource) ClearErr() {
+	// Lock the source
+	c.mu.Lock()
+	// Clear error
+	c.e = nil
+	// Unlock the source
+	c.mu.Unlock()
+}
+
+// record records error e, if no error is recorded. record expects the source to be locked.
+func (c *CryptoSource) record(e error) {
+	if c.e == nil {
+		c.e = e
+	}
+}
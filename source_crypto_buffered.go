@@ -0,0 +1,191 @@
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tsrand
+
+// Import standard library packages and tserr
+import (
+	crand "crypto/rand" // crypto/rand
+	"encoding/binary"   // encoding/binary
+	"io"                // io
+	"math/rand"         // math/rand
+	"sync"              // sync
+
+	"github.com/thorstenrie/tserr" // tserr
+)
+
+// defaultBufferSize is the default size in bytes of the buffer of a BufferedCryptoSource
+const (
+	defaultBufferSize int = 4096
+)
+
+// BufferedCryptoSource implements Source64 and can be used as source for a rand.Rand. It provides a cryptographically secure
+// random number generator source based on crypto/rand. In contrast to the source of NewCryptoRand, it reads from crypto/rand in
+// large blocks into a buffer and serves Uint64 from the buffer. This reduces the number of calls to crypto/rand and the time per call
+// significantly. Consumed bytes of the buffer are zeroed immediately, so that returned random values do not remain in memory.
+// To check, if it is available on the platform, Assert() should be called. If it is available, Err() will return nil, otherwise
+// will return an error. BufferedCryptoSource holds the buffer, the position of the next unread byte, the reader, the error of an invalid
+// buffer size, the first occurring error, if any, and a sync.Mutex to enable concurrent use. Like for CryptoSource, an error is sticky
+// until it is explicitly cleared with ClearErr. A BufferedCryptoSource is safe for concurrent use by multiple goroutines.
+// It cannot be seeded, therefore Seed(int64) is empty.
+type BufferedCryptoSource struct {
+	mu   sync.Mutex // mutex to enable concurrency
+	buf  []byte     // buffer of random bytes
+	pos  int        // position of the next unread byte, pos == len(buf) means the buffer is empty
+	r    io.Reader  // reader of random bytes, crypto/rand Reader
+	size error      // error of an invalid buffer size, if any
+	e    error      // first error occurring since the last ClearErr, if any
+}
+
+// NewBufferedCryptoSource returns a new instance of BufferedCryptoSource with a buffer of size bytes. The size is rounded
+// down to a multiple of 8. If size is 0, the default size of 4096 bytes is used. If size is negative or lower than 8,
+// Err returns an error and the source is not available.
+func NewBufferedCryptoSource(size int) *BufferedCryptoSource {
+	return newBufferedCryptoSource(size, crand.Reader)
+}
+
+// NewBufferedCryptoRand returns a new instance of rand.Rand which provides a cryptographically secure random number generator
+// based on crypto/rand using a BufferedCryptoSource with a buffer of size bytes. It is safe for concurrent use by multiple goroutines.
+// If it is not available on the platform or size is invalid, NewBufferedCryptoRand() returns an error and *rand.Rand is nil.
+func NewBufferedCryptoRand(size int) (*rand.Rand, error) {
+	return New(NewBufferedCryptoSource(size))
+}
+
+// newBufferedCryptoSource returns a new instance of BufferedCryptoSource with a buffer of size bytes reading from r.
+func newBufferedCryptoSource(size int, r io.Reader) *BufferedCryptoSource {
+	// Use default size, if size is 0
+	if size == 0 {
+		size = defaultBufferSize
+	}
+	// Return a source with an error, if size is too small
+	if size < 8 {
+		e := tserr.Higher(&tserr.HigherArgs{Var: "buffer size", Actual: int64(size), LowerBound: 8})
+		return &BufferedCryptoSource{r: r, size: e, e: e}
+	}
+	// Allocate buffer with a size of a multiple of 8 and return the source with an empty buffer
+	size -= size % 8
+	return &BufferedCryptoSource{buf: make([]byte, size), pos: size, r: r}
+}
+
+// BufferedCryptoSource cannot be seeded. Seed(int64) is empty for BufferedCryptoSource.
+func (c *BufferedCryptoSource) Seed(s int64) {}
+
+// fill reads random bytes from crypto/rand into the buffer. If reading fails, the error is recorded and returned and
+// the buffer is zeroed and remains empty. fill expects the source to be locked.
+func (c *BufferedCryptoSource) fill() error {
+	// Read from crypto/rand into the buffer
+	if _, e := io.ReadFull(c.r, c.buf); e != nil {
+		// Zero partially read bytes and mark buffer as empty
+		zero(c.buf)
+		c.pos = len(c.buf)
+		// Record the error, if no error is recorded, and return the error
+		c.record(e)
+		return e
+	}
+	// Mark buffer as full
+	c.pos = 0
+	return nil
+}
+
+// TryUint64 returns a random 64-bit value. If reading from crypto/rand fails or the buffer size is invalid, it returns 0 and the error.
+// The error is also recorded and returned by subsequent calls of Err until ClearErr is called.
+func (c *BufferedCryptoSource) TryUint64() (uint64, error) {
+	// Lock source
+	c.mu.Lock()
+	// Unlock source on return
+	defer c.mu.Unlock()
+	// Return 0 and the error, if the source has no buffer due to an invalid size
+	if c.size != nil {
+		c.record(c.size)
+		return 0, c.size
+	}
+	// Refill buffer, if it is empty
+	if c.pos >= len(c.buf) {
+		if e := c.fill(); e != nil {
+			return 0, e
+		}
+	}
+	// Read 8 bytes from the buffer in v
+	b := c.buf[c.pos : c.pos+8]
+	v := binary.BigEndian.Uint64(b)
+	// Zero consumed bytes
+	zero(b)
+	c.pos += 8
+	// Return v
+	return v, nil
+}
+
+// Uint64 returns a random 64-bit value. If reading from crypto/rand fails, it returns 0 and
+// subsequent calls of Err return the error until ClearErr is called.
+func (c *BufferedCryptoSource) Uint64() uint64 {
+	// Retrieve random value with TryUint64, the error is recorded for Err
+	v, _ := c.TryUint64()
+	// Return v
+	return v
+}
+
+// Int63 returns a random 63-bit integer
+func (c *BufferedCryptoSource) Int63() int64 {
+	// Retrieve a random 64-bit value with Uint64() in vu
+	vu := c.Uint64()
+	// Bitmask for the first 63 bits
+	mask := ^uint64(1 << 63)
+	// Return the first 63 bits of vu
+	return int64(vu & mask)
+}
+
+// Assert checks the availability of a random number generator source. A subsequent call of Err() returns an error,
+// if the source is not available on the platform or the buffer size is invalid. If the buffer is empty, Assert fills the buffer
+// from crypto/rand. A successful check does not clear a recorded error.
+func (c *BufferedCryptoSource) Assert() {
+	// Lock the source
+	c.mu.Lock()
+	// Record the error of an invalid buffer size
+	if c.size != nil {
+		c.record(c.size)
+	}
+	// Fill the buffer from crypto/rand, if the source has a buffer and it is empty
+	if c.size == nil && c.pos >= len(c.buf) {
+		c.fill()
+	}
+	// Unlock the source
+	c.mu.Unlock()
+}
+
+// Err provides the first occurring error of the random number generator source since
+// the last call of ClearErr, if any. It returns nil, if no error occurrred.
+func (c *BufferedCryptoSource) Err() error {
+	// Lock the source
+	c.mu.Lock()
+	// Set return value e to struct e
+	e := c.e
+	// Unlock the source
+	c.mu.Unlock()
+	// Return e
+	return e
+}
+
+// ClearErr clears the recorded error. Subsequent calls of Err return nil until a new error occurs.
+// The error of an invalid buffer size is recorded again by the next call of Assert, Uint64 or TryUint64.
+func (c *BufferedCryptoSource) ClearErr() {
+	// Lock the source
+	c.mu.Lock()
+	// Clear error
+	c.e = nil
+	// Unlock the source
+	c.mu.Unlock()
+}
+
+// record records error e, if no error is recorded. record expects the source to be locked.
+func (c *BufferedCryptoSource) record(e error) {
+	if c.e == nil {
+		c.e = e
+	}
+}
+
+// zero sets all bytes of b to zero.
+func zero(b []byte) {
+	for i := range b {
+		b[i] = 0
+	}
+}
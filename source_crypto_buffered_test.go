@@ -0,0 +1,175 @@
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tsrand
+
+// Import standard library packages and tserr
+import (
+	"errors"  // errors
+	"fmt"     // fmt
+	"io"      // io
+	"testing" // testing
+
+	"github.com/thorstenrie/tserr" // tserr
+)
+
+// testReader is an io.Reader returning the byte sequence 1, 2, 3, ... for n bytes and
+// the error err afterwards.
+type testReader struct {
+	i   byte  // last returned byte
+	n   int   // number of remaining bytes before err is returned
+	err error // error returned after n bytes
+}
+
+// Read reads the byte sequence into p. It returns err, if the n bytes are consumed.
+func (r *testReader) Read(p []byte) (int, error) {
+	if r.n <= 0 {
+		return 0, r.err
+	}
+	if len(p) > r.n {
+		p = p[:r.n]
+	}
+	for j := range p {
+		r.i++
+		p[j] = r.i
+	}
+	r.n -= len(p)
+	return len(p), nil
+}
+
+// TestBufferedCryptoSize tests the rounding of the buffer size and that invalid buffer sizes result in an error.
+func TestBufferedCryptoSize(t *testing.T) {
+	// Valid buffer sizes and the expected size of the buffer
+	for size, want := range map[int]int{0: defaultBufferSize, 8: 8, 15: 8, 1000: 1000, 1001: 1000} {
+		src := NewBufferedCryptoSource(size)
+		// The test fails, if Err returns an error
+		if src.Assert(); src.Err() != nil {
+			t.Error(tserr.NotAvailable(&tserr.NotAvailableArgs{S: "BufferedCryptoSource", Err: src.Err()}))
+		}
+		// The test fails, if the size of the buffer does not match
+		if len(src.buf) != want {
+			t.Error(tserr.Equal(&tserr.EqualArgs{Var: fmt.Sprintf("buffer size of %d", size), Actual: int64(len(src.buf)), Want: int64(want)}))
+		}
+	}
+	// Invalid buffer sizes
+	for _, size := range []int{-8, -1, 1, 7} {
+		src := NewBufferedCryptoSource(size)
+		// The test fails, if Err does not return an error
+		if src.Assert(); src.Err() == nil {
+			t.Error(tserr.NilFailed(fmt.Sprintf("Err of buffer size %d", size)))
+		}
+		// The test fails, if Uint64 does not return zero
+		if v := src.Uint64(); v != 0 {
+			t.Error(tserr.Equal(&tserr.EqualArgs{Var: "Uint64", Actual: int64(v), Want: 0}))
+		}
+		// The test fails, if the error is not recorded again after ClearErr
+		if src.ClearErr(); src.Uint64() != 0 || src.Err() == nil {
+			t.Error(tserr.NilFailed(fmt.Sprintf("Err after ClearErr of buffer size %d", size)))
+		}
+		// The test fails, if NewBufferedCryptoRand does not return an error
+		if _, err := NewBufferedCryptoRand(size); err == nil {
+			t.Error(tserr.NilFailed(fmt.Sprintf("NewBufferedCryptoRand of buffer size %d", size)))
+		}
+	}
+}
+
+// TestBufferedCryptoZero tests that consumed bytes of the buffer are zeroed and the values are read from the buffer in big-endian order.
+func TestBufferedCryptoZero(t *testing.T) {
+	// Create source with a buffer of 32 bytes reading the sequence 1, 2, 3, ...
+	src := newBufferedCryptoSource(32, &testReader{n: 64, err: io.EOF})
+	// Retrieve the first value from the buffer
+	if v, w := src.Uint64(), uint64(0x0102030405060708); v != w {
+		t.Error(tserr.Equal(&tserr.EqualArgs{Var: "first value", Actual: int64(v), Want: int64(w)}))
+	}
+	// The test fails, if the consumed bytes are not zeroed
+	for i, b := range src.buf[:8] {
+		if b != 0 {
+			t.Error(tserr.Equal(&tserr.EqualArgs{Var: fmt.Sprintf("consumed byte %d", i), Actual: int64(b), Want: 0}))
+		}
+	}
+	// The test fails, if the unconsumed bytes are zeroed
+	for i, b := range src.buf[8:] {
+		if b == 0 {
+			t.Error(tserr.Forbidden(fmt.Sprintf("zeroed unconsumed byte %d", i+8)))
+		}
+	}
+	// Consume the remaining bytes of the buffer, the test fails, if the buffer is not zeroed completely
+	for i := 0; i < 3; i++ {
+		src.Uint64()
+	}
+	for i, b := range src.buf {
+		if b != 0 {
+			t.Error(tserr.Equal(&tserr.EqualArgs{Var: fmt.Sprintf("consumed byte %d", i), Actual: int64(b), Want: 0}))
+		}
+	}
+	// The next value is read from the refilled buffer
+	if v, w := src.Uint64(), uint64(0x2122232425262728); v != w {
+		t.Error(tserr.Equal(&tserr.EqualArgs{Var: "first value of refilled buffer", Actual: int64(v), Want: int64(w)}))
+	}
+}
+
+// TestBufferedCryptoErr tests that a failed read is propagated through Err, Uint64 returns zero and
+// partially read bytes are not served.
+func TestBufferedCryptoErr(t *testing.T) {
+	// Error returned by the reader
+	errRead := errors.New("read failed")
+	// Create source with a buffer of 16 bytes, the reader fails within the second block
+	r := &testReader{n: 20, err: errRead}
+	src := newBufferedCryptoSource(16, r)
+	// The test fails, if the first block cannot be read
+	if src.Uint64(); src.Err() != nil {
+		t.Fatal(tserr.NotAvailable(&tserr.NotAvailableArgs{S: "BufferedCryptoSource", Err: src.Err()}))
+	}
+	src.Uint64()
+	// The test fails, if reading the second block does not fail
+	v, e := src.TryUint64()
+	if !errors.Is(e, errRead) || !errors.Is(src.Err(), errRead) {
+		t.Error(tserr.NilFailed("TryUint64"))
+	}
+	// The test fails, if Uint64 does not return zero
+	if v != 0 {
+		t.Error(tserr.Equal(&tserr.EqualArgs{Var: "Uint64", Actual: int64(v), Want: 0}))
+	}
+	// The test fails, if the partially read bytes are not zeroed
+	for i, b := range src.buf {
+		if b != 0 {
+			t.Error(tserr.Equal(&tserr.EqualArgs{Var: fmt.Sprintf("byte %d", i), Actual: int64(b), Want: 0}))
+		}
+	}
+	// The reader provides bytes again, the test fails, if TryUint64 returns an error
+	r.n = 16
+	if _, e := src.TryUint64(); e != nil {
+		t.Error(tserr.NotAvailable(&tserr.NotAvailableArgs{S: "BufferedCryptoSource", Err: e}))
+	}
+	// The test fails, if the error is not kept after a successful read
+	if !errors.Is(src.Err(), errRead) {
+		t.Error(tserr.NilFailed("Err after successful read"))
+	}
+	// The test fails, if ClearErr does not clear the error
+	if src.ClearErr(); src.Err() != nil {
+		t.Error(tserr.Equal(&tserr.EqualArgs{Var: "Err after ClearErr", Actual: 1, Want: 0}))
+	}
+	// The test fails, if New does not return an error for a failing source
+	if _, err := New(newBufferedCryptoSource(16, &testReader{err: errRead})); err == nil {
+		t.Error(tserr.NilFailed("New"))
+	}
+}
+
+// BenchmarkBufferedCryptoSize performs a benchmark on Uint64 of BufferedCryptoSource with different buffer sizes
+// compared to Uint64 of the unbuffered cryptographically secure source.
+func BenchmarkBufferedCryptoSize(b *testing.B) {
+	b.Run("unbuffered", func(b *testing.B) {
+		src := NewCryptoSource()
+		for i := 0; i < b.N; i++ {
+			src.Uint64()
+		}
+	})
+	for _, size := range []int{64, 512, 4096, 65536} {
+		b.Run(fmt.Sprintf("buffer=%d", size), func(b *testing.B) {
+			src := NewBufferedCryptoSource(size)
+			for i := 0; i < b.N; i++ {
+				src.Uint64()
+			}
+		})
+	}
+}
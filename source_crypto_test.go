@@ -0,0 +1,75 @@
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tsrand
+
+// Import standard library packages and tserr
+import (
+	"errors"  // errors
+	"testing" // testing
+
+	"github.com/thorstenrie/tserr" // tserr
+)
+
+// TestCryptoSourceInstances tests that each CryptoSource holds its own error. An error of one
+// instance must not be visible in another instance.
+func TestCryptoSourceInstances(t *testing.T) {
+	// Error returned by the reader
+	errRead := errors.New("read failed")
+	// Create a failing source and a working source
+	failing, working := newCryptoSource(&testReader{err: errRead}), NewCryptoSource()
+	// The test fails, if the failing source does not return an error
+	if failing.Assert(); failing.Err() == nil {
+		t.Error(tserr.NilFailed("Err of failing source"))
+	}
+	// The test fails, if the working source returns an error
+	if working.Assert(); working.Err() != nil {
+		t.Error(tserr.NotAvailable(&tserr.NotAvailableArgs{S: "CryptoSource", Err: working.Err()}))
+	}
+	// The test fails, if two instances of NewCryptoRand use the same source
+	if NewCryptoSource() == NewCryptoSource() {
+		t.Error(tserr.Forbidden("shared CryptoSource"))
+	}
+}
+
+// TestCryptoSourceSticky tests that an error of CryptoSource is returned by TryUint64, recorded by Err and
+// kept after subsequent successful reads until ClearErr is called.
+func TestCryptoSourceSticky(t *testing.T) {
+	// Error returned by the reader
+	errRead := errors.New("read failed")
+	// Create source with a reader providing 8 bytes before failing
+	r := &testReader{n: 8, err: errRead}
+	src := newCryptoSource(r)
+	// The test fails, if the first read fails
+	v, e := src.TryUint64()
+	if e != nil {
+		t.Fatal(tserr.NotAvailable(&tserr.NotAvailableArgs{S: "CryptoSource", Err: e}))
+	}
+	// The test fails, if the value is not read in big-endian order
+	if w := uint64(0x0102030405060708); v != w {
+		t.Error(tserr.Equal(&tserr.EqualArgs{Var: "TryUint64", Actual: int64(v), Want: int64(w)}))
+	}
+	// The test fails, if TryUint64 does not return the read error and zero
+	if v, e := src.TryUint64(); !errors.Is(e, errRead) || v != 0 {
+		t.Error(tserr.NilFailed("TryUint64"))
+	}
+	// The reader provides bytes again
+	r.n = 16
+	// The test fails, if the next read fails
+	if _, e := src.TryUint64(); e != nil {
+		t.Error(tserr.NotAvailable(&tserr.NotAvailableArgs{S: "CryptoSource", Err: e}))
+	}
+	// The test fails, if the error is not kept after a successful read and Assert
+	src.Uint64()
+	if src.Assert(); !errors.Is(src.Err(), errRead) {
+		t.Error(tserr.NilFailed("Err after successful read"))
+	}
+	// The test fails, if ClearErr does not clear the error
+	if src.ClearErr(); src.Err() != nil {
+		t.Error(tserr.Equal(&tserr.EqualArgs{Var: "Err after ClearErr", Actual: 1, Want: 0}))
+	}
+	// The test fails, if a new error is not recorded and Uint64 does not return zero
+	if v := src.Uint64(); v != 0 || !errors.Is(src.Err(), errRead) {
+		t.Error(tserr.NilFailed("Uint64"))
+	}
+}
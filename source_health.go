@@ -0,0 +1,262 @@
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tsrand
+
+// Import standard library packages and tserr
+import (
+	"fmt"       // fmt
+	"math"      // math
+	"math/rand" // math/rand
+	"sync"      // sync
+
+	"github.com/thorstenrie/tserr" // tserr
+)
+
+// healthc holds the parameters of the health tests of NIST SP 800-90B
+var (
+	healthc = struct {
+		alpha      float64 // false positive probability of each test per sample
+		window     int     // window size of the adaptive proportion test for non-binary samples
+		startup    int     // number of samples of the start-up test
+		maxEntropy float64 // maximum min-entropy of a sample of 8 bits
+	}{
+		alpha:      0x1p-40, // false positive probability of each test per sample
+		window:     512,     // window size of the adaptive proportion test for non-binary samples
+		startup:    1024,    // number of samples of the start-up test
+		maxEntropy: 8,       // maximum min-entropy of a sample of 8 bits
+	}
+)
+
+// HealthSource implements Source and wraps a Source with the continuous health tests of NIST SP 800-90B, section 4.4.
+// Each random 64-bit value of the wrapped source is split into eight samples of one byte, starting with the most significant byte.
+// The repetition count test detects a sample, which is repeated too many times in a row. The adaptive proportion test detects
+// a sample, which occurs too often within a window of 512 samples. The cutoffs of both tests are derived from the claimed min-entropy
+// per sample with a false positive probability of 2^-40 per sample. If a test fails, the error is recorded and the source does not
+// output any random values until ClearErr is called: Uint64 and Int63 return 0. Like for CryptoSource, an error is sticky and
+// can be detected per call with TryUint64. Assert checks the wrapped source and runs the start-up test on 1024 samples, which are discarded.
+// HealthSource is safe for concurrent use by multiple goroutines.
+type HealthSource struct {
+	mu    sync.Mutex // mutex to enable concurrency
+	src   Source     // wrapped source
+	rctC  int        // cutoff of the repetition count test
+	aptC  int        // cutoff of the adaptive proportion test
+	last  byte       // last sample of the repetition count test
+	count int        // number of repetitions of the last sample
+	first byte       // first sample of the current window of the adaptive proportion test
+	freq  int        // number of occurrences of the first sample in the current window
+	pos   int        // number of samples in the current window
+	param error      // error of an invalid min-entropy, if any
+	fail  error      // error of a failed health test, if any
+	e     error      // first error occurring since the last ClearErr, if any
+}
+
+// NewHealthSource returns a new instance of HealthSource, which wraps src with the continuous health tests of NIST SP 800-90B.
+// The claimed min-entropy h in bits per byte of the wrapped source must be in (0,8], e.g., 8 for CryptoSource. If h is invalid,
+// the source does not output any random values and Err returns an error. The wrapped source src must not be nil and must not be used directly afterwards.
+func NewHealthSource(src Source, h float64) *HealthSource {
+	// Return a source with an error, if h is invalid
+	if !(h > 0 && h <= healthc.maxEntropy) {
+		e := tserr.Check(&tserr.CheckArgs{F: "min-entropy", Err: fmt.Errorf("value is %v, but it must be in (0,%v]", h, healthc.maxEntropy)})
+		return &HealthSource{src: src, param: e, e: e}
+	}
+	// Return the source with the cutoffs of the tests
+	return &HealthSource{src: src, rctC: rctCutoff(h, healthc.alpha), aptC: aptCutoff(healthc.window, h, healthc.alpha)}
+}
+
+// NewHealthRand returns a new instance of rand.Rand, which uses src wrapped by NewHealthSource with the claimed min-entropy h.
+// If the source is not available on the platform, h is invalid or the start-up test fails, NewHealthRand returns an error and *rand.Rand is nil.
+func NewHealthRand(src Source, h float64) (*rand.Rand, error) {
+	return New(NewHealthSource(src, h))
+}
+
+// rctCutoff returns the cutoff of the repetition count test for the min-entropy h per sample and the false positive probability alpha.
+func rctCutoff(h, alpha float64) int {
+	return 1 + int(math.Ceil(-math.Log2(alpha)/h))
+}
+
+// aptCutoff returns the cutoff of the adaptive proportion test with window size w for the min-entropy h per sample and the false
+// positive probability alpha. It is the lowest number c, so that c or more occurrences of the first sample within a window have a
+// probability of at most alpha.
+func aptCutoff(w int, h, alpha float64) int {
+	// Probability of the most likely sample
+	p := math.Exp2(-h)
+	// Sum the binomial probabilities from the upper tail, until the tail exceeds alpha
+	tail := 0.0
+	for k := w; k >= 0; k-- {
+		lg, _ := math.Lgamma(float64(w + 1))
+		lk, _ := math.Lgamma(float64(k + 1))
+		lw, _ := math.Lgamma(float64(w - k + 1))
+		tail += math.Exp(lg - lk - lw + float64(k)*math.Log(p) + float64(w-k)*math.Log1p(-p))
+		// The cutoff is the lowest number with a tail probability of at most alpha
+		if tail > alpha {
+			return k + 1
+		}
+	}
+	// Return 1, which cannot be reached for a valid h
+	return 1
+}
+
+// Seed initializes the wrapped source to a deterministic state defined by s.
+func (h *HealthSource) Seed(s int64) {
+	// Lock source
+	h.mu.Lock()
+	// Seed wrapped source
+	h.src.Seed(s)
+	// Unlock source
+	h.mu.Unlock()
+}
+
+// TryUint64 returns a random 64-bit value. If a health test fails, the min-entropy is invalid or the wrapped source provides TryUint64 and
+// it fails, TryUint64 returns 0 and the error. The error is also recorded and returned by subsequent calls of Err until ClearErr is called.
+// After a failed health test, TryUint64 returns 0 and the error until ClearErr is called.
+func (h *HealthSource) TryUint64() (uint64, error) {
+	// Lock source
+	h.mu.Lock()
+	// Unlock source on return
+	defer h.mu.Unlock()
+	// Return the random value
+	return h.next()
+}
+
+// Uint64 returns a random 64-bit value. If a health test fails, it returns 0 and subsequent calls of Err return the error until ClearErr is called.
+func (h *HealthSource) Uint64() uint64 {
+	// Retrieve random value with TryUint64, the error is recorded for Err
+	v, _ := h.TryUint64()
+	// Return v
+	return v
+}
+
+// Int63 returns a random 63-bit integer
+func (h *HealthSource) Int63() int64 {
+	// Retrieve a random 64-bit value with Uint64() in vu
+	vu := h.Uint64()
+	// Bitmask for the first 63 bits
+	mask := ^uint64(1 << 63)
+	// Return the first 63 bits of vu
+	return int64(vu & mask)
+}
+
+// Assert checks the availability of the wrapped source and runs the start-up test on 1024 samples of the wrapped source, which are discarded.
+// A subsequent call of Err() returns an error, if the wrapped source is not available, the min-entropy is invalid or a health test fails.
+// A successful check does not clear a recorded error.
+func (h *HealthSource) Assert() {
+	// Lock the source
+	h.mu.Lock()
+	// Unlock the source on return
+	defer h.mu.Unlock()
+	// Check availability of wrapped source
+	h.src.Assert()
+	if e := h.src.Err(); e != nil {
+		h.record(e)
+		return
+	}
+	// Run the health tests on the samples of the start-up test
+	for i := 0; i < healthc.startup/8; i++ {
+		if _, e := h.next(); e != nil {
+			return
+		}
+	}
+}
+
+// Err provides the first occurring error of the source since the last call of ClearErr, if any. If the source did not record an error,
+// Err returns the error of the wrapped source. It returns nil, if no error occurrred.
+func (h *HealthSource) Err() (e error) {
+	// Lock the source
+	h.mu.Lock()
+	// Set return value e to the recorded error or the error of the wrapped source
+	if e = h.e; e == nil {
+		e = h.src.Err()
+	}
+	// Unlock the source
+	h.mu.Unlock()
+	// Return e
+	return e
+}
+
+// ClearErr clears the recorded error and resets the health tests, so that the source outputs random values again. If the wrapped source
+// provides ClearErr, its error is cleared as well. The error of an invalid min-entropy is recorded again by the next call of Assert, Uint64 or TryUint64.
+func (h *HealthSource) ClearErr() {
+	// Lock the source
+	h.mu.Lock()
+	// Clear errors and reset the health tests
+	h.e, h.fail = nil, nil
+	h.count, h.pos = 0, 0
+	// Clear error of the wrapped source, if supported
+	if c, ok := h.src.(interface{ ClearErr() }); ok {
+		c.ClearErr()
+	}
+	// Unlock the source
+	h.mu.Unlock()
+}
+
+// next retrieves a random 64-bit value from the wrapped source and runs the health tests on its samples. It returns 0 and the error,
+// if the min-entropy is invalid, a health test failed before or fails, or TryUint64 of the wrapped source fails. next expects the source to be locked.
+func (h *HealthSource) next() (uint64, error) {
+	// Return 0 and the error, if the min-entropy is invalid
+	if h.param != nil {
+		h.record(h.param)
+		return 0, h.param
+	}
+	// Return 0 and the error, if a health test failed
+	if h.fail != nil {
+		return 0, h.fail
+	}
+	// Retrieve a random value from the wrapped source
+	var v uint64
+	if t, ok := h.src.(interface{ TryUint64() (uint64, error) }); ok {
+		var e error
+		if v, e = t.TryUint64(); e != nil {
+			h.record(e)
+			return 0, e
+		}
+	} else {
+		v = h.src.Uint64()
+	}
+	// Run the health tests on the samples of v, starting with the most significant byte
+	for i := 56; i >= 0; i -= 8 {
+		if e := h.test(byte(v >> i)); e != nil {
+			// Record the failure, the source does not output random values until ClearErr is called
+			h.fail = e
+			h.record(e)
+			return 0, e
+		}
+	}
+	// Return v
+	return v, nil
+}
+
+// test runs the repetition count test and the adaptive proportion test on sample s. It returns an error, if a test fails.
+// test expects the source to be locked.
+func (h *HealthSource) test(s byte) error {
+	// Repetition count test: count the repetitions of the last sample
+	if h.count > 0 && s == h.last {
+		h.count++
+		if h.count >= h.rctC {
+			return tserr.Check(&tserr.CheckArgs{F: "repetition count test", Err: fmt.Errorf("sample %#02x is repeated %d times in a row, cutoff is %d", s, h.count, h.rctC)})
+		}
+	} else {
+		h.last, h.count = s, 1
+	}
+	// Adaptive proportion test: start a new window with the sample or count the occurrences of the first sample of the window
+	if h.pos == 0 {
+		h.first, h.freq = s, 1
+	} else if s == h.first {
+		h.freq++
+		if h.freq >= h.aptC {
+			return tserr.Check(&tserr.CheckArgs{F: "adaptive proportion test", Err: fmt.Errorf("sample %#02x occurs %d times within %d samples, cutoff is %d", s, h.freq, healthc.window, h.aptC)})
+		}
+	}
+	// Start a new window, if the window is complete
+	if h.pos++; h.pos == healthc.window {
+		h.pos = 0
+	}
+	return nil
+}
+
+// record records error e, if no error is recorded. record expects the source to be locked.
+func (h *HealthSource) record(e error) {
+	if h.e == nil {
+		h.e = e
+	}
+}
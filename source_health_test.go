@@ -0,0 +1,122 @@
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tsrand
+
+// Import standard library packages and tserr
+import (
+	"errors"  // errors
+	"math"    // math
+	"strings" // strings
+	"testing" // testing
+
+	"github.com/thorstenrie/tserr" // tserr
+)
+
+// TestHealthCutoffs tests the cutoffs of the repetition count test and the adaptive proportion test with the values of
+// NIST SP 800-90B, sections 4.4.1 and 4.4.2, for a false positive probability of 2^-20 and a window of 512 samples.
+func TestHealthCutoffs(t *testing.T) {
+	// Min-entropy per sample and expected cutoffs of the repetition count test and the adaptive proportion test
+	for _, c := range []struct {
+		h        float64
+		rct, apt int
+	}{{0.5, 41, 410}, {1, 21, 311}, {2, 11, 177}, {4, 6, 62}, {8, 4, 13}} {
+		// The test fails, if a cutoff differs from the specification
+		if r := rctCutoff(c.h, 0x1p-20); r != c.rct {
+			t.Error(tserr.Equal(&tserr.EqualArgs{Var: "repetition count test cutoff", Actual: int64(r), Want: int64(c.rct)}))
+		}
+		if a := aptCutoff(512, c.h, 0x1p-20); a != c.apt {
+			t.Error(tserr.Equal(&tserr.EqualArgs{Var: "adaptive proportion test cutoff", Actual: int64(a), Want: int64(c.apt)}))
+		}
+	}
+}
+
+// TestHealthRand retrieves random values from a PCG64Source and a CryptoSource wrapped by NewHealthSource and performs the defined tests
+// on arithmetic mean and variance. The test fails, if a health test fails or if tests on the retrieved random numbers fail.
+func TestHealthRand(t *testing.T) {
+	for name, src := range map[string]Source{"PCG64Source": NewPCG64Source(), "CryptoSource": NewCryptoSource()} {
+		// Wrap the source and retrieve the random number generator with the wrapped source
+		h := NewHealthSource(src, 8)
+		rnd, err := New(h)
+		// The test fails if an error occurs
+		if err != nil {
+			t.Fatal(tserr.NotAvailable(&tserr.NotAvailableArgs{S: name, Err: err}))
+		}
+		// Perform tests on the random number generator source
+		testRandInt(t, rnd)
+		testRandFloat(t, rnd)
+		testRandUint(t, rnd)
+		// The test fails, if a health test failed
+		if e := h.Err(); e != nil {
+			t.Error(tserr.NotAvailable(&tserr.NotAvailableArgs{S: name, Err: e}))
+		}
+	}
+}
+
+// BenchmarkHealthRand performs a benchmark on a PCG64Source wrapped by NewHealthSource
+func BenchmarkHealthRand(b *testing.B) {
+	// Retrieve the random number generator with the wrapped source
+	rnd, err := NewHealthRand(NewPCG64Source(), 8)
+	// The test fails if an error occurs
+	if err != nil {
+		b.Fatal(tserr.NotAvailable(&tserr.NotAvailableArgs{S: "HealthRand", Err: err}))
+	}
+	benchRandUint(b, rnd)
+}
+
+// TestHealthFailures tests that a stuck source fails the repetition count test and a source with a biased distribution fails the
+// adaptive proportion test. The failure must be recorded, the source must not output random values until ClearErr is called.
+func TestHealthFailures(t *testing.T) {
+	// Source repeating a sample six times in a row across consecutive values and source alternating between two samples,
+	// which never repeats a sample in a row. A single value passes both tests.
+	for test, v := range map[string]uint64{"repetition count test": 0x4242424201034242, "adaptive proportion test": 0x0001000100010001} {
+		src := NewHealthSource(&testSeqSource{v: []uint64{v}}, 8)
+		// The test fails, if the start-up test does not fail with the expected test
+		if src.Assert(); src.Err() == nil || !strings.Contains(src.Err().Error(), test) {
+			t.Errorf("%s: %v", test, tserr.NilFailed("Err"))
+		}
+		// The test fails, if the source outputs a random value or does not return the error
+		if x, e := src.TryUint64(); x != 0 || e == nil {
+			t.Errorf("%s: %v", test, tserr.NilFailed("TryUint64"))
+		}
+		if x := src.Uint64(); x != 0 {
+			t.Error(tserr.Equal(&tserr.EqualArgs{Var: "Uint64", Actual: int64(x), Want: 0}))
+		}
+		// The test fails, if ClearErr does not clear the error
+		if src.ClearErr(); src.Err() != nil {
+			t.Error(tserr.Equal(&tserr.EqualArgs{Var: "Err after ClearErr", Actual: 1, Want: 0}))
+		}
+		// The test fails, if the source does not output the first value after ClearErr
+		if x, e := src.TryUint64(); x != v || e != nil {
+			t.Errorf("%s: %v", test, tserr.Equal(&tserr.EqualArgs{Var: "TryUint64 after ClearErr", Actual: int64(x), Want: int64(v)}))
+		}
+	}
+}
+
+// TestHealthErrors tests that errors of the wrapped source are returned and an invalid min-entropy is rejected.
+func TestHealthErrors(t *testing.T) {
+	// Error returned by the reader
+	errRead := errors.New("read failed")
+	// The test fails, if the error of a failing CryptoSource is not returned
+	src := NewHealthSource(newCryptoSource(&testReader{err: errRead}), 8)
+	if src.Assert(); !errors.Is(src.Err(), errRead) {
+		t.Error(tserr.NilFailed("Err of failing source"))
+	}
+	// The test fails, if TryUint64 does not return the error of the wrapped source
+	if src.ClearErr(); src.Err() != nil {
+		t.Error(tserr.Equal(&tserr.EqualArgs{Var: "Err after ClearErr", Actual: 1, Want: 0}))
+	}
+	if v, e := src.TryUint64(); v != 0 || !errors.Is(e, errRead) {
+		t.Error(tserr.NilFailed("TryUint64"))
+	}
+	// The test fails, if an invalid min-entropy is accepted
+	for _, h := range []float64{0, -1, 8.5, math.NaN(), math.Inf(1)} {
+		if _, e := NewHealthRand(NewPCG64Source(), h); e == nil {
+			t.Errorf("min-entropy %v: %v", h, tserr.NilFailed("NewHealthRand"))
+		}
+		src := NewHealthSource(NewPCG64Source(), h)
+		if src.ClearErr(); src.Uint64() != 0 || src.Err() == nil {
+			t.Errorf("min-entropy %v: %v", h, tserr.NilFailed("Uint64"))
+		}
+	}
+}
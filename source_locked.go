@@ -0,0 +1,117 @@
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tsrand
+
+// Import standard library packages and tserr
+import (
+	"math/rand" // math/rand
+	"sync"      // sync
+
+	"github.com/thorstenrie/tserr" // tserr
+)
+
+// lockedSource implements Source and wraps a Source, which is not safe for concurrent use by multiple goroutines.
+// It holds the wrapped source src and a sync.Mutex, which serializes all calls to src.
+// A lockedSource is safe for concurrent use by multiple goroutines.
+type lockedSource struct {
+	mu  sync.Mutex // mutex to enable concurrency
+	src Source     // wrapped source
+}
+
+// NewLockedSource returns a new Source, which wraps src and serializes all calls of Seed, Uint64, Int63, Assert and Err
+// with a mutex. The returned Source is safe for concurrent use by multiple goroutines, even if src is not.
+// The wrapped source src must not be used directly afterwards. If src is nil, the returned Source does not output any
+// random values and Err returns an error.
+func NewLockedSource(src Source) Source {
+	return &lockedSource{src: src}
+}
+
+// NewLockedRand returns a new instance of rand.Rand, which uses src wrapped by NewLockedSource. Since all
+// calls to the source are serialized, the methods of rand.Rand are safe for concurrent use by multiple goroutines,
+// except for Read and Seed, which modify the state of rand.Rand itself. If src is nil or the source is not available on the platform,
+// NewLockedRand returns an error and *rand.Rand is nil.
+func NewLockedRand(src Source) (*rand.Rand, error) {
+	// Return an error, if src is nil
+	if src == nil {
+		return nil, tserr.NilPtr()
+	}
+	return New(NewLockedSource(src))
+}
+
+// Seed initializes the wrapped source to a deterministic state defined by s. If the wrapped source is nil, Seed does nothing.
+func (l *lockedSource) Seed(s int64) {
+	// Return, if the wrapped source is nil
+	if l.src == nil {
+		return
+	}
+	// Lock source
+	l.mu.Lock()
+	// Seed wrapped source
+	l.src.Seed(s)
+	// Unlock source
+	l.mu.Unlock()
+}
+
+// Uint64 returns a random 64-bit value of the wrapped source. If the wrapped source is nil, it returns 0.
+func (l *lockedSource) Uint64() (v uint64) {
+	// Return 0, if the wrapped source is nil
+	if l.src == nil {
+		return 0
+	}
+	// Lock source
+	l.mu.Lock()
+	// Retrieve random 64-bit value from wrapped source
+	v = l.src.Uint64()
+	// Unlock source
+	l.mu.Unlock()
+	// Return v
+	return v
+}
+
+// Int63 returns a random 63-bit integer of the wrapped source. If the wrapped source is nil, it returns 0.
+func (l *lockedSource) Int63() (v int64) {
+	// Return 0, if the wrapped source is nil
+	if l.src == nil {
+		return 0
+	}
+	// Lock source
+	l.mu.Lock()
+	// Retrieve random 63-bit integer from wrapped source
+	v = l.src.Int63()
+	// Unlock source
+	l.mu.Unlock()
+	// Return v
+	return v
+}
+
+// Assert checks the availability of the wrapped source.
+func (l *lockedSource) Assert() {
+	// Return, if the wrapped source is nil, Err returns the error
+	if l.src == nil {
+		return
+	}
+	// Lock source
+	l.mu.Lock()
+	// Check availability of wrapped source
+	l.src.Assert()
+	// Unlock source
+	l.mu.Unlock()
+}
+
+// Err provides the last occurring error of the wrapped source, if any.
+// It returns an error, if the wrapped source is nil, and nil, if no error occurrred.
+func (l *lockedSource) Err() (e error) {
+	// Return an error, if the wrapped source is nil
+	if l.src == nil {
+		return tserr.NilPtr()
+	}
+	// Lock source
+	l.mu.Lock()
+	// Retrieve last error of wrapped source
+	e = l.src.Err()
+	// Unlock source
+	l.mu.Unlock()
+	// Return e
+	return e
+}
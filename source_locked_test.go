@@ -0,0 +1,132 @@
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tsrand
+
+// Import standard library packages and tserr
+import (
+	"fmt"     // fmt
+	"sync"    // sync
+	"testing" // testing
+
+	"github.com/thorstenrie/tserr" // tserr
+)
+
+// Parameters for the concurrency tests
+const (
+	testGoroutines int = 8    // number of concurrent goroutines
+	testCalls      int = 1000 // number of calls per goroutine
+)
+
+// testBuiltinSources returns new instances of all built-in sources with their names
+func testBuiltinSources() map[string]func() Source {
+	return map[string]func() Source{
+		"CryptoSource":               func() Source { return NewCryptoSource() },
+		"BufferedCryptoSource":       func() Source { return NewBufferedCryptoSource(0) },
+		"dSource":                    func() Source { return newDeterministicSource(defaultSeed) },
+		"SimpleSource":               func() Source { return NewSimpleSource() },
+		"MT32Source":                 func() Source { return NewMT32Source() },
+		"MT64Source":                 func() Source { return NewMT64Source() },
+		"PCG32Source":                func() Source { return NewPCG32Source() },
+		"PCG64Source":                func() Source { return NewPCG64Source() },
+		"Xoshiro256StarStarSource":   func() Source { return NewXoshiro256StarStarSource() },
+		"Xoshiro256PlusSource":       func() Source { return NewXoshiro256PlusSource() },
+		"Xoroshiro128PlusPlusSource": func() Source { return NewXoroshiro128PlusPlusSource() },
+		"SplitMix64Source":           func() Source { return NewSplitMix64Source() },
+		"ChaCha20Source":             func() Source { return NewChaCha20Source() },
+		"ChaCha8Source":              func() Source { return NewChaCha8Source() },
+		"PhiloxSource":               func() Source { return NewPhiloxSource() },
+		"ThreefrySource":             func() Source { return NewThreefrySource() },
+	}
+}
+
+// TestLockedSourceConcurrent uses each built-in source wrapped by NewLockedSource concurrently from multiple goroutines.
+// Run with the race detector, e.g., go test -race, the test fails, if a data race occurs on the state of a source.
+func TestLockedSourceConcurrent(t *testing.T) {
+	for name, f := range testBuiltinSources() {
+		// Wrap the source and retrieve a rand.Rand using the wrapped source
+		l := NewLockedSource(f())
+		rnd, err := New(l)
+		// The test fails if an error occurs
+		if err != nil {
+			t.Fatal(tserr.NotAvailable(&tserr.NotAvailableArgs{S: name, Err: err}))
+		}
+		// Use rnd concurrently from multiple goroutines
+		var wg sync.WaitGroup
+		for g := 0; g < testGoroutines; g++ {
+			wg.Add(1)
+			go func(g int) {
+				defer wg.Done()
+				for i := 0; i < testCalls; i++ {
+					rnd.Uint64()
+					rnd.Int63()
+					rnd.Float64()
+					rnd.Intn(testIntn)
+				}
+				// Seed the wrapped source concurrently, since rand.Rand.Seed is not safe for concurrent use
+				l.Seed(int64(g))
+			}(g)
+		}
+		wg.Wait()
+		// The test fails if an error occurred
+		if e := l.Err(); e != nil {
+			t.Error(tserr.NotAvailable(&tserr.NotAvailableArgs{S: name, Err: e}))
+		}
+	}
+}
+
+// TestLockedSourceOutput tests, if a wrapped source returns the same output as the source itself.
+// The test fails, if the output differs.
+func TestLockedSourceOutput(t *testing.T) {
+	// Create a source and the same source wrapped by NewLockedSource
+	src, l := NewMT64Source(), NewLockedSource(NewMT64Source())
+	// Seed both sources
+	src.Seed(42)
+	l.Seed(42)
+	// The test fails, if Assert results in an error
+	if l.Assert(); l.Err() != nil {
+		t.Fatal(tserr.NotAvailable(&tserr.NotAvailableArgs{S: "lockedSource", Err: l.Err()}))
+	}
+	// Compare the output
+	for i := 0; i < testCalls; i++ {
+		if v, w := l.Uint64(), src.Uint64(); v != w {
+			t.Fatal(tserr.NotEqualStr(&tserr.NotEqualStrArgs{X: fmt.Sprintf("output %d: %#x", i, v), Y: fmt.Sprintf("%#x", w)}))
+		}
+		if v, w := l.Int63(), src.Int63(); v != w {
+			t.Fatal(tserr.Equal(&tserr.EqualArgs{Var: fmt.Sprintf("output %d", i), Actual: v, Want: w}))
+		}
+	}
+}
+
+// TestLockedNil tests that NewLockedRand returns an error for a nil source and a nil source wrapped by NewLockedSource
+// returns an error with Err and does not panic.
+func TestLockedNil(t *testing.T) {
+	// The test fails, if NewLockedRand does not return an error
+	if r, e := NewLockedRand(nil); r != nil || e == nil {
+		t.Error(tserr.NilFailed("NewLockedRand"))
+	}
+	// The test fails, if the wrapped nil source does not return an error or outputs a random value
+	l := NewLockedSource(nil)
+	l.Seed(1)
+	if l.Assert(); l.Err() == nil {
+		t.Error(tserr.NilFailed("Err"))
+	}
+	if v, w := l.Uint64(), l.Int63(); v != 0 || w != 0 {
+		t.Error(tserr.Equal(&tserr.EqualArgs{Var: "Uint64 and Int63 of nil source", Actual: int64(v) | w, Want: 0}))
+	}
+	// The test fails, if New does not return an error
+	if _, e := New(l); e == nil {
+		t.Error(tserr.NilFailed("New"))
+	}
+}
+
+// BenchmarkLockedRand performs a benchmark on the 64-bit Mersenne Twister wrapped by NewLockedSource
+func BenchmarkLockedRand(b *testing.B) {
+	// Retrieve the random number generator
+	rnd, err := NewLockedRand(NewMT64Source())
+	// The test fails if an error occurs
+	if err != nil {
+		b.Fatal(tserr.NotAvailable(&tserr.NotAvailableArgs{S: "NewLockedRand", Err: err}))
+	}
+	benchRandUint(b, rnd)
+}
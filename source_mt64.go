@@ -3,6 +3,11 @@
 // that can be found in the LICENSE file.
 package tsrand
 
+// Import standard library packages
+import (
+	"encoding/binary" // encoding/binary
+)
+
 // MT64Source implements Source64 and can be used as source for a rand.Rand. It is based on the
 // reference implementation of the 64-bit Mersenne Twister.
 // MT64Source holds the pseudo-random number generator internal states mt and mti. A MT64Source is not safe for
@@ -37,7 +42,7 @@ func NewMT64Source() *MT64Source {
 	return src
 }
 
-// seed initializes the state vector with seed s.
+// seed initializes the state vector with seed s. The implementation is based on init_genrand of the reference implementation.
 func (src *MT64Source) seed(s int64) {
 	src.mt[0] = uint64(s)
 	for src.mti = 1; src.mti < mt64c.n; src.mti++ {
@@ -45,12 +50,75 @@ func (src *MT64Source) seed(s int64) {
 	}
 }
 
-// Seed initializes the state vector with seed s.
+// Seed initializes the state vector with seed s. The state vector is filled with
+// the expanded seed s, which avoids correlated states for similar seeds.
 func (src *MT64Source) Seed(s int64) {
-	// Initialization of the state vector with seed s
+	// Initialization of the state vector with the expanded seed s
+	src.expand(NewSeedExpander(s))
+}
+
+// SeedGenrand initializes the state vector with seed s based on init_genrand of the reference implementation.
+// Seed of earlier versions used init_genrand, SeedGenrand reproduces their output. The output is also equal to std::mt19937_64 seeded with s.
+func (src *MT64Source) SeedGenrand(s int64) {
+	// Initialization of the state vector with init_genrand
 	src.seed(s)
 }
 
+// SeedBytes initializes the state vector with seed b. The seed b may have an arbitrary length.
+func (src *MT64Source) SeedBytes(b []byte) {
+	// Initialization of the state vector with the expanded seed b
+	src.expand(NewSeedExpanderBytes(b))
+}
+
+// expand fills the state vector with the output of e. As in init_by_array of the reference
+// implementation, the most significant bit is set to assure a non-zero initial state vector.
+func (src *MT64Source) expand(e *SeedExpander) {
+	e.Fill(src.mt)
+	src.mt[0] = uint64(1) << 63
+	src.mti = mt64c.n
+}
+
+// SeedArray initializes the state vector with the array key. The implementation is based on init_by_array64 of the
+// reference implementation mt19937-64.c. If key is empty, the state vector is initialized as for a key with a single zero word.
+func (src *MT64Source) SeedArray(key []uint64) {
+	// Use a single zero word for an empty key
+	if len(key) == 0 {
+		key = []uint64{0}
+	}
+	// Initialize state vector with init_genrand64
+	src.seed(19650218)
+	i, j := 1, 0
+	k := mt64c.n
+	if len(key) > k {
+		k = len(key)
+	}
+	for ; k > 0; k-- {
+		// Non-linear mixing of the key into the state vector
+		src.mt[i] = (src.mt[i] ^ ((src.mt[i-1] ^ (src.mt[i-1] >> 62)) * 3935559000370003845)) + key[j] + uint64(j)
+		i++
+		j++
+		if i >= mt64c.n {
+			src.mt[0] = src.mt[mt64c.n-1]
+			i = 1
+		}
+		if j >= len(key) {
+			j = 0
+		}
+	}
+	for k = mt64c.n - 1; k > 0; k-- {
+		// Non-linear mixing of the state vector
+		src.mt[i] = (src.mt[i] ^ ((src.mt[i-1] ^ (src.mt[i-1] >> 62)) * 2862933555777941757)) - uint64(i)
+		i++
+		if i >= mt64c.n {
+			src.mt[0] = src.mt[mt64c.n-1]
+			i = 1
+		}
+	}
+	// Most significant bit is 1 to assure a non-zero initial state vector
+	src.mt[0] = uint64(1) << 63
+	src.mti = mt64c.n
+}
+
 // Uint64 returns a pseudo-random 64-bit value. The implementation is
 // based on mt19937-64.c
 func (src *MT64Source) Uint64() uint64 {
@@ -101,3 +169,56 @@ func (src *MT64Source) Err() error {
 // Assert checks the availability of a random number generator source. For MT64Source, it is empty,
 // because the pseudo random number calculation is always available.
 func (src *MT64Source) Assert() {}
+
+// MarshalBinary implements encoding.BinaryMarshaler and returns the state of the MT64Source in a versioned binary format.
+func (src *MT64Source) MarshalBinary() ([]byte, error) {
+	// Create binary format with header
+	b := marshalHeader(marshalMT64, 4+8*mt64c.n)
+	// Append index and state vector
+	mt := src.mt
+	if mt == nil {
+		mt = make([]uint64, mt64c.n)
+	}
+	b = binary.BigEndian.AppendUint32(b, uint32(src.mti))
+	for _, v := range mt {
+		b = binary.BigEndian.AppendUint64(b, v)
+	}
+	// Append checksum and return binary format
+	return marshalChecksum(b), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler and restores the state of the MT64Source from data in the binary format
+// returned by MarshalBinary. It returns an error, if data is corrupted, has an unsupported version, belongs to another type
+// of source or contains an invalid state. In case of an error, the state is not changed.
+func (src *MT64Source) UnmarshalBinary(data []byte) error {
+	// Validate data and retrieve reader on state
+	r, e := unmarshalState(data, marshalMT64, 4+8*mt64c.n)
+	if e != nil {
+		return e
+	}
+	// Read index and return an error, if it is out of range
+	mti := int(r.uint32())
+	if mti > mt64c.n+1 {
+		return unmarshalInvalid(marshalTypes[marshalMT64], "index out of range")
+	}
+	// Read state vector
+	mt := make([]uint64, mt64c.n)
+	for i := range mt {
+		mt[i] = r.uint64()
+	}
+	// Restore state
+	src.mt, src.mti = mt, mti
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler and returns the state of the MT64Source in the binary format
+// returned by MarshalBinary encoded with standard base64 encoding.
+func (src *MT64Source) MarshalText() ([]byte, error) {
+	return marshalText(src)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler and restores the state of the MT64Source from text
+// returned by MarshalText. It returns an error, if text is invalid. In case of an error, the state is not changed.
+func (src *MT64Source) UnmarshalText(text []byte) error {
+	return unmarshalText(src, marshalTypes[marshalMT64], text)
+}
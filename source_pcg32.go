@@ -0,0 +1,148 @@
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tsrand
+
+// Import standard library packages
+import (
+	"encoding/binary" // encoding/binary
+)
+
+// PCG32Source implements Source64 and can be used as source for a rand.Rand. It is based on the
+// reference implementation of the PCG32 permuted congruential generator with XSH-RR output function.
+// PCG32Source holds the 64-bit state of the linear congruential generator and the increment inc, which
+// selects the stream. Different streams produce independent sequences for the same seed. A PCG32Source is not safe for
+// concurrent use by multiple goroutines. The output might be easily predictable and is unsuitable
+// for security-sensitive services.
+type PCG32Source struct {
+	state uint64 // state of the linear congruential generator
+	inc   uint64 // increment of the linear congruential generator, always odd
+}
+
+// Parameters based on the reference implementation pcg_basic.c of the PCG32 generator
+var (
+	pcg32c = struct {
+		multiplier, defaultState, defaultInc uint64
+	}{
+		multiplier:   6364136223846793005,
+		defaultState: 0x853c49e6748fea9b, // default state of PCG32_INITIALIZER
+		defaultInc:   0xda3e39cb94b95bdb, // default increment of PCG32_INITIALIZER
+	}
+)
+
+// NewPCG32Source returns a new instance of PCG32Source. PCG32Source implements Source64,
+// is based on the reference implementation of the PCG32 generator and can be used as source for a rand.Rand.
+// It is initialized with the default state and stream of the reference implementation.
+// A PCG32Source is not safe for concurrent use by multiple goroutines. The output might be
+// easily predictable and is unsuitable for security-sensitive services.
+func NewPCG32Source() *PCG32Source {
+	src := &PCG32Source{state: pcg32c.defaultState, inc: pcg32c.defaultInc}
+	return src
+}
+
+// Seed initializes the state with the expanded seed s. The currently selected stream is kept.
+func (src *PCG32Source) Seed(s int64) {
+	// Initialization of the state with the expanded seed s for the current stream
+	src.seed(NewSeedExpander(s).Uint64(), src.inc>>1)
+}
+
+// SeedBytes initializes the state with seed b. The seed b may have an arbitrary length.
+// The currently selected stream is kept.
+func (src *PCG32Source) SeedBytes(b []byte) {
+	// Initialization of the state with the expanded seed b for the current stream
+	src.seed(NewSeedExpanderBytes(b).Uint64(), src.inc>>1)
+}
+
+// SeedStream initializes the state with seed s and selects the stream seq. Only the lower 63 bits of seq are used.
+// Compatible with the reference implementation, the seed s is used as initstate without expansion.
+func (src *PCG32Source) SeedStream(s int64, seq uint64) {
+	// Initialization of the state with seed s and stream seq
+	src.seed(uint64(s), seq)
+}
+
+// seed initializes the state with initstate s and stream seq. The implementation is
+// based on pcg32_srandom_r of pcg_basic.c.
+func (src *PCG32Source) seed(s, seq uint64) {
+	src.state = 0
+	src.inc = (seq << 1) | 1
+	src.uint32()
+	src.state += s
+	src.uint32()
+}
+
+// uint32 returns a pseudo-random 32-bit value. The implementation is
+// based on pcg32_random_r of pcg_basic.c
+func (src *PCG32Source) uint32() uint32 {
+	old := src.state
+	// Advance internal state
+	src.state = old*pcg32c.multiplier + src.inc
+	// Output function XSH-RR based on the old state
+	xorshifted := uint32(((old >> 18) ^ old) >> 27)
+	rot := uint32(old >> 59)
+	return (xorshifted >> rot) | (xorshifted << ((-rot) & 31))
+}
+
+// Uint64 returns a pseudo-random 64-bit value. The pseudo-random value
+// is calculated by two calls of uint32().
+func (src *PCG32Source) Uint64() uint64 {
+	return uint64(src.uint32()) | uint64(src.uint32())<<32
+}
+
+// Int63 returns a pseudo-random 63-bit integer. The pseudo-random value
+// is calculated by two calls of uint32().
+func (src *PCG32Source) Int63() int64 {
+	return int64(src.Uint64() >> 1)
+}
+
+// Err provides the last occurring error of the random number generator source. Since
+// no used operation of PCG32Source returns an error, Err always returns nil.
+func (src *PCG32Source) Err() error {
+	return nil
+}
+
+// Assert checks the availability of a random number generator source. For PCG32Source, it is empty,
+// because the pseudo random number calculation is always available.
+func (src *PCG32Source) Assert() {}
+
+// MarshalBinary implements encoding.BinaryMarshaler and returns the state of the PCG32Source in a versioned binary format.
+func (src *PCG32Source) MarshalBinary() ([]byte, error) {
+	// Create binary format with header
+	b := marshalHeader(marshalPCG32, 16)
+	// Append state and increment
+	b = binary.BigEndian.AppendUint64(b, src.state)
+	b = binary.BigEndian.AppendUint64(b, src.inc)
+	// Append checksum and return binary format
+	return marshalChecksum(b), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler and restores the state of the PCG32Source from data in the binary format
+// returned by MarshalBinary. It returns an error, if data is corrupted, has an unsupported version, belongs to another type
+// of source or contains an invalid state. In case of an error, the state is not changed.
+func (src *PCG32Source) UnmarshalBinary(data []byte) error {
+	// Validate data and retrieve reader on state
+	r, e := unmarshalState(data, marshalPCG32, 16)
+	if e != nil {
+		return e
+	}
+	// Read state and increment
+	state, inc := r.uint64(), r.uint64()
+	// Return an error, if the increment is even
+	if inc&1 == 0 {
+		return unmarshalInvalid(marshalTypes[marshalPCG32], "even increment")
+	}
+	// Restore state
+	src.state, src.inc = state, inc
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler and returns the state of the PCG32Source in the binary format
+// returned by MarshalBinary encoded with standard base64 encoding.
+func (src *PCG32Source) MarshalText() ([]byte, error) {
+	return marshalText(src)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler and restores the state of the PCG32Source from text
+// returned by MarshalText. It returns an error, if text is invalid. In case of an error, the state is not changed.
+func (src *PCG32Source) UnmarshalText(text []byte) error {
+	return unmarshalText(src, marshalTypes[marshalPCG32], text)
+}
@@ -0,0 +1,171 @@
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tsrand
+
+// Import standard library packages
+import (
+	"encoding/binary" // encoding/binary
+	"math/bits"       // math/bits
+)
+
+// PCG64Source implements Source64 and can be used as source for a rand.Rand. It is based on the
+// PCG64 DXSM permuted congruential generator with a 128-bit state, the cheap 64-bit multiplier and
+// the DXSM (double xorshift multiply) output function as used by the reference implementation and numpy.
+// PCG64Source holds the 128-bit state of the linear congruential generator and the 128-bit increment inc, which
+// selects the stream. Different streams produce independent sequences for the same seed. A PCG64Source is not safe for
+// concurrent use by multiple goroutines. The output might be easily predictable and is unsuitable
+// for security-sensitive services.
+type PCG64Source struct {
+	state pcg128 // state of the linear congruential generator
+	inc   pcg128 // increment of the linear congruential generator, always odd
+}
+
+// pcg128 holds an unsigned 128-bit integer as high and low 64-bit words.
+type pcg128 struct {
+	hi, lo uint64 // high and low 64-bit word
+}
+
+// Parameters based on the reference implementation of the PCG64 DXSM generator
+var (
+	pcg64c = struct {
+		cheapMultiplier          uint64
+		defaultState, defaultInc pcg128
+	}{
+		cheapMultiplier: 0xda942042e4dd58b5,                                     // cheap 64-bit multiplier
+		defaultState:    pcg128{hi: 0x979c9a98d8462005, lo: 0x7d3e9cb6cfe0549b}, // default state of the initializer
+		defaultInc:      pcg128{hi: 0x0000000000000001, lo: 0xda3e39cb94b95bdb}, // default increment of the initializer
+	}
+)
+
+// NewPCG64Source returns a new instance of PCG64Source. PCG64Source implements Source64,
+// is based on the PCG64 DXSM generator and can be used as source for a rand.Rand.
+// It is initialized with the default state and stream of the reference implementation.
+// A PCG64Source is not safe for concurrent use by multiple goroutines. The output might be
+// easily predictable and is unsuitable for security-sensitive services.
+func NewPCG64Source() *PCG64Source {
+	src := &PCG64Source{state: pcg64c.defaultState, inc: pcg64c.defaultInc}
+	return src
+}
+
+// Seed initializes the state with the expanded seed s. The currently selected stream is kept.
+func (src *PCG64Source) Seed(s int64) {
+	// Initialization of the state with the expanded seed s for the current stream
+	src.expand(NewSeedExpander(s))
+}
+
+// SeedBytes initializes the state with seed b. The seed b may have an arbitrary length.
+// The currently selected stream is kept.
+func (src *PCG64Source) SeedBytes(b []byte) {
+	// Initialization of the state with the expanded seed b for the current stream
+	src.expand(NewSeedExpanderBytes(b))
+}
+
+// expand initializes the 128-bit state with two words of e for the current stream.
+func (src *PCG64Source) expand(e *SeedExpander) {
+	var s pcg128
+	s.hi, s.lo = e.Uint64(), e.Uint64()
+	src.seed(s, src.inc)
+}
+
+// SeedStream initializes the state with seed s and selects the stream seq. Compatible with the
+// reference implementation, the seed s is used as initstate without expansion.
+func (src *PCG64Source) SeedStream(s int64, seq uint64) {
+	// Initialization of the state with seed s and increment (seq << 1) | 1
+	src.seed(pcg128{lo: uint64(s)}, pcg128{hi: seq >> 63, lo: (seq << 1) | 1})
+}
+
+// seed initializes the state with initstate s and increment inc. The implementation is
+// based on pcg_cm_srandom_r of the reference implementation.
+func (src *PCG64Source) seed(s, inc pcg128) {
+	src.state = pcg128{}
+	src.inc = inc
+	src.step()
+	var c uint64
+	src.state.lo, c = bits.Add64(src.state.lo, s.lo, 0)
+	src.state.hi, _ = bits.Add64(src.state.hi, s.hi, c)
+	src.step()
+}
+
+// step advances the state of the linear congruential generator by state = state * cheapMultiplier + inc.
+func (src *PCG64Source) step() {
+	hi, lo := bits.Mul64(src.state.lo, pcg64c.cheapMultiplier)
+	hi += src.state.hi * pcg64c.cheapMultiplier
+	var c uint64
+	src.state.lo, c = bits.Add64(lo, src.inc.lo, 0)
+	src.state.hi, _ = bits.Add64(hi, src.inc.hi, c)
+}
+
+// Uint64 returns a pseudo-random 64-bit value. The implementation is
+// based on pcg_cm_random_r and applies the DXSM output function on the state before it is advanced.
+func (src *PCG64Source) Uint64() uint64 {
+	hi, lo := src.state.hi, src.state.lo|1
+	// Output function DXSM
+	hi ^= hi >> 32
+	hi *= pcg64c.cheapMultiplier
+	hi ^= hi >> 48
+	hi *= lo
+	// Advance internal state
+	src.step()
+	return hi
+}
+
+// Int63 returns a pseudo-random 63-bit integer.
+func (src *PCG64Source) Int63() int64 {
+	return int64(src.Uint64() >> 1)
+}
+
+// Err provides the last occurring error of the random number generator source. Since
+// no used operation of PCG64Source returns an error, Err always returns nil.
+func (src *PCG64Source) Err() error {
+	return nil
+}
+
+// Assert checks the availability of a random number generator source. For PCG64Source, it is empty,
+// because the pseudo random number calculation is always available.
+func (src *PCG64Source) Assert() {}
+
+// MarshalBinary implements encoding.BinaryMarshaler and returns the state of the PCG64Source in a versioned binary format.
+func (src *PCG64Source) MarshalBinary() ([]byte, error) {
+	// Create binary format with header
+	b := marshalHeader(marshalPCG64, 32)
+	// Append state and increment
+	for _, v := range []uint64{src.state.hi, src.state.lo, src.inc.hi, src.inc.lo} {
+		b = binary.BigEndian.AppendUint64(b, v)
+	}
+	// Append checksum and return binary format
+	return marshalChecksum(b), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler and restores the state of the PCG64Source from data in the binary format
+// returned by MarshalBinary. It returns an error, if data is corrupted, has an unsupported version, belongs to another type
+// of source or contains an invalid state. In case of an error, the state is not changed.
+func (src *PCG64Source) UnmarshalBinary(data []byte) error {
+	// Validate data and retrieve reader on state
+	r, e := unmarshalState(data, marshalPCG64, 32)
+	if e != nil {
+		return e
+	}
+	// Read state and increment
+	state := pcg128{hi: r.uint64(), lo: r.uint64()}
+	inc := pcg128{hi: r.uint64(), lo: r.uint64()}
+	// Return an error, if the increment is even
+	if inc.lo&1 == 0 {
+		return unmarshalInvalid(marshalTypes[marshalPCG64], "even increment")
+	}
+	// Restore state
+	src.state, src.inc = state, inc
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler and returns the state of the PCG64Source in the binary format
+// returned by MarshalBinary encoded with standard base64 encoding.
+func (src *PCG64Source) MarshalText() ([]byte, error) {
+	return marshalText(src)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler and restores the state of the PCG64Source from text
+// returned by MarshalText. It returns an error, if text is invalid. In case of an error, the state is not changed.
+func (src *PCG64Source) UnmarshalText(text []byte) error {
+	return unmarshalText(src, marshalTypes[marshalPCG64], text)
+}
@@ -0,0 +1,182 @@
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tsrand
+
+// Import standard library packages
+import (
+	"encoding/binary" // encoding/binary
+	"math/bits"       // math/bits
+)
+
+// PhiloxSource implements Source64 and can be used as source for a rand.Rand. It is based on the counter-based Philox4x32-10
+// generator of Salmon et al. as implemented by the Random123 library. The i-th random value is a function of i and the key only.
+// Therefore, At returns any value of the stream without generating the preceding values and SetCounter moves the stream to any
+// position in constant time. Each 128-bit block of Philox4x32-10 for counter c provides the values 2c and 2c+1 in little-endian order.
+// PhiloxSource holds the 64-bit key, the 64-bit stream id in the upper half of the 128-bit block counter, the index of the next value and
+// the current block. Different stream ids result in independent streams for the same key. A PhiloxSource is not safe for concurrent use
+// by multiple goroutines. The output might be easily predictable and is unsuitable for security-sensitive services.
+type PhiloxSource struct {
+	key    [2]uint32 // key
+	stream uint64    // stream id, upper 64 bits of the block counter
+	n      uint64    // index of the next value
+	block  [4]uint32 // block of the counter n/2, valid if n is odd
+}
+
+// Parameters of Philox4x32-10 based on the Random123 library
+var (
+	philoxc = struct {
+		m0, m1 uint32 // multipliers
+		w0, w1 uint32 // Weyl sequence increments of the key
+		rounds int    // number of rounds
+	}{
+		m0:     0xd2511f53, // multiplier of the first word
+		m1:     0xcd9e8d57, // multiplier of the third word
+		w0:     0x9e3779b9, // golden ratio increment of the first key word
+		w1:     0xbb67ae85, // sqrt(3)-1 increment of the second key word
+		rounds: 10,         // number of rounds
+	}
+)
+
+// NewPhiloxSource returns a new instance of PhiloxSource. PhiloxSource implements Source64, is based on the counter-based
+// Philox4x32-10 generator and can be used as source for a rand.Rand. It is initialized with key 0, stream 0 and counter 0.
+// A PhiloxSource is not safe for concurrent use by multiple goroutines. The output might be easily predictable and is unsuitable
+// for security-sensitive services.
+func NewPhiloxSource() *PhiloxSource {
+	return &PhiloxSource{}
+}
+
+// Seed initializes the key with the expanded seed s and resets the stream id and counter to 0.
+func (src *PhiloxSource) Seed(s int64) {
+	// Initialization of the key with the expanded seed s
+	src.expand(NewSeedExpander(s))
+}
+
+// SeedBytes initializes the key with the expanded seed b and resets the stream id and counter to 0. The seed b may have an arbitrary length.
+func (src *PhiloxSource) SeedBytes(b []byte) {
+	// Initialization of the key with the expanded seed b
+	src.expand(NewSeedExpanderBytes(b))
+}
+
+// expand initializes the key with one word of e, stream 0 and counter 0.
+func (src *PhiloxSource) expand(e *SeedExpander) {
+	k := e.Uint64()
+	src.SeedKey([2]uint32{uint32(k), uint32(k >> 32)}, 0)
+}
+
+// SeedKey initializes the source with the 64-bit key and the 64-bit stream id. The counter is reset to 0.
+// The values 2c and 2c+1 equal the output of Philox4x32-10 of the Random123 library for the key and the block counter
+// {low and high word of c, low and high word of the stream id}.
+func (src *PhiloxSource) SeedKey(key [2]uint32, stream uint64) {
+	src.key, src.stream, src.n = key, stream, 0
+}
+
+// Counter returns the index of the next value of the stream.
+func (src *PhiloxSource) Counter() uint64 {
+	return src.n
+}
+
+// SetCounter sets the index of the next value of the stream to i. The next call of Uint64 returns At(i).
+func (src *PhiloxSource) SetCounter(i uint64) {
+	src.n = i
+	// Compute the current block, if the next value is the second half of a block
+	if i%2 == 1 {
+		src.block = philox(src.counter(i/2), src.key)
+	}
+}
+
+// At returns the value with index i of the stream without changing the state of the source.
+func (src *PhiloxSource) At(i uint64) uint64 {
+	b := philox(src.counter(i/2), src.key)
+	return uint64(b[2*(i%2)]) | uint64(b[2*(i%2)+1])<<32
+}
+
+// counter returns the 128-bit block counter of block c of the stream.
+func (src *PhiloxSource) counter(c uint64) [4]uint32 {
+	return [4]uint32{uint32(c), uint32(c >> 32), uint32(src.stream), uint32(src.stream >> 32)}
+}
+
+// Uint64 returns a pseudo-random 64-bit value. The value with index n is the half n%2 of the block with counter n/2.
+func (src *PhiloxSource) Uint64() uint64 {
+	// Compute the block, if the next value is the first half of a block
+	if src.n%2 == 0 {
+		src.block = philox(src.counter(src.n/2), src.key)
+	}
+	h := 2 * (src.n % 2)
+	src.n++
+	return uint64(src.block[h]) | uint64(src.block[h+1])<<32
+}
+
+// Int63 returns a pseudo-random 63-bit integer.
+func (src *PhiloxSource) Int63() int64 {
+	return int64(src.Uint64() >> 1)
+}
+
+// Err provides the last occurring error of the random number generator source. Since
+// no used operation of PhiloxSource returns an error, Err always returns nil.
+func (src *PhiloxSource) Err() error {
+	return nil
+}
+
+// Assert checks the availability of a random number generator source. For PhiloxSource, it is empty,
+// because the pseudo random number calculation is always available.
+func (src *PhiloxSource) Assert() {}
+
+// philox returns the block of Philox4x32-10 for counter ctr and key. The implementation is based on philox4x32 of the Random123 library.
+func philox(ctr [4]uint32, key [2]uint32) [4]uint32 {
+	c0, c1, c2, c3 := ctr[0], ctr[1], ctr[2], ctr[3]
+	k0, k1 := key[0], key[1]
+	for r := 0; r < philoxc.rounds; r++ {
+		// Bump the key before each round except the first
+		if r > 0 {
+			k0 += philoxc.w0
+			k1 += philoxc.w1
+		}
+		// Round of Philox4x32
+		hi0, lo0 := bits.Mul32(philoxc.m0, c0)
+		hi1, lo1 := bits.Mul32(philoxc.m1, c2)
+		c0, c1, c2, c3 = hi1^c1^k0, lo1, hi0^c3^k1, lo0
+	}
+	return [4]uint32{c0, c1, c2, c3}
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler and returns the state of the PhiloxSource in a versioned binary format.
+func (src *PhiloxSource) MarshalBinary() ([]byte, error) {
+	// Create binary format with header
+	b := marshalHeader(marshalPhilox, 24)
+	// Append key, stream id and counter
+	b = binary.BigEndian.AppendUint32(b, src.key[0])
+	b = binary.BigEndian.AppendUint32(b, src.key[1])
+	b = binary.BigEndian.AppendUint64(b, src.stream)
+	b = binary.BigEndian.AppendUint64(b, src.n)
+	// Append checksum and return binary format
+	return marshalChecksum(b), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler and restores the state of the PhiloxSource from data in the binary format
+// returned by MarshalBinary. It returns an error, if data is corrupted, has an unsupported version or belongs to another type
+// of source. In case of an error, the state is not changed.
+func (src *PhiloxSource) UnmarshalBinary(data []byte) error {
+	// Validate data and retrieve reader on state
+	r, e := unmarshalState(data, marshalPhilox, 24)
+	if e != nil {
+		return e
+	}
+	// Restore key and stream id and set the counter, which computes the current block
+	src.key = [2]uint32{r.uint32(), r.uint32()}
+	src.stream = r.uint64()
+	src.SetCounter(r.uint64())
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler and returns the state of the PhiloxSource in the binary format
+// returned by MarshalBinary encoded with standard base64 encoding.
+func (src *PhiloxSource) MarshalText() ([]byte, error) {
+	return marshalText(src)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler and restores the state of the PhiloxSource from text
+// returned by MarshalText. It returns an error, if text is invalid. In case of an error, the state is not changed.
+func (src *PhiloxSource) UnmarshalText(text []byte) error {
+	return unmarshalText(src, marshalTypes[marshalPhilox], text)
+}
@@ -5,7 +5,8 @@ package tsrand
 
 // Import standard library packages and lpstats
 import (
-	"math" // math
+	"encoding/binary" // encoding/binary
+	"math"            // math
 
 	"github.com/thorstenrie/lpstats" // lpstats
 )
@@ -71,3 +72,39 @@ func (ex *SimpleSource) Err() error {
 // Assert checks the availability of a random number generator source. For SimpleSource, it is empty,
 // because the pseudo random number calculation is always available.
 func (ex *SimpleSource) Assert() {}
+
+// MarshalBinary implements encoding.BinaryMarshaler and returns the state of the SimpleSource in a versioned binary format.
+func (ex *SimpleSource) MarshalBinary() ([]byte, error) {
+	// Create binary format with header
+	b := marshalHeader(marshalSimple, 8)
+	// Append seed
+	b = binary.BigEndian.AppendUint64(b, uint64(ex.s))
+	// Append checksum and return binary format
+	return marshalChecksum(b), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler and restores the state of the SimpleSource from data in the binary format
+// returned by MarshalBinary. It returns an error, if data is corrupted, has an unsupported version, belongs to another type
+// of source or contains an invalid state. In case of an error, the state is not changed.
+func (ex *SimpleSource) UnmarshalBinary(data []byte) error {
+	// Validate data and retrieve reader on state
+	r, e := unmarshalState(data, marshalSimple, 8)
+	if e != nil {
+		return e
+	}
+	// Restore seed
+	ex.s = int64(r.uint64())
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler and returns the state of the SimpleSource in the binary format
+// returned by MarshalBinary encoded with standard base64 encoding.
+func (ex *SimpleSource) MarshalText() ([]byte, error) {
+	return marshalText(ex)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler and restores the state of the SimpleSource from text
+// returned by MarshalText. It returns an error, if text is invalid. In case of an error, the state is not changed.
+func (ex *SimpleSource) UnmarshalText(text []byte) error {
+	return unmarshalText(ex, marshalTypes[marshalSimple], text)
+}
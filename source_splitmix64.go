@@ -0,0 +1,107 @@
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tsrand
+
+// Import standard library packages
+import (
+	"encoding/binary" // encoding/binary
+)
+
+// SplitMix64Source implements Source64 and can be used as source for a rand.Rand. It is based on the
+// reference implementation splitmix64.c by Sebastiano Vigna. SplitMix64Source holds the 64-bit pseudo-random
+// number generator internal state x. Every seed results in a full period of 2^64. A SplitMix64Source is not
+// safe for concurrent use by multiple goroutines. The output might be easily predictable and is unsuitable
+// for security-sensitive services.
+type SplitMix64Source struct {
+	x uint64 // state
+}
+
+// NewSplitMix64Source returns a new instance of SplitMix64Source initialized with the default seed. SplitMix64Source
+// implements Source64, is based on the reference implementation of splitmix64 and can be used as source for a rand.Rand.
+// A SplitMix64Source is not safe for concurrent use by multiple goroutines. The output might be
+// easily predictable and is unsuitable for security-sensitive services.
+func NewSplitMix64Source() *SplitMix64Source {
+	src := &SplitMix64Source{}
+	src.Seed(defaultSeed)
+	return src
+}
+
+// Seed initializes the state with seed s.
+func (src *SplitMix64Source) Seed(s int64) {
+	// Initialization of the state with seed s
+	src.x = uint64(s)
+}
+
+// SeedBytes initializes the state with seed b. The seed b may have an arbitrary length.
+func (src *SplitMix64Source) SeedBytes(b []byte) {
+	// Initialization of the state with the expanded seed b
+	src.x = NewSeedExpanderBytes(b).Uint64()
+}
+
+// Uint64 returns a pseudo-random 64-bit value. The implementation is
+// based on splitmix64.c
+func (src *SplitMix64Source) Uint64() uint64 {
+	return splitmix64(&src.x)
+}
+
+// Int63 returns a pseudo-random 63-bit integer.
+func (src *SplitMix64Source) Int63() int64 {
+	return int64(src.Uint64() >> 1)
+}
+
+// Err provides the last occurring error of the random number generator source. Since
+// no used operation of SplitMix64Source returns an error, Err always returns nil.
+func (src *SplitMix64Source) Err() error {
+	return nil
+}
+
+// Assert checks the availability of a random number generator source. For SplitMix64Source, it is empty,
+// because the pseudo random number calculation is always available.
+func (src *SplitMix64Source) Assert() {}
+
+// splitmix64 returns the next 64-bit value of the splitmix64 generator with state x and advances x.
+// The implementation is based on splitmix64.c by Sebastiano Vigna.
+func splitmix64(x *uint64) uint64 {
+	*x += 0x9e3779b97f4a7c15
+	z := *x
+	z = (z ^ (z >> 30)) * 0xbf58476d1ce4e5b9
+	z = (z ^ (z >> 27)) * 0x94d049bb133111eb
+	return z ^ (z >> 31)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler and returns the state of the SplitMix64Source in a versioned binary format.
+func (src *SplitMix64Source) MarshalBinary() ([]byte, error) {
+	// Create binary format with header
+	b := marshalHeader(marshalSplitMix64, 8)
+	// Append state
+	b = binary.BigEndian.AppendUint64(b, src.x)
+	// Append checksum and return binary format
+	return marshalChecksum(b), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler and restores the state of the SplitMix64Source from data in the binary format
+// returned by MarshalBinary. It returns an error, if data is corrupted, has an unsupported version, belongs to another type
+// of source or contains an invalid state. In case of an error, the state is not changed.
+func (src *SplitMix64Source) UnmarshalBinary(data []byte) error {
+	// Validate data and retrieve reader on state
+	r, e := unmarshalState(data, marshalSplitMix64, 8)
+	if e != nil {
+		return e
+	}
+	// Restore state
+	src.x = r.uint64()
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler and returns the state of the SplitMix64Source in the binary format
+// returned by MarshalBinary encoded with standard base64 encoding.
+func (src *SplitMix64Source) MarshalText() ([]byte, error) {
+	return marshalText(src)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler and restores the state of the SplitMix64Source from text
+// returned by MarshalText. It returns an error, if text is invalid. In case of an error, the state is not changed.
+func (src *SplitMix64Source) UnmarshalText(text []byte) error {
+	return unmarshalText(src, marshalTypes[marshalSplitMix64], text)
+}
@@ -0,0 +1,542 @@
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tsrand
+
+// Import standard library packages and tserr
+import (
+	"encoding"        // encoding
+	"encoding/binary" // encoding/binary
+	"encoding/hex"    // encoding/hex
+	"fmt"             // fmt
+	"math"            // math
+	"os"              // os
+	"path/filepath"   // path/filepath
+	"strconv"         // strconv
+	"strings"         // strings
+	"testing"         // testing
+
+	"github.com/thorstenrie/tserr" // tserr
+)
+
+// The reference vector tests compare the output of the sources with the output of the reference implementations
+// of the underlying generators. The tests fail, if the output of a source differs from the expected values.
+
+// TestPCG32Reference compares the output of PCG32Source with the published output of pcg32-demo.c of the
+// reference implementation seeded with initstate 42 and initseq 54.
+func TestPCG32Reference(t *testing.T) {
+	// Expected values of the reference implementation
+	want := []uint32{0xa15c02b7, 0x7b47f409, 0xba1d3330, 0x83d2f293, 0xbfa4784b, 0xcbed606e}
+	// Create a new PCG32Source and seed it with state 42 and stream 54
+	src := NewPCG32Source()
+	src.SeedStream(42, 54)
+	// Compare output with the expected values
+	for i, w := range want {
+		if v := src.uint32(); v != w {
+			t.Error(tserr.NotEqualStr(&tserr.NotEqualStrArgs{X: fmt.Sprintf("output %d: %#08x", i, v), Y: fmt.Sprintf("%#08x", w)}))
+		}
+	}
+}
+
+// TestPCG32Stream tests, if two PCG32Sources with the same seed, but different streams, return different values
+// and if Seed keeps the selected stream.
+func TestPCG32Stream(t *testing.T) {
+	// Create two PCG32Sources with the same seed and different streams
+	src1, src2 := NewPCG32Source(), NewPCG32Source()
+	src1.SeedStream(42, 54)
+	src2.SeedStream(42, 55)
+	// The test fails, if the sources return the same value
+	if src1.Uint64() == src2.Uint64() {
+		t.Error(tserr.Forbidden("equal output of different streams"))
+	}
+	// Seed the second source with 42, which keeps stream 55, and the first source with the expanded seed 42 and stream 55
+	src1.SeedStream(int64(NewSeedExpander(42).Uint64()), 55)
+	src2.Seed(42)
+	// The test fails, if the sources return different values
+	if v1, v2 := src1.Uint64(), src2.Uint64(); v1 != v2 {
+		t.Error(tserr.NotEqualStr(&tserr.NotEqualStrArgs{X: fmt.Sprintf("%#x", v1), Y: fmt.Sprintf("%#x", v2)}))
+	}
+}
+
+// TestPCG64Reference compares the output of PCG64Source with the output of the PCG64 DXSM reference
+// implementation seeded with initstate 42 and initseq 54 and with the default initializer.
+func TestPCG64Reference(t *testing.T) {
+	// Expected values of the reference implementation for initstate 42 and initseq 54
+	want := []uint64{0xf0847c9518bddb90, 0x8e7d5f5514ba8aaa, 0x86fbd36f8028f6fd, 0x8d14b6edbe9f740a, 0xa85b2896c7cad55d, 0x8ca3894a1d9227bb}
+	// Create a new PCG64Source and seed it with state 42 and stream 54
+	src := NewPCG64Source()
+	src.SeedStream(42, 54)
+	// Compare output with the expected values
+	testReference(t, src, want)
+	// Expected values of the reference implementation for the default initializer
+	want = []uint64{0x122c0e717ecaa7bc, 0x52d49026d88e8ae3, 0x9ecab6d014c66c49}
+	// Compare output of a new PCG64Source with the expected values
+	testReference(t, NewPCG64Source(), want)
+}
+
+// testReference retrieves len(want) values from src with Uint64 and compares them with want.
+// The test fails, if a retrieved value does not equal the expected value.
+func testReference(t *testing.T, src Source, want []uint64) {
+	// Panic if t is nil
+	if t == nil {
+		panic("nil pointer")
+	}
+	// The test fails if src is nil
+	if src == nil {
+		t.Fatal(tserr.NilPtr())
+	}
+	// Compare output with the expected values
+	for i, w := range want {
+		if v := src.Uint64(); v != w {
+			t.Error(tserr.NotEqualStr(&tserr.NotEqualStrArgs{X: fmt.Sprintf("output %d: %#016x", i, v), Y: fmt.Sprintf("%#016x", w)}))
+		}
+	}
+}
+
+// TestXoshiro256StarStarReference compares the output of Xoshiro256StarStarSource with the output of the reference
+// implementation xoshiro256starstar.c with the state initialized to {1, 2, 3, 4}, after Jump and after LongJump.
+func TestXoshiro256StarStarReference(t *testing.T) {
+	// Create a new Xoshiro256StarStarSource with state {1, 2, 3, 4}
+	src := &Xoshiro256StarStarSource{s: [4]uint64{1, 2, 3, 4}}
+	// Compare output with the expected values
+	testReference(t, src, []uint64{0x0000000000002d00, 0x0000000000000000, 0x000000005a007080, 0x10e0000000009d80, 0x10e0b61ce1009d80, 0x0870021ce143ad00})
+	// Reset state, jump and compare output with the expected values
+	src.s = [4]uint64{1, 2, 3, 4}
+	src.Jump()
+	testReference(t, src, []uint64{0xbbd2f312298443d8, 0x62e57db2d5706577, 0x34d1890374a6d72b})
+	// Reset state, long jump and compare output with the expected values
+	src.s = [4]uint64{1, 2, 3, 4}
+	src.LongJump()
+	testReference(t, src, []uint64{0x527752a1d792704d, 0xd8d8bdec57599e64, 0x601cb926727eb003})
+}
+
+// TestXoshiro256PlusReference compares the output of Xoshiro256PlusSource with the output of the reference
+// implementation xoshiro256plus.c with the state initialized to {1, 2, 3, 4}.
+func TestXoshiro256PlusReference(t *testing.T) {
+	// Create a new Xoshiro256PlusSource with state {1, 2, 3, 4}
+	src := &Xoshiro256PlusSource{s: [4]uint64{1, 2, 3, 4}}
+	// Compare output with the expected values
+	testReference(t, src, []uint64{0x0000000000000005, 0x0000c00000000007, 0x0000c00018000007, 0x8001600018040302, 0x8061900024040305, 0xc0617014120f0583})
+	// Jump and compare the state with the expected state after 2^128 steps
+	src.s = [4]uint64{1, 2, 3, 4}
+	src.Jump()
+	testState(t, src.s[:], []uint64{0x8c7a153956b5f3d1, 0x701f1a713401d85e, 0x6527f66a65469085, 0x8386b786c4408050})
+	// Long jump and compare the state with the expected state after 2^192 steps
+	src.s = [4]uint64{1, 2, 3, 4}
+	src.LongJump()
+	testState(t, src.s[:], []uint64{0x096a8eb71295a400, 0xdbf84991e50f4516, 0x534ee745810d2a0e, 0x31655ca1a2215bf1})
+}
+
+// TestXoroshiro128PlusPlusReference compares the output of Xoroshiro128PlusPlusSource with the output of the reference
+// implementation xoroshiro128plusplus.c with the state initialized to {1, 2}, after Jump and after LongJump.
+func TestXoroshiro128PlusPlusReference(t *testing.T) {
+	// Create a new Xoroshiro128PlusPlusSource with state {1, 2}
+	src := &Xoroshiro128PlusPlusSource{s: [2]uint64{1, 2}}
+	// Compare output with the expected values
+	testReference(t, src, []uint64{0x0000000000060001, 0x000260c000660007, 0x180acc04718606d3, 0x9e226d35036fc4c7, 0x849bc9ac6b960be4, 0x31c5870fc130361b})
+	// Reset state, jump and compare the state with the expected state after 2^64 steps
+	src.s = [2]uint64{1, 2}
+	src.Jump()
+	testState(t, src.s[:], []uint64{0x77b2ead123dde4bb, 0xf60f09e0665f8d42})
+	// Reset state, long jump and compare the state with the expected state after 2^96 steps
+	src.s = [2]uint64{1, 2}
+	src.LongJump()
+	testState(t, src.s[:], []uint64{0x1ecb960befaf39e9, 0x85fe3812041d7a83})
+}
+
+// TestSplitMix64Reference compares the output of SplitMix64Source with the output of the reference
+// implementation splitmix64.c seeded with 0.
+func TestSplitMix64Reference(t *testing.T) {
+	// Create a new SplitMix64Source and seed it with 0
+	src := NewSplitMix64Source()
+	src.Seed(0)
+	// Compare output with the expected values
+	testReference(t, src, []uint64{0xe220a8397b1dcdaf, 0x6e789e6aa1b965f4, 0x06c45d188009454f, 0xf88bb8a8724c81ec, 0x1b39896a51a8749b, 0x53cb9f0c747ea2ea})
+}
+
+// TestSeedExpander tests, if the SeedExpander returns the same words for the same seed and
+// different words for adjacent seeds, byte seeds and byte seeds only differing by trailing zeros.
+func TestSeedExpander(t *testing.T) {
+	// Expand seeds 1 and 2, the seed 1 twice, and byte seeds
+	s1, s2, s3 := make([]uint64, 4), make([]uint64, 4), make([]uint64, 4)
+	NewSeedExpander(1).Fill(s1)
+	NewSeedExpander(2).Fill(s2)
+	NewSeedExpander(1).Fill(s3)
+	b1, b2, b3 := make([]uint64, 4), make([]uint64, 4), make([]uint64, 4)
+	NewSeedExpanderBytes([]byte("tsrand")).Fill(b1)
+	NewSeedExpanderBytes([]byte("tsrand\x00")).Fill(b2)
+	NewSeedExpanderBytes([]byte("tsrand")).Fill(b3)
+	// Compare the expanded seeds
+	for i := range s1 {
+		// The test fails, if the same seed results in different words
+		if s1[i] != s3[i] || b1[i] != b3[i] {
+			t.Error(tserr.NotEqualStr(&tserr.NotEqualStrArgs{X: fmt.Sprintf("word %d of expanded seed", i), Y: "word of the same expanded seed"}))
+		}
+		// The test fails, if different seeds result in equal words
+		if s1[i] == s2[i] || b1[i] == b2[i] {
+			t.Error(tserr.Forbidden(fmt.Sprintf("equal word %d of different expanded seeds", i)))
+		}
+	}
+	// The 32-bit words are the lower and upper halves of the 64-bit words
+	w := make([]uint32, 4)
+	NewSeedExpander(1).Fill32(w)
+	if uint64(w[0])|uint64(w[1])<<32 != s1[0] || uint64(w[2])|uint64(w[3])<<32 != s1[1] {
+		t.Error(tserr.NotEqualStr(&tserr.NotEqualStrArgs{X: "32-bit words", Y: "halves of 64-bit words"}))
+	}
+}
+
+// TestSeedBytes tests, if the sources seeded with the same byte seed return the same values
+// and if they return different values for different byte seeds.
+func TestSeedBytes(t *testing.T) {
+	// Create sources implementing ByteSeeder
+	srcs := []func() Source{
+		func() Source { return NewMT32Source() },
+		func() Source { return NewMT64Source() },
+		func() Source { return NewPCG32Source() },
+		func() Source { return NewPCG64Source() },
+		func() Source { return NewXoshiro256StarStarSource() },
+		func() Source { return NewXoshiro256PlusSource() },
+		func() Source { return NewXoroshiro128PlusPlusSource() },
+		func() Source { return NewSplitMix64Source() },
+	}
+	for i, f := range srcs {
+		// Seed two sources with the same seed and one with a different seed
+		src1, src2, src3 := f(), f(), f()
+		// The test fails, if the source does not implement ByteSeeder
+		bs1, ok1 := src1.(ByteSeeder)
+		bs2, ok2 := src2.(ByteSeeder)
+		bs3, ok3 := src3.(ByteSeeder)
+		if !ok1 || !ok2 || !ok3 {
+			t.Fatal(tserr.TypeNotMatching(&tserr.TypeNotMatchingArgs{Act: fmt.Sprintf("source %d", i), Want: "ByteSeeder"}))
+		}
+		bs1.SeedBytes([]byte("tsrand"))
+		bs2.SeedBytes([]byte("tsrand"))
+		bs3.SeedBytes([]byte("tsrane"))
+		v1, v2, v3 := src1.Uint64(), src2.Uint64(), src3.Uint64()
+		// The test fails, if the same seed results in different values
+		if v1 != v2 {
+			t.Error(tserr.NotEqualStr(&tserr.NotEqualStrArgs{X: fmt.Sprintf("source %d: %#x", i, v1), Y: fmt.Sprintf("%#x", v2)}))
+		}
+		// The test fails, if different seeds result in equal values
+		if v1 == v3 {
+			t.Error(tserr.Forbidden(fmt.Sprintf("source %d: equal output for different seeds", i)))
+		}
+	}
+}
+
+// testState compares the internal state s of a source with the expected state want.
+// The test fails, if the states differ.
+func testState(t *testing.T, s, want []uint64) {
+	// Panic if t is nil
+	if t == nil {
+		panic("nil pointer")
+	}
+	// The test fails, if the length of the states differ
+	if len(s) != len(want) {
+		t.Fatal(tserr.Equal(&tserr.EqualArgs{Var: "length of state", Actual: int64(len(s)), Want: int64(len(want))}))
+	}
+	// Compare each word of the state with the expected value
+	for i, w := range want {
+		if s[i] != w {
+			t.Error(tserr.NotEqualStr(&tserr.NotEqualStrArgs{X: fmt.Sprintf("state %d: %#016x", i, s[i]), Y: fmt.Sprintf("%#016x", w)}))
+		}
+	}
+}
+
+// TestChaCha20Reference compares the keystream of ChaChaSource using 20 rounds with the test vectors
+// of section 2.3.2 and appendix A.1 of RFC 8439.
+func TestChaCha20Reference(t *testing.T) {
+	// Create a new ChaCha20 source
+	src := NewChaCha20Source()
+	// Test vector of section 2.3.2 with key 00:01:...:1f, nonce 00:00:00:09:00:00:00:4a:00:00:00:00 and block count 1
+	var key [32]byte
+	for i := range key {
+		key[i] = byte(i)
+	}
+	src.SeedKey(key, 0)
+	// The 96-bit nonce of RFC 8439 occupies words 13 to 15, which hold the upper half of the block counter and the stream id
+	src.input[12], src.input[13], src.input[14], src.input[15] = 1, 0x09000000, 0x4a000000, 0
+	testKeystream(t, src, "10f1e7e4d13b5915500fdd1fa32071c4c7d1f4c733c068030422aa9ac3d46c4ed2826446079faa0914c2d705d98b02a2b5129cd1de164eb9cbd083e8a2503c4e")
+	// Test vectors #1 and #2 of appendix A.1 with zero key, zero nonce and block counts 0 and 1
+	src.SeedKey([32]byte{}, 0)
+	testKeystream(t, src, "76b8e0ada0f13d90405d6ae55386bd28bdd219b8a08ded1aa836efcc8b770dc7da41597c5157488d7724e03fb8d84a376a43b8f41518a11cc387b669b2ee6586")
+	testKeystream(t, src, "9f07e7be5551387a98ba977c732d080dcb0f29a048e3656912c6533e32ee7aed29b721769ce64e43d57133b074d839d531ed1f28510afb45ace10a1f4b794d6f")
+}
+
+// TestChaCha8Reference compares the keystream of ChaChaSource using 8 rounds with the
+// test vector for a zero key and a zero nonce.
+func TestChaCha8Reference(t *testing.T) {
+	// Create a new ChaCha8 source with zero key and stream 0
+	src := NewChaCha8Source()
+	src.SeedKey([32]byte{}, 0)
+	testKeystream(t, src, "3e00ef2f895f40d67f5bb8e81f09a5a12c840ec3ce9a7f3b181be188ef711a1e984ce172b9216f419f445367456d5619314a42a3da86b001387bfdb80e0cfe42")
+}
+
+// TestChaChaSeed tests, if ChaChaSource returns the same output for the same seed, different output for
+// different streams and if seeding from crypto/rand succeeds.
+func TestChaChaSeed(t *testing.T) {
+	// Create two sources seeded from crypto/rand
+	src1, src2 := NewChaCha20Source(), NewChaCha8Source()
+	// The test fails, if crypto/rand is not available
+	if src1.Err() != nil || src2.Err() != nil {
+		t.Fatal(tserr.NotAvailable(&tserr.NotAvailableArgs{S: "crypto/rand", Err: src1.Err()}))
+	}
+	// Seed both sources with the same seed, after reseeding with Seed the number of rounds is kept
+	src1.Seed(42)
+	src3 := NewChaCha20Source()
+	src3.Seed(42)
+	// The test fails, if the same seed results in different values
+	if v1, v3 := src1.Uint64(), src3.Uint64(); v1 != v3 {
+		t.Error(tserr.NotEqualStr(&tserr.NotEqualStrArgs{X: fmt.Sprintf("%#x", v1), Y: fmt.Sprintf("%#x", v3)}))
+	}
+	// Select different streams for the same key
+	src1.SeedKey([32]byte{1}, 1)
+	src3.SeedKey([32]byte{1}, 2)
+	// The test fails, if different streams result in equal values
+	if src1.Uint64() == src3.Uint64() {
+		t.Error(tserr.Forbidden("equal output of different streams"))
+	}
+}
+
+// testKeystream retrieves values from src with Uint64 and compares them in little-endian order
+// with the expected hex encoded keystream want. The test fails, if the keystream differs.
+func testKeystream(t *testing.T, src Source, want string) {
+	// Panic if t is nil
+	if t == nil {
+		panic("nil pointer")
+	}
+	// Decode the expected keystream into 64-bit values in little-endian order
+	b, e := hex.DecodeString(want)
+	if e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "DecodeString", Fn: want, Err: e}))
+	}
+	w := make([]uint64, len(b)/8)
+	for i := range w {
+		w[i] = binary.LittleEndian.Uint64(b[8*i:])
+	}
+	// Compare output with the expected values
+	testReference(t, src, w)
+}
+
+// TestMT32Reference compares the first 1000 outputs of MT32Source seeded with SeedArray with the canonical output
+// mt19937ar.out of the reference implementation mt19937ar.c and the first output for the default seed 5489.
+func TestMT32Reference(t *testing.T) {
+	// Read the expected values from the canonical output
+	want := testReadOutput(t, "mt19937ar.out")
+	// Create a new MT32Source and seed it with the key of the reference implementation
+	src := NewMT32Source()
+	src.SeedArray([]uint32{0x123, 0x234, 0x345, 0x456})
+	// Compare output with the expected values
+	for i, w := range want {
+		if v := src.uint32(); uint64(v) != w {
+			t.Error(tserr.Equal(&tserr.EqualArgs{Var: fmt.Sprintf("output %d", i), Actual: int64(v), Want: int64(w)}))
+		}
+	}
+	// The first output for the default seed is 3499211612
+	if v := NewMT32Source().uint32(); v != 3499211612 {
+		t.Error(tserr.Equal(&tserr.EqualArgs{Var: "output for default seed", Actual: int64(v), Want: 3499211612}))
+	}
+}
+
+// TestMT64Reference compares the first 1000 outputs of MT64Source seeded with SeedArray with the canonical output
+// mt19937-64.out of the reference implementation mt19937-64.c and the first output for the default seed 5489.
+func TestMT64Reference(t *testing.T) {
+	// Read the expected values from the canonical output
+	want := testReadOutput(t, "mt19937-64.out")
+	// Create a new MT64Source and seed it with the key of the reference implementation
+	src := NewMT64Source()
+	src.SeedArray([]uint64{0x12345, 0x23456, 0x34567, 0x45678})
+	// Compare output with the expected values
+	testReference(t, src, want)
+	// The first output for the default seed is 14514284786278117030
+	testReference(t, NewMT64Source(), []uint64{14514284786278117030})
+}
+
+// TestMTGenrand tests that MT32Source and MT64Source seeded with SeedGenrand reproduce the output of init_genrand of the reference
+// implementations, which was the output of Seed of earlier versions. The 10000th output for seed 5489 is specified for std::mt19937
+// and std::mt19937_64 of C++11, the first output of std::mt19937 for seed 1 is 1791095845.
+func TestMTGenrand(t *testing.T) {
+	mt32, mt64 := NewMT32Source(), NewMT64Source()
+	mt32.SeedGenrand(5489)
+	mt64.SeedGenrand(5489)
+	// Discard the first 9999 outputs
+	for i := 0; i < 9999; i++ {
+		mt32.uint32()
+		mt64.Uint64()
+	}
+	// The test fails, if the 10000th output differs from the specified value
+	if v := mt32.uint32(); v != 4123659995 {
+		t.Error(tserr.Equal(&tserr.EqualArgs{Var: "10000th output of MT32Source", Actual: int64(v), Want: 4123659995}))
+	}
+	testReference(t, mt64, []uint64{9981545732273789042})
+	// The test fails, if SeedGenrand does not reset the state to the same output
+	mt32.SeedGenrand(1)
+	v := mt32.uint32()
+	mt32.Seed(1)
+	if mt32.SeedGenrand(1); mt32.uint32() != v || v != 1791095845 {
+		t.Error(tserr.Equal(&tserr.EqualArgs{Var: "first output of MT32Source for seed 1", Actual: int64(v), Want: 1791095845}))
+	}
+}
+
+// testReadOutput reads the canonical output file fn of a Mersenne Twister reference implementation from testdata
+// and returns the 1000 integer outputs of the first section. The test fails, if the file cannot be read or parsed.
+func testReadOutput(t *testing.T, fn string) []uint64 {
+	// Panic if t is nil
+	if t == nil {
+		panic("nil pointer")
+	}
+	// Read file fn from testdata
+	b, e := os.ReadFile(filepath.Join("testdata", fn))
+	if e != nil {
+		t.Fatal(tserr.Op(&tserr.OpArgs{Op: "ReadFile", Fn: fn, Err: e}))
+	}
+	// The first line is the header of the integer outputs, which are followed by an empty line
+	lines := strings.Split(string(b), "\n")
+	var out []uint64
+	for _, l := range lines[1:] {
+		if strings.TrimSpace(l) == "" {
+			break
+		}
+		// Parse each integer output of the line
+		for _, f := range strings.Fields(l) {
+			v, e := strconv.ParseUint(f, 10, 64)
+			if e != nil {
+				t.Fatal(tserr.Op(&tserr.OpArgs{Op: "ParseUint", Fn: f, Err: e}))
+			}
+			out = append(out, v)
+		}
+	}
+	// The test fails, if the file does not contain 1000 integer outputs
+	if len(out) != 1000 {
+		t.Fatal(tserr.Equal(&tserr.EqualArgs{Var: "number of outputs in " + fn, Actual: int64(len(out)), Want: 1000}))
+	}
+	// Return integer outputs
+	return out
+}
+
+// TestPhiloxReference compares the blocks of Philox4x32-10 with the known-answer tests of the Random123 library.
+func TestPhiloxReference(t *testing.T) {
+	for _, c := range []struct {
+		ctr, want [4]uint32
+		key       [2]uint32
+	}{
+		{[4]uint32{}, [4]uint32{0x6627e8d5, 0xe169c58d, 0xbc57ac4c, 0x9b00dbd8}, [2]uint32{}},
+		{[4]uint32{0xffffffff, 0xffffffff, 0xffffffff, 0xffffffff}, [4]uint32{0x408f276d, 0x41c83b0e, 0xa20bc7c6, 0x6d5451fd}, [2]uint32{0xffffffff, 0xffffffff}},
+		{[4]uint32{0x243f6a88, 0x85a308d3, 0x13198a2e, 0x03707344}, [4]uint32{0xd16cfe09, 0x94fdcceb, 0x5001e420, 0x24126ea1}, [2]uint32{0xa4093822, 0x299f31d0}},
+	} {
+		// The test fails, if the block does not equal the known answer
+		if b := philox(c.ctr, c.key); b != c.want {
+			t.Error(tserr.NotEqualStr(&tserr.NotEqualStrArgs{X: fmt.Sprintf("%08x", b), Y: fmt.Sprintf("%08x", c.want)}))
+		}
+	}
+	// The test fails, if the first values of PhiloxSource with key 0 do not equal the first known answer in little-endian order
+	src := NewPhiloxSource()
+	if v0, v1 := src.Uint64(), src.Uint64(); v0 != 0xe169c58d6627e8d5 || v1 != 0x9b00dbd8bc57ac4c {
+		t.Error(tserr.NotEqualStr(&tserr.NotEqualStrArgs{X: fmt.Sprintf("%#016x %#016x", v0, v1), Y: "0xe169c58d6627e8d5 0x9b00dbd8bc57ac4c"}))
+	}
+}
+
+// TestThreefryReference compares the blocks of Threefry4x64-20 with the known-answer tests of the Random123 library.
+func TestThreefryReference(t *testing.T) {
+	for _, c := range []struct {
+		ctr, key, want [4]uint64
+	}{
+		{[4]uint64{}, [4]uint64{}, [4]uint64{0x09218ebde6c85537, 0x55941f5266d86105, 0x4bd25e16282434dc, 0xee29ec846bd2e40b}},
+		{[4]uint64{math.MaxUint64, math.MaxUint64, math.MaxUint64, math.MaxUint64}, [4]uint64{math.MaxUint64, math.MaxUint64, math.MaxUint64, math.MaxUint64},
+			[4]uint64{0x29c24097942bba1b, 0x0371bbfb0f6f4e11, 0x3c231ffa33f83a1c, 0xcd29113fde32d168}},
+		// The key of the known-answer test repeats the word 0xbe5466cf34e90c6c of the hexadecimal digits of pi
+		{[4]uint64{0x243f6a8885a308d3, 0x13198a2e03707344, 0xa4093822299f31d0, 0x082efa98ec4e6c89}, [4]uint64{0x452821e638d01377, 0xbe5466cf34e90c6c, 0xbe5466cf34e90c6c, 0xc0ac29b7c97c50dd},
+			[4]uint64{0xa7e8fde591651bd9, 0xbaafd0c30138319b, 0x84a5c1a729e685b9, 0x901d406ccebc1ba4}},
+	} {
+		// The test fails, if the block does not equal the known answer
+		if b := threefry(c.ctr, c.key); b != c.want {
+			t.Error(tserr.NotEqualStr(&tserr.NotEqualStrArgs{X: fmt.Sprintf("%016x", b), Y: fmt.Sprintf("%016x", c.want)}))
+		}
+	}
+	// The test fails, if the first values of ThreefrySource with key 0 do not equal the first known answer
+	src := NewThreefrySource()
+	for i, w := range []uint64{0x09218ebde6c85537, 0x55941f5266d86105, 0x4bd25e16282434dc, 0xee29ec846bd2e40b} {
+		if v := src.Uint64(); v != w {
+			t.Error(tserr.NotEqualStr(&tserr.NotEqualStrArgs{X: fmt.Sprintf("value %d: %#016x", i, v), Y: fmt.Sprintf("%#016x", w)}))
+		}
+	}
+}
+
+// counterSource is implemented by the counter-based sources
+type counterSource interface {
+	Source
+	At(i uint64) uint64
+	Counter() uint64
+	SetCounter(i uint64)
+}
+
+// TestCounterAt tests for the counter-based sources, that At returns the values of Uint64 without changing the state, SetCounter
+// moves the stream to any position including the second half of a block and Counter returns the index of the next value.
+func TestCounterAt(t *testing.T) {
+	for name, f := range map[string]func() counterSource{
+		"PhiloxSource":   func() counterSource { return NewPhiloxSource() },
+		"ThreefrySource": func() counterSource { return NewThreefrySource() },
+	} {
+		src := f()
+		src.Seed(defaultSeed)
+		// Retrieve the first values with Uint64
+		vals := make([]uint64, 12)
+		for i := range vals {
+			vals[i] = src.Uint64()
+		}
+		// The test fails, if Counter does not return the number of retrieved values
+		if c := src.Counter(); c != uint64(len(vals)) {
+			t.Error(tserr.Equal(&tserr.EqualArgs{Var: name + " Counter", Actual: int64(c), Want: int64(len(vals))}))
+		}
+		// The test fails, if At does not return the retrieved values or changes the state
+		for i, w := range vals {
+			if v := src.At(uint64(i)); v != w {
+				t.Error(tserr.NotEqualStr(&tserr.NotEqualStrArgs{X: fmt.Sprintf("%s At(%d): %#016x", name, i, v), Y: fmt.Sprintf("%#016x", w)}))
+			}
+		}
+		if src.Counter() != uint64(len(vals)) {
+			t.Error(tserr.Forbidden(name + " At changed the counter"))
+		}
+		// The test fails, if Uint64 after SetCounter does not return the retrieved values
+		for _, i := range []uint64{0, 1, 3, 5, 10} {
+			if src.SetCounter(i); src.Uint64() != vals[i] || src.Uint64() != vals[i+1] {
+				t.Error(tserr.Return(&tserr.ReturnArgs{Op: fmt.Sprintf("%s Uint64 after SetCounter(%d)", name, i), Actual: "different value", Want: fmt.Sprintf("%#016x", vals[i])}))
+			}
+		}
+		// The test fails, if the value at a large index does not equal the value after SetCounter
+		src.SetCounter(1<<62 + 3)
+		if v, w := src.Uint64(), src.At(1<<62+3); v != w {
+			t.Error(tserr.NotEqualStr(&tserr.NotEqualStrArgs{X: fmt.Sprintf("%s value 2^62+3: %#016x", name, v), Y: fmt.Sprintf("%#016x", w)}))
+		}
+		// The test fails, if a state restored within a block does not continue the stream
+		src.SetCounter(1)
+		b, _ := src.(encoding.BinaryMarshaler).MarshalBinary()
+		restored := f()
+		if e := restored.(encoding.BinaryUnmarshaler).UnmarshalBinary(b); e != nil || restored.Uint64() != vals[1] {
+			t.Error(tserr.Return(&tserr.ReturnArgs{Op: name + " Uint64 after UnmarshalBinary", Actual: "different value", Want: fmt.Sprintf("%#016x", vals[1])}))
+		}
+	}
+}
+
+// TestCounterStream tests, if the counter-based sources return different output for different streams of the same key
+// and the same output for the same seed.
+func TestCounterStream(t *testing.T) {
+	p1, p2 := NewPhiloxSource(), NewPhiloxSource()
+	p1.SeedKey([2]uint32{1, 2}, 1)
+	p2.SeedKey([2]uint32{1, 2}, 2)
+	t1, t2 := NewThreefrySource(), NewThreefrySource()
+	t1.SeedKey([4]uint64{1, 2, 3, 4}, 1)
+	t2.SeedKey([4]uint64{1, 2, 3, 4}, 2)
+	// The test fails, if different streams result in equal values
+	if p1.Uint64() == p2.Uint64() || t1.Uint64() == t2.Uint64() {
+		t.Error(tserr.Forbidden("equal output of different streams"))
+	}
+	// The test fails, if the same seed results in different values
+	p1.Seed(42)
+	p2.Seed(42)
+	t1.Seed(42)
+	t2.Seed(42)
+	if p1.Uint64() != p2.Uint64() || t1.Uint64() != t2.Uint64() {
+		t.Error(tserr.Forbidden("different output of the same seed"))
+	}
+}
@@ -0,0 +1,199 @@
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tsrand
+
+// Import standard library packages
+import (
+	"encoding/binary" // encoding/binary
+	"math/bits"       // math/bits
+)
+
+// ThreefrySource implements Source64 and can be used as source for a rand.Rand. It is based on the counter-based Threefry4x64-20
+// generator of Salmon et al. as implemented by the Random123 library, which is derived from the Threefish block cipher. Like for PhiloxSource,
+// the i-th random value is a function of i and the key only. At returns any value of the stream and SetCounter moves the stream to any position
+// in constant time. Each 256-bit block of Threefry4x64-20 for counter c provides the values 4c to 4c+3. ThreefrySource holds the 256-bit key,
+// the 64-bit stream id in the second word of the 256-bit block counter, the index of the next value and the current block. Different stream ids
+// result in independent streams for the same key. A ThreefrySource is not safe for concurrent use by multiple goroutines. The output might be
+// easily predictable and is unsuitable for security-sensitive services.
+type ThreefrySource struct {
+	key    [4]uint64 // key
+	stream uint64    // stream id, second word of the block counter
+	n      uint64    // index of the next value
+	block  [4]uint64 // block of the counter n/4, valid if n is not a multiple of 4
+}
+
+// Parameters of Threefry4x64-20 based on the Random123 library
+var (
+	threefryc = struct {
+		parity uint64     // parity constant of the key schedule
+		rot    [8][2]uint // rotation constants of the rounds
+		rounds int        // number of rounds
+	}{
+		parity: 0x1bd11bdaa9fc1a22,                                                                        // parity constant of the key schedule of Threefish
+		rot:    [8][2]uint{{14, 16}, {52, 57}, {23, 40}, {5, 37}, {25, 33}, {46, 12}, {58, 22}, {32, 32}}, // rotation constants of Threefry4x64
+		rounds: 20,                                                                                        // number of rounds
+	}
+)
+
+// NewThreefrySource returns a new instance of ThreefrySource. ThreefrySource implements Source64, is based on the counter-based
+// Threefry4x64-20 generator and can be used as source for a rand.Rand. It is initialized with key 0, stream 0 and counter 0.
+// A ThreefrySource is not safe for concurrent use by multiple goroutines. The output might be easily predictable and is unsuitable
+// for security-sensitive services.
+func NewThreefrySource() *ThreefrySource {
+	return &ThreefrySource{}
+}
+
+// Seed initializes the key with the expanded seed s and resets the stream id and counter to 0.
+func (src *ThreefrySource) Seed(s int64) {
+	// Initialization of the key with the expanded seed s
+	src.expand(NewSeedExpander(s))
+}
+
+// SeedBytes initializes the key with the expanded seed b and resets the stream id and counter to 0. The seed b may have an arbitrary length.
+func (src *ThreefrySource) SeedBytes(b []byte) {
+	// Initialization of the key with the expanded seed b
+	src.expand(NewSeedExpanderBytes(b))
+}
+
+// expand initializes the key with four words of e, stream 0 and counter 0.
+func (src *ThreefrySource) expand(e *SeedExpander) {
+	var key [4]uint64
+	e.Fill(key[:])
+	src.SeedKey(key, 0)
+}
+
+// SeedKey initializes the source with the 256-bit key and the 64-bit stream id. The counter is reset to 0. The values 4c to 4c+3 equal the output
+// of Threefry4x64-20 of the Random123 library for the key and the block counter {c, stream id, 0, 0}.
+func (src *ThreefrySource) SeedKey(key [4]uint64, stream uint64) {
+	src.key, src.stream, src.n = key, stream, 0
+}
+
+// Counter returns the index of the next value of the stream.
+func (src *ThreefrySource) Counter() uint64 {
+	return src.n
+}
+
+// SetCounter sets the index of the next value of the stream to i. The next call of Uint64 returns At(i).
+func (src *ThreefrySource) SetCounter(i uint64) {
+	src.n = i
+	// Compute the current block, if the next value is not the first word of a block
+	if i%4 != 0 {
+		src.block = threefry(src.counter(i/4), src.key)
+	}
+}
+
+// At returns the value with index i of the stream without changing the state of the source.
+func (src *ThreefrySource) At(i uint64) uint64 {
+	return threefry(src.counter(i/4), src.key)[i%4]
+}
+
+// counter returns the 256-bit block counter of block c of the stream.
+func (src *ThreefrySource) counter(c uint64) [4]uint64 {
+	return [4]uint64{c, src.stream, 0, 0}
+}
+
+// Uint64 returns a pseudo-random 64-bit value. The value with index n is the word n%4 of the block with counter n/4.
+func (src *ThreefrySource) Uint64() uint64 {
+	// Compute the block, if the next value is the first word of a block
+	if src.n%4 == 0 {
+		src.block = threefry(src.counter(src.n/4), src.key)
+	}
+	v := src.block[src.n%4]
+	src.n++
+	return v
+}
+
+// Int63 returns a pseudo-random 63-bit integer.
+func (src *ThreefrySource) Int63() int64 {
+	return int64(src.Uint64() >> 1)
+}
+
+// Err provides the last occurring error of the random number generator source. Since
+// no used operation of ThreefrySource returns an error, Err always returns nil.
+func (src *ThreefrySource) Err() error {
+	return nil
+}
+
+// Assert checks the availability of a random number generator source. For ThreefrySource, it is empty,
+// because the pseudo random number calculation is always available.
+func (src *ThreefrySource) Assert() {}
+
+// threefry returns the block of Threefry4x64-20 for counter ctr and key. The implementation is based on threefry4x64 of the Random123 library.
+func threefry(ctr, key [4]uint64) [4]uint64 {
+	// Key schedule with the parity word
+	ks := [5]uint64{key[0], key[1], key[2], key[3], threefryc.parity ^ key[0] ^ key[1] ^ key[2] ^ key[3]}
+	// Initial key injection
+	x := ctr
+	for i := range x {
+		x[i] += ks[i]
+	}
+	for r := 0; r < threefryc.rounds; r++ {
+		rot := threefryc.rot[r%8]
+		// Mix the word pairs (0,1) and (2,3) in even rounds and (0,3) and (2,1) in odd rounds
+		if r%2 == 0 {
+			x[0] += x[1]
+			x[1] = bits.RotateLeft64(x[1], int(rot[0])) ^ x[0]
+			x[2] += x[3]
+			x[3] = bits.RotateLeft64(x[3], int(rot[1])) ^ x[2]
+		} else {
+			x[0] += x[3]
+			x[3] = bits.RotateLeft64(x[3], int(rot[0])) ^ x[0]
+			x[2] += x[1]
+			x[1] = bits.RotateLeft64(x[1], int(rot[1])) ^ x[2]
+		}
+		// Key injection after each fourth round
+		if r%4 == 3 {
+			s := uint64(r/4 + 1)
+			for i := range x {
+				x[i] += ks[(s+uint64(i))%5]
+			}
+			x[3] += s
+		}
+	}
+	return x
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler and returns the state of the ThreefrySource in a versioned binary format.
+func (src *ThreefrySource) MarshalBinary() ([]byte, error) {
+	// Create binary format with header
+	b := marshalHeader(marshalThreefry, 48)
+	// Append key, stream id and counter
+	for _, v := range src.key {
+		b = binary.BigEndian.AppendUint64(b, v)
+	}
+	b = binary.BigEndian.AppendUint64(b, src.stream)
+	b = binary.BigEndian.AppendUint64(b, src.n)
+	// Append checksum and return binary format
+	return marshalChecksum(b), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler and restores the state of the ThreefrySource from data in the binary format
+// returned by MarshalBinary. It returns an error, if data is corrupted, has an unsupported version or belongs to another type
+// of source. In case of an error, the state is not changed.
+func (src *ThreefrySource) UnmarshalBinary(data []byte) error {
+	// Validate data and retrieve reader on state
+	r, e := unmarshalState(data, marshalThreefry, 48)
+	if e != nil {
+		return e
+	}
+	// Restore key and stream id and set the counter, which computes the current block
+	for i := range src.key {
+		src.key[i] = r.uint64()
+	}
+	src.stream = r.uint64()
+	src.SetCounter(r.uint64())
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler and returns the state of the ThreefrySource in the binary format
+// returned by MarshalBinary encoded with standard base64 encoding.
+func (src *ThreefrySource) MarshalText() ([]byte, error) {
+	return marshalText(src)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler and restores the state of the ThreefrySource from text
+// returned by MarshalText. It returns an error, if text is invalid. In case of an error, the state is not changed.
+func (src *ThreefrySource) UnmarshalText(text []byte) error {
+	return unmarshalText(src, marshalTypes[marshalThreefry], text)
+}
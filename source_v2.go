@@ -0,0 +1,79 @@
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tsrand
+
+// Import standard library packages and tserr
+import (
+	"encoding/binary"     // encoding/binary
+	"fmt"                 // fmt
+	randv2 "math/rand/v2" // math/rand/v2
+
+	"github.com/thorstenrie/tserr" // tserr
+)
+
+// v2Source implements Source and wraps a math/rand/v2 Source, e.g., PCG or ChaCha8. It holds the wrapped
+// source src and the last occurring error, if any. A v2Source is safe for concurrent use by multiple goroutines
+// only if the wrapped source is.
+type v2Source struct {
+	src randv2.Source // wrapped math/rand/v2 source
+	e   error         // last error occurring, if any
+}
+
+// NewSourceFromV2 returns a new Source, which wraps the math/rand/v2 Source src. The returned Source can be used
+// with New, NewV2, NewLockedSource or as parent of a Pool. Seed is supported for *randv2.PCG and *randv2.ChaCha8 and
+// initializes them with the SeedExpander. For other wrapped sources, Seed is not supported and a subsequent call of Err
+// returns an error. If src is nil, Assert records an error.
+func NewSourceFromV2(src randv2.Source) Source {
+	return &v2Source{src: src}
+}
+
+// Seed initializes the wrapped source with the expanded seed s, if it is a *randv2.PCG or *randv2.ChaCha8.
+// Otherwise, the wrapped source is not changed and a subsequent call of Err returns an error.
+func (v *v2Source) Seed(s int64) {
+	// Expand seed s
+	e := NewSeedExpander(s)
+	switch src := v.src.(type) {
+	case *randv2.PCG:
+		// Seed PCG with two words of e
+		src.Seed(e.Uint64(), e.Uint64())
+	case *randv2.ChaCha8:
+		// Seed ChaCha8 with a key of four words of e
+		var key [32]byte
+		for i := 0; i < len(key); i += 8 {
+			binary.LittleEndian.PutUint64(key[i:], e.Uint64())
+		}
+		src.Seed(key)
+	default:
+		// Seed is not supported by the wrapped source
+		v.e = tserr.NotExistent(fmt.Sprintf("Seed of %T", v.src))
+	}
+}
+
+// Uint64 returns a random 64-bit value of the wrapped source. If the wrapped source is nil, it returns 0.
+func (v *v2Source) Uint64() uint64 {
+	// Return 0, if the wrapped source is nil
+	if v.src == nil {
+		return 0
+	}
+	// Return random 64-bit value of wrapped source
+	return v.src.Uint64()
+}
+
+// Int63 returns a random 63-bit integer.
+func (v *v2Source) Int63() int64 {
+	return int64(v.Uint64() >> 1)
+}
+
+// Assert checks the availability of the wrapped source. A subsequent call of Err returns an error, if the wrapped source is nil.
+func (v *v2Source) Assert() {
+	if v.src == nil {
+		v.e = tserr.NilPtr()
+	}
+}
+
+// Err provides the last occurring error of the source, if any.
+// It returns nil, if no error occurrred.
+func (v *v2Source) Err() error {
+	return v.e
+}
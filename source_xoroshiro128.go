@@ -0,0 +1,154 @@
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tsrand
+
+// Import standard library packages
+import (
+	"encoding/binary" // encoding/binary
+	"math/bits"       // math/bits
+)
+
+// Xoroshiro128PlusPlusSource implements Source64 and can be used as source for a rand.Rand. It is based on the
+// reference implementation xoroshiro128plusplus.c of the xoroshiro128++ generator by David Blackman and Sebastiano Vigna.
+// Xoroshiro128PlusPlusSource holds the 128-bit pseudo-random number generator internal state s. With Jump and LongJump
+// the state can be advanced by 2^64 and 2^96 steps to retrieve non-overlapping subsequences for parallel computations.
+// A Xoroshiro128PlusPlusSource is not safe for concurrent use by multiple goroutines. The output might be easily
+// predictable and is unsuitable for security-sensitive services.
+type Xoroshiro128PlusPlusSource struct {
+	s [2]uint64 // state
+}
+
+// Jump polynomials based on the reference implementation of xoroshiro128++
+var (
+	xoroshiro128c = struct {
+		jump, longJump [2]uint64
+	}{
+		jump:     [2]uint64{0x2bd7a6a6e99c2ddc, 0x0992ccaf6a6fca05}, // 2^64 steps
+		longJump: [2]uint64{0x360fd5f2cf8d5d99, 0x9c6e6877736c46e3}, // 2^96 steps
+	}
+)
+
+// NewXoroshiro128PlusPlusSource returns a new instance of Xoroshiro128PlusPlusSource initialized with the default seed.
+// Xoroshiro128PlusPlusSource implements Source64, is based on the reference implementation of xoroshiro128++ and can be used
+// as source for a rand.Rand. A Xoroshiro128PlusPlusSource is not safe for concurrent use by multiple goroutines. The output
+// might be easily predictable and is unsuitable for security-sensitive services.
+func NewXoroshiro128PlusPlusSource() *Xoroshiro128PlusPlusSource {
+	src := &Xoroshiro128PlusPlusSource{}
+	src.Seed(defaultSeed)
+	return src
+}
+
+// Seed initializes the state with seed s. As recommended by the authors, the state
+// is filled with the output of a splitmix64 generator seeded with s.
+func (src *Xoroshiro128PlusPlusSource) Seed(s int64) {
+	// Initialization of the state with the expanded seed s
+	NewSeedExpander(s).Fill(src.s[:])
+}
+
+// SeedBytes initializes the state with seed b. The seed b may have an arbitrary length.
+func (src *Xoroshiro128PlusPlusSource) SeedBytes(b []byte) {
+	// Initialization of the state with the expanded seed b
+	NewSeedExpanderBytes(b).Fill(src.s[:])
+}
+
+// next advances the state by one step.
+func (src *Xoroshiro128PlusPlusSource) next() {
+	s0, s1 := src.s[0], src.s[1]
+	s1 ^= s0
+	src.s[0] = bits.RotateLeft64(s0, 49) ^ s1 ^ (s1 << 21)
+	src.s[1] = bits.RotateLeft64(s1, 28)
+}
+
+// Uint64 returns a pseudo-random 64-bit value. The implementation is
+// based on xoroshiro128plusplus.c
+func (src *Xoroshiro128PlusPlusSource) Uint64() uint64 {
+	result := bits.RotateLeft64(src.s[0]+src.s[1], 17) + src.s[0]
+	src.next()
+	return result
+}
+
+// Int63 returns a pseudo-random 63-bit integer.
+func (src *Xoroshiro128PlusPlusSource) Int63() int64 {
+	return int64(src.Uint64() >> 1)
+}
+
+// Jump advances the state by 2^64 steps. It is equivalent to 2^64 calls to Uint64. It can be used
+// to generate 2^64 non-overlapping subsequences for parallel computations.
+func (src *Xoroshiro128PlusPlusSource) Jump() {
+	src.jump(&xoroshiro128c.jump)
+}
+
+// LongJump advances the state by 2^96 steps. It is equivalent to 2^96 calls to Uint64. It can be used
+// to generate 2^32 starting points, from each of which Jump generates 2^32 non-overlapping subsequences.
+func (src *Xoroshiro128PlusPlusSource) LongJump() {
+	src.jump(&xoroshiro128c.longJump)
+}
+
+// jump advances the state by the number of steps defined by the jump polynomial j.
+func (src *Xoroshiro128PlusPlusSource) jump(j *[2]uint64) {
+	var t [2]uint64
+	for _, w := range j {
+		for b := 0; b < 64; b++ {
+			if w&(uint64(1)<<b) != 0 {
+				t[0] ^= src.s[0]
+				t[1] ^= src.s[1]
+			}
+			src.next()
+		}
+	}
+	src.s = t
+}
+
+// Err provides the last occurring error of the random number generator source. Since
+// no used operation of Xoroshiro128PlusPlusSource returns an error, Err always returns nil.
+func (src *Xoroshiro128PlusPlusSource) Err() error {
+	return nil
+}
+
+// Assert checks the availability of a random number generator source. For Xoroshiro128PlusPlusSource, it is empty,
+// because the pseudo random number calculation is always available.
+func (src *Xoroshiro128PlusPlusSource) Assert() {}
+
+// MarshalBinary implements encoding.BinaryMarshaler and returns the state of the Xoroshiro128PlusPlusSource in a versioned binary format.
+func (src *Xoroshiro128PlusPlusSource) MarshalBinary() ([]byte, error) {
+	// Create binary format with header
+	b := marshalHeader(marshalXoroshiro128PlusPlus, 16)
+	// Append state
+	b = binary.BigEndian.AppendUint64(b, src.s[0])
+	b = binary.BigEndian.AppendUint64(b, src.s[1])
+	// Append checksum and return binary format
+	return marshalChecksum(b), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler and restores the state of the Xoroshiro128PlusPlusSource from data in the binary format
+// returned by MarshalBinary. It returns an error, if data is corrupted, has an unsupported version, belongs to another type
+// of source or contains an invalid state. In case of an error, the state is not changed.
+func (src *Xoroshiro128PlusPlusSource) UnmarshalBinary(data []byte) error {
+	// Validate data and retrieve reader on state
+	r, e := unmarshalState(data, marshalXoroshiro128PlusPlus, 16)
+	if e != nil {
+		return e
+	}
+	// Read state
+	s := [2]uint64{r.uint64(), r.uint64()}
+	// Return an error, if the state is zero
+	if s == [2]uint64{} {
+		return unmarshalInvalid(marshalTypes[marshalXoroshiro128PlusPlus], "zero state")
+	}
+	// Restore state
+	src.s = s
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler and returns the state of the Xoroshiro128PlusPlusSource in the binary format
+// returned by MarshalBinary encoded with standard base64 encoding.
+func (src *Xoroshiro128PlusPlusSource) MarshalText() ([]byte, error) {
+	return marshalText(src)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler and restores the state of the Xoroshiro128PlusPlusSource from text
+// returned by MarshalText. It returns an error, if text is invalid. In case of an error, the state is not changed.
+func (src *Xoroshiro128PlusPlusSource) UnmarshalText(text []byte) error {
+	return unmarshalText(src, marshalTypes[marshalXoroshiro128PlusPlus], text)
+}
@@ -0,0 +1,287 @@
+// Copyright (c) 2023 thorstenrie
+// All rights reserved. Use is governed with GNU Affero General Public License v3.0
+// that can be found in the LICENSE file.
+package tsrand
+
+// Import standard library packages
+import (
+	"encoding/binary" // encoding/binary
+	"math/bits"       // math/bits
+)
+
+// Xoshiro256StarStarSource implements Source64 and can be used as source for a rand.Rand. It is based on the
+// reference implementation xoshiro256starstar.c of the xoshiro256** generator by David Blackman and Sebastiano Vigna.
+// Xoshiro256StarStarSource holds the 256-bit pseudo-random number generator internal state s. With Jump and LongJump
+// the state can be advanced by 2^128 and 2^192 steps to retrieve non-overlapping subsequences for parallel computations.
+// A Xoshiro256StarStarSource is not safe for concurrent use by multiple goroutines. The output might be easily
+// predictable and is unsuitable for security-sensitive services.
+type Xoshiro256StarStarSource struct {
+	s [4]uint64 // state
+}
+
+// Xoshiro256PlusSource implements Source64 and can be used as source for a rand.Rand. It is based on the
+// reference implementation xoshiro256plus.c of the xoshiro256+ generator by David Blackman and Sebastiano Vigna.
+// The lowest bits of the output have low linear complexity. Therefore, it is primarily suitable for the generation of
+// floating-point numbers, which use the upper bits. Xoshiro256PlusSource holds the 256-bit pseudo-random number generator
+// internal state s. With Jump and LongJump the state can be advanced by 2^128 and 2^192 steps to retrieve non-overlapping
+// subsequences for parallel computations. A Xoshiro256PlusSource is not safe for concurrent use by multiple goroutines.
+// The output might be easily predictable and is unsuitable for security-sensitive services.
+type Xoshiro256PlusSource struct {
+	s [4]uint64 // state
+}
+
+// Jump polynomials based on the reference implementation of xoshiro256
+var (
+	xoshiro256c = struct {
+		jump, longJump [4]uint64
+	}{
+		jump:     [4]uint64{0x180ec6d33cfd0aba, 0xd5a61266f0c9392c, 0xa9582618e03fc9aa, 0x39abdc4529b1661c}, // 2^128 steps
+		longJump: [4]uint64{0x76e15d3efefdcbbf, 0xc5004e441c522fb3, 0x77710069854ee241, 0x39109bb02acbe635}, // 2^192 steps
+	}
+)
+
+// NewXoshiro256StarStarSource returns a new instance of Xoshiro256StarStarSource initialized with the default seed.
+// Xoshiro256StarStarSource implements Source64, is based on the reference implementation of xoshiro256** and can be used
+// as source for a rand.Rand. A Xoshiro256StarStarSource is not safe for concurrent use by multiple goroutines. The output
+// might be easily predictable and is unsuitable for security-sensitive services.
+func NewXoshiro256StarStarSource() *Xoshiro256StarStarSource {
+	src := &Xoshiro256StarStarSource{}
+	src.Seed(defaultSeed)
+	return src
+}
+
+// Seed initializes the state with seed s. As recommended by the authors, the state
+// is filled with the output of a splitmix64 generator seeded with s.
+func (src *Xoshiro256StarStarSource) Seed(s int64) {
+	// Initialization of the state with the expanded seed s
+	NewSeedExpander(s).Fill(src.s[:])
+}
+
+// SeedBytes initializes the state with seed b. The seed b may have an arbitrary length.
+func (src *Xoshiro256StarStarSource) SeedBytes(b []byte) {
+	// Initialization of the state with the expanded seed b
+	NewSeedExpanderBytes(b).Fill(src.s[:])
+}
+
+// Uint64 returns a pseudo-random 64-bit value. The implementation is
+// based on xoshiro256starstar.c
+func (src *Xoshiro256StarStarSource) Uint64() uint64 {
+	result := bits.RotateLeft64(src.s[1]*5, 7) * 9
+	xoshiro256Next(&src.s)
+	return result
+}
+
+// Int63 returns a pseudo-random 63-bit integer.
+func (src *Xoshiro256StarStarSource) Int63() int64 {
+	return int64(src.Uint64() >> 1)
+}
+
+// Jump advances the state by 2^128 steps. It is equivalent to 2^128 calls to Uint64. It can be used
+// to generate 2^128 non-overlapping subsequences for parallel computations.
+func (src *Xoshiro256StarStarSource) Jump() {
+	xoshiro256Jump(&src.s, &xoshiro256c.jump)
+}
+
+// LongJump advances the state by 2^192 steps. It is equivalent to 2^192 calls to Uint64. It can be used
+// to generate 2^64 starting points, from each of which Jump generates 2^64 non-overlapping subsequences.
+func (src *Xoshiro256StarStarSource) LongJump() {
+	xoshiro256Jump(&src.s, &xoshiro256c.longJump)
+}
+
+// Err provides the last occurring error of the random number generator source. Since
+// no used operation of Xoshiro256StarStarSource returns an error, Err always returns nil.
+func (src *Xoshiro256StarStarSource) Err() error {
+	return nil
+}
+
+// Assert checks the availability of a random number generator source. For Xoshiro256StarStarSource, it is empty,
+// because the pseudo random number calculation is always available.
+func (src *Xoshiro256StarStarSource) Assert() {}
+
+// NewXoshiro256PlusSource returns a new instance of Xoshiro256PlusSource initialized with the default seed.
+// Xoshiro256PlusSource implements Source64, is based on the reference implementation of xoshiro256+ and can be used
+// as source for a rand.Rand. A Xoshiro256PlusSource is not safe for concurrent use by multiple goroutines. The output
+// might be easily predictable and is unsuitable for security-sensitive services.
+func NewXoshiro256PlusSource() *Xoshiro256PlusSource {
+	src := &Xoshiro256PlusSource{}
+	src.Seed(defaultSeed)
+	return src
+}
+
+// Seed initializes the state with seed s. As recommended by the authors, the state
+// is filled with the output of a splitmix64 generator seeded with s.
+func (src *Xoshiro256PlusSource) Seed(s int64) {
+	// Initialization of the state with the expanded seed s
+	NewSeedExpander(s).Fill(src.s[:])
+}
+
+// SeedBytes initializes the state with seed b. The seed b may have an arbitrary length.
+func (src *Xoshiro256PlusSource) SeedBytes(b []byte) {
+	// Initialization of the state with the expanded seed b
+	NewSeedExpanderBytes(b).Fill(src.s[:])
+}
+
+// Uint64 returns a pseudo-random 64-bit value. The implementation is
+// based on xoshiro256plus.c
+func (src *Xoshiro256PlusSource) Uint64() uint64 {
+	result := src.s[0] + src.s[3]
+	xoshiro256Next(&src.s)
+	return result
+}
+
+// Int63 returns a pseudo-random 63-bit integer. It uses the upper 63 bits of Uint64.
+func (src *Xoshiro256PlusSource) Int63() int64 {
+	return int64(src.Uint64() >> 1)
+}
+
+// Jump advances the state by 2^128 steps. It is equivalent to 2^128 calls to Uint64. It can be used
+// to generate 2^128 non-overlapping subsequences for parallel computations.
+func (src *Xoshiro256PlusSource) Jump() {
+	xoshiro256Jump(&src.s, &xoshiro256c.jump)
+}
+
+// LongJump advances the state by 2^192 steps. It is equivalent to 2^192 calls to Uint64. It can be used
+// to generate 2^64 starting points, from each of which Jump generates 2^64 non-overlapping subsequences.
+func (src *Xoshiro256PlusSource) LongJump() {
+	xoshiro256Jump(&src.s, &xoshiro256c.longJump)
+}
+
+// Err provides the last occurring error of the random number generator source. Since
+// no used operation of Xoshiro256PlusSource returns an error, Err always returns nil.
+func (src *Xoshiro256PlusSource) Err() error {
+	return nil
+}
+
+// Assert checks the availability of a random number generator source. For Xoshiro256PlusSource, it is empty,
+// because the pseudo random number calculation is always available.
+func (src *Xoshiro256PlusSource) Assert() {}
+
+// xoshiro256Next advances the state s by one step. The linear engine is shared by
+// xoshiro256** and xoshiro256+, which only differ in their output function.
+func xoshiro256Next(s *[4]uint64) {
+	t := s[1] << 17
+	s[2] ^= s[0]
+	s[3] ^= s[1]
+	s[1] ^= s[2]
+	s[0] ^= s[3]
+	s[2] ^= t
+	s[3] = bits.RotateLeft64(s[3], 45)
+}
+
+// xoshiro256Jump advances the state s by the number of steps defined by the jump polynomial j.
+func xoshiro256Jump(s *[4]uint64, j *[4]uint64) {
+	var t [4]uint64
+	for _, w := range j {
+		for b := 0; b < 64; b++ {
+			if w&(uint64(1)<<b) != 0 {
+				t[0] ^= s[0]
+				t[1] ^= s[1]
+				t[2] ^= s[2]
+				t[3] ^= s[3]
+			}
+			xoshiro256Next(s)
+		}
+	}
+	*s = t
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler and returns the state of the Xoshiro256StarStarSource in a versioned binary format.
+func (src *Xoshiro256StarStarSource) MarshalBinary() ([]byte, error) {
+	// Create binary format with header
+	b := marshalHeader(marshalXoshiro256StarStar, 32)
+	// Append state
+	for _, v := range src.s {
+		b = binary.BigEndian.AppendUint64(b, v)
+	}
+	// Append checksum and return binary format
+	return marshalChecksum(b), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler and restores the state of the Xoshiro256StarStarSource from data in the binary format
+// returned by MarshalBinary. It returns an error, if data is corrupted, has an unsupported version, belongs to another type
+// of source or contains an invalid state. In case of an error, the state is not changed.
+func (src *Xoshiro256StarStarSource) UnmarshalBinary(data []byte) error {
+	// Validate data and retrieve reader on state
+	r, e := unmarshalState(data, marshalXoshiro256StarStar, 32)
+	if e != nil {
+		return e
+	}
+	// Read state and return an error, if the state is zero
+	s, e := xoshiro256Unmarshal(r, marshalTypes[marshalXoshiro256StarStar])
+	if e != nil {
+		return e
+	}
+	// Restore state
+	src.s = s
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler and returns the state of the Xoshiro256StarStarSource in the binary format
+// returned by MarshalBinary encoded with standard base64 encoding.
+func (src *Xoshiro256StarStarSource) MarshalText() ([]byte, error) {
+	return marshalText(src)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler and restores the state of the Xoshiro256StarStarSource from text
+// returned by MarshalText. It returns an error, if text is invalid. In case of an error, the state is not changed.
+func (src *Xoshiro256StarStarSource) UnmarshalText(text []byte) error {
+	return unmarshalText(src, marshalTypes[marshalXoshiro256StarStar], text)
+}
+
+// MarshalBinary implements encoding.BinaryMarshaler and returns the state of the Xoshiro256PlusSource in a versioned binary format.
+func (src *Xoshiro256PlusSource) MarshalBinary() ([]byte, error) {
+	// Create binary format with header
+	b := marshalHeader(marshalXoshiro256Plus, 32)
+	// Append state
+	for _, v := range src.s {
+		b = binary.BigEndian.AppendUint64(b, v)
+	}
+	// Append checksum and return binary format
+	return marshalChecksum(b), nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler and restores the state of the Xoshiro256PlusSource from data in the binary format
+// returned by MarshalBinary. It returns an error, if data is corrupted, has an unsupported version, belongs to another type
+// of source or contains an invalid state. In case of an error, the state is not changed.
+func (src *Xoshiro256PlusSource) UnmarshalBinary(data []byte) error {
+	// Validate data and retrieve reader on state
+	r, e := unmarshalState(data, marshalXoshiro256Plus, 32)
+	if e != nil {
+		return e
+	}
+	// Read state and return an error, if the state is zero
+	s, e := xoshiro256Unmarshal(r, marshalTypes[marshalXoshiro256Plus])
+	if e != nil {
+		return e
+	}
+	// Restore state
+	src.s = s
+	return nil
+}
+
+// MarshalText implements encoding.TextMarshaler and returns the state of the Xoshiro256PlusSource in the binary format
+// returned by MarshalBinary encoded with standard base64 encoding.
+func (src *Xoshiro256PlusSource) MarshalText() ([]byte, error) {
+	return marshalText(src)
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler and restores the state of the Xoshiro256PlusSource from text
+// returned by MarshalText. It returns an error, if text is invalid. In case of an error, the state is not changed.
+func (src *Xoshiro256PlusSource) UnmarshalText(text []byte) error {
+	return unmarshalText(src, marshalTypes[marshalXoshiro256Plus], text)
+}
+
+// xoshiro256Unmarshal reads the state of a xoshiro256 source named name from r. It returns an error, if the state is zero.
+func xoshiro256Unmarshal(r *stateReader, name string) ([4]uint64, error) {
+	// Read state
+	var s [4]uint64
+	for i := range s {
+		s[i] = r.uint64()
+	}
+	// Return an error, if the state is zero
+	if s == [4]uint64{} {
+		return s, unmarshalInvalid(name, "zero state")
+	}
+	// Return state
+	return s, nil
+}